@@ -0,0 +1,378 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package nuget
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/huhouhua/go-nuget/config"
+
+	nugetVersion "github.com/huhouhua/go-nuget/version"
+)
+
+// ErrPackageSourceNotMapped The package source mapping does not allow the package id from any of the sources.
+var ErrPackageSourceNotMapped = errors.New("package is not mapped to any package source")
+
+// SourceRepository A named package source of an AggregateClient
+type SourceRepository struct {
+	Name   string
+	Client *Client
+}
+
+// AggregateClient fans requests out to several package sources and merges the results.
+// Sources are kept in priority order, the first source serving a package version wins.
+type AggregateClient struct {
+	sources []*SourceRepository
+
+	// mapping restricts the sources a package id may come from, nil when disabled.
+	mapping *config.PackageSourceMapping
+
+	// ignoreFailedSources skips sources that fail instead of returning their error.
+	ignoreFailedSources bool
+}
+
+// AggregateOptionFunc can be used to customize a new AggregateClient.
+type AggregateOptionFunc func(*AggregateClient) error
+
+// WithPackageSourceMapping restricts the sources a package id is resolved from.
+// Ex: Contoso.* mapped to the internal feed is never resolved from nuget.org
+func WithPackageSourceMapping(mapping *config.PackageSourceMapping) AggregateOptionFunc {
+	return func(a *AggregateClient) error {
+		a.mapping = mapping
+		return nil
+	}
+}
+
+// WithIgnoreFailedSources skips the sources that fail instead of failing the whole request.
+func WithIgnoreFailedSources() AggregateOptionFunc {
+	return func(a *AggregateClient) error {
+		a.ignoreFailedSources = true
+		return nil
+	}
+}
+
+// NewAggregateClient returns a client over the given sources, in priority order.
+func NewAggregateClient(sources []*SourceRepository, options ...AggregateOptionFunc) (*AggregateClient, error) {
+	a := &AggregateClient{}
+	for _, source := range sources {
+		if source == nil || source.Client == nil {
+			return nil, fmt.Errorf("source client is required")
+		}
+		if source.Name == "" {
+			return nil, fmt.Errorf("source name is required")
+		}
+		if _, ok := a.Source(source.Name); ok {
+			return nil, fmt.Errorf("duplicate source %s", source.Name)
+		}
+		a.sources = append(a.sources, source)
+	}
+	for _, fn := range options {
+		if fn == nil {
+			continue
+		}
+		if err := fn(a); err != nil {
+			return nil, err
+		}
+	}
+	return a, nil
+}
+
+// NewAggregateClientFromSettings returns a client over the enabled http sources of a NuGet.config,
+// honoring its package source mapping. Client options are applied to every source. When some sources fail,
+// the client over the other sources is returned together with the joined errors of the failed sources.
+func NewAggregateClientFromSettings(
+	settings *config.Settings,
+	options ...ClientOptionFunc,
+) (*AggregateClient, error) {
+	clients, sourcesErr := NewClientsFromSettings(settings, options...)
+	if len(clients) == 0 && sourcesErr != nil {
+		return nil, sourcesErr
+	}
+	var sources []*SourceRepository
+	for _, source := range settings.EnabledPackageSources() {
+		if client, ok := clients[source.Name]; ok {
+			sources = append(sources, &SourceRepository{Name: source.Name, Client: client})
+		}
+	}
+	client, err := NewAggregateClient(sources, WithPackageSourceMapping(settings.PackageSourceMapping()))
+	if err != nil {
+		return nil, err
+	}
+	return client, sourcesErr
+}
+
+// Sources Returns the sources of the client, in priority order.
+func (a *AggregateClient) Sources() []*SourceRepository {
+	return append([]*SourceRepository(nil), a.sources...)
+}
+
+// Source Returns the source with the given name.
+func (a *AggregateClient) Source(name string) (*SourceRepository, bool) {
+	for _, source := range a.sources {
+		if strings.EqualFold(source.Name, name) {
+			return source, true
+		}
+	}
+	return nil, false
+}
+
+// SourcesFor Returns the sources a package id may be resolved from, in priority order.
+func (a *AggregateClient) SourcesFor(id string) []*SourceRepository {
+	if a.mapping == nil || !a.mapping.IsEnabled() {
+		return a.Sources()
+	}
+	var sources []*SourceRepository
+	for _, source := range a.sources {
+		if a.isMapped(id, source.Name) {
+			sources = append(sources, source)
+		}
+	}
+	return sources
+}
+
+// isMapped True if the package id may be resolved from the source.
+func (a *AggregateClient) isMapped(id, source string) bool {
+	if a.mapping == nil || !a.mapping.IsEnabled() {
+		return true
+	}
+	for _, name := range a.mapping.GetConfiguredPackageSources(id) {
+		if strings.EqualFold(name, source) {
+			return true
+		}
+	}
+	return false
+}
+
+// SourceVersion A package version and the sources serving it, in priority order.
+type SourceVersion struct {
+	*nugetVersion.Version
+
+	// Sources The sources serving the version, the first one is used
+	Sources []string
+}
+
+// Source Returns the source the version is resolved from.
+func (v *SourceVersion) Source() string {
+	return v.Sources[0]
+}
+
+// SourceVersionInfo A search result version and the sources serving it, in priority order.
+type SourceVersionInfo struct {
+	*VersionInfo
+
+	// Sources The sources serving the version, the first one is used
+	Sources []string
+}
+
+// AggregateSearchResult A package found on one or more sources.
+type AggregateSearchResult struct {
+	// Metadata The metadata of the package, from the first source that found it
+	Metadata *PackageSearchMetadata
+
+	// Sources The sources that found the package
+	Sources []string
+
+	// Versions The versions of the package across all sources
+	Versions []*SourceVersionInfo
+}
+
+// SourceMetadata The registration metadata of a package version and the source that served it.
+type SourceMetadata struct {
+	*PackageSearchMetadataRegistration
+
+	// Source The source the metadata was read from
+	Source string
+}
+
+// Search retrieves search results from every source, merged by package id.
+// Packages that the package source mapping doesn't allow from a source are dropped from its results.
+func (a *AggregateClient) Search(
+	opt *SearchOptions,
+	options ...RequestOptionFunc,
+) ([]*AggregateSearchResult, error) {
+	results := make([][]*PackageSearchMetadata, len(a.sources))
+	err := a.fanOut(a.sources, func(i int, source *SourceRepository) error {
+		data, _, err := source.Client.SearchResource.Search(opt, options...)
+		results[i] = data
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var merged []*AggregateSearchResult
+	byID := make(map[string]*AggregateSearchResult)
+	for i, data := range results {
+		name := a.sources[i].Name
+		for _, metadata := range data {
+			if metadata == nil || metadata.SearchMetadata == nil || !a.isMapped(metadata.PackageId, name) {
+				continue
+			}
+			key := strings.ToLower(metadata.PackageId)
+			result, ok := byID[key]
+			if !ok {
+				result = &AggregateSearchResult{Metadata: metadata}
+				byID[key] = result
+				merged = append(merged, result)
+			}
+			result.Sources = append(result.Sources, name)
+			for _, info := range metadata.Versions {
+				result.addVersion(info, name)
+			}
+		}
+	}
+	for _, result := range merged {
+		sort.SliceStable(result.Versions, func(i, j int) bool {
+			return lessVersionString(result.Versions[i].Version, result.Versions[j].Version)
+		})
+	}
+	return merged, nil
+}
+
+func (r *AggregateSearchResult) addVersion(info *VersionInfo, source string) {
+	if info == nil {
+		return
+	}
+	key := versionStringKey(info.Version)
+	for _, existing := range r.Versions {
+		if versionStringKey(existing.Version) == key {
+			existing.Sources = append(existing.Sources, source)
+			return
+		}
+	}
+	r.Versions = append(r.Versions, &SourceVersionInfo{VersionInfo: info, Sources: []string{source}})
+}
+
+// ListAllVersions gets all package versions for a package ID from every source it is mapped to,
+// sorted ascending and de-duplicated.
+func (a *AggregateClient) ListAllVersions(id string, options ...RequestOptionFunc) ([]*SourceVersion, error) {
+	sources := a.SourcesFor(id)
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("%s: %w", id, ErrPackageSourceNotMapped)
+	}
+	results := make([][]*nugetVersion.Version, len(sources))
+	err := a.fanOut(sources, func(i int, source *SourceRepository) error {
+		versions, _, err := source.Client.FindPackageResource.ListAllVersions(id, options...)
+		results[i] = versions
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var merged []*SourceVersion
+	byVersion := make(map[string]*SourceVersion)
+	for i, versions := range results {
+		for _, v := range versions {
+			key := versionKey(v)
+			if existing, ok := byVersion[key]; ok {
+				existing.Sources = append(existing.Sources, sources[i].Name)
+				continue
+			}
+			sv := &SourceVersion{Version: v, Sources: []string{sources[i].Name}}
+			byVersion[key] = sv
+			merged = append(merged, sv)
+		}
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		return compareVersion(merged[i].Version, merged[j].Version) < 0
+	})
+	return merged, nil
+}
+
+// ListMetadata List of package metadata from every source the package ID is mapped to,
+// sorted by version. A version served by several sources is read from the first one.
+func (a *AggregateClient) ListMetadata(
+	id string,
+	opt *ListMetadataOptions,
+	options ...RequestOptionFunc,
+) ([]*SourceMetadata, error) {
+	sources := a.SourcesFor(id)
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("%s: %w", id, ErrPackageSourceNotMapped)
+	}
+	results := make([][]*PackageSearchMetadataRegistration, len(sources))
+	err := a.fanOut(sources, func(i int, source *SourceRepository) error {
+		metadata, _, err := source.Client.MetadataResource.ListMetadata(id, opt, options...)
+		results[i] = metadata
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var merged []*SourceMetadata
+	seen := make(map[string]bool)
+	for i, list := range results {
+		for _, metadata := range list {
+			if metadata == nil || metadata.SearchMetadata == nil {
+				continue
+			}
+			key := versionStringKey(metadata.Version)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			merged = append(merged, &SourceMetadata{PackageSearchMetadataRegistration: metadata, Source: sources[i].Name})
+		}
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		return lessVersionString(merged[i].Version, merged[j].Version)
+	})
+	return merged, nil
+}
+
+// fanOut calls fn for every source concurrently. A source not knowing the package is not an error,
+// other errors are joined unless failed sources are ignored.
+func (a *AggregateClient) fanOut(sources []*SourceRepository, fn func(i int, source *SourceRepository) error) error {
+	errs := make([]error, len(sources))
+	var wg sync.WaitGroup
+	for i, source := range sources {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := fn(i, source); err != nil && !errors.Is(err, ErrNotFound) && !a.ignoreFailedSources {
+				errs[i] = fmt.Errorf("source %s: %w", source.Name, err)
+			}
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// versionKey identifies a version regardless of its original string and build metadata.
+// Ex: 1.0 and 1.0.0+build are the same version
+func versionKey(v *nugetVersion.Version) string {
+	return strings.ToLower(fmt.Sprintf("%d.%d.%d.%d-%s",
+		v.Semver.Major(), v.Semver.Minor(), v.Semver.Patch(), v.Revision, v.Semver.Prerelease()))
+}
+
+// versionStringKey versionKey of an unparsed version, invalid versions are compared as is.
+func versionStringKey(value string) string {
+	if v, err := nugetVersion.Parse(value); err == nil {
+		return versionKey(v)
+	}
+	return strings.ToLower(value)
+}
+
+func compareVersion(x, y *nugetVersion.Version) int {
+	if c := x.Semver.Compare(y.Semver); c != 0 {
+		return c
+	}
+	return x.Revision - y.Revision
+}
+
+func lessVersionString(x, y string) bool {
+	vx, errX := nugetVersion.Parse(x)
+	vy, errY := nugetVersion.Parse(y)
+	if errX != nil || errY != nil {
+		return x < y
+	}
+	return compareVersion(vx, vy) < 0
+}
@@ -0,0 +1,268 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package nuget
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/huhouhua/go-nuget/config"
+
+	"github.com/stretchr/testify/require"
+)
+
+// setupAggregate creates an aggregate client over a public and an internal source.
+func setupAggregate(t *testing.T, options ...AggregateOptionFunc) (*http.ServeMux, *http.ServeMux, *AggregateClient) {
+	publicMux, public := setup(t, index_V3)
+	internalMux, internal := setup(t, index_V3)
+	client, err := NewAggregateClient([]*SourceRepository{
+		{Name: "nuget.org", Client: public},
+		{Name: "internal", Client: internal},
+	}, options...)
+	require.NoError(t, err)
+	return publicMux, internalMux, client
+}
+
+// contosoMapping resolves Contoso.* from the internal source only, other packages from both sources.
+func contosoMapping() *config.PackageSourceMapping {
+	return config.NewPackageSourceMapping(
+		&config.PackageSourcePatterns{Source: "nuget.org", Patterns: []string{"*"}},
+		&config.PackageSourcePatterns{Source: "internal", Patterns: []string{"Contoso.*", "*"}},
+	)
+}
+
+func TestAggregateClient_ListAllVersions(t *testing.T) {
+	publicMux, internalMux, client := setupAggregate(t)
+
+	publicMux.HandleFunc("/v3-flatcontainer/newtonsoft.json/index.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"versions":["13.0.1","12.0.3","13.0.3"]}`)
+	})
+	internalMux.HandleFunc("/v3-flatcontainer/newtonsoft.json/index.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"versions":["13.0.1.0","14.0.0-internal"]}`)
+	})
+
+	versions, err := client.ListAllVersions("Newtonsoft.Json")
+	require.NoError(t, err)
+
+	type served struct {
+		Version string
+		Sources []string
+	}
+	actual := make([]served, 0, len(versions))
+	for _, v := range versions {
+		actual = append(actual, served{v.OriginalVersion, v.Sources})
+	}
+	require.Equal(t, []served{
+		{"12.0.3", []string{"nuget.org"}},
+		{"13.0.1", []string{"nuget.org", "internal"}},
+		{"13.0.3", []string{"nuget.org"}},
+		{"14.0.0-internal", []string{"internal"}},
+	}, actual)
+	require.Equal(t, "nuget.org", versions[1].Source())
+
+	// unknown on every source
+	versions, err = client.ListAllVersions("missing")
+	require.NoError(t, err)
+	require.Empty(t, versions)
+}
+
+func TestAggregateClient_ListAllVersions_PackageSourceMapping(t *testing.T) {
+	publicMux, internalMux, client := setupAggregate(t, WithPackageSourceMapping(contosoMapping()))
+
+	publicMux.HandleFunc("/v3-flatcontainer/contoso.lib/index.json", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Contoso.Lib must not be resolved from nuget.org")
+		fmt.Fprint(w, `{"versions":["99.0.0"]}`)
+	})
+	internalMux.HandleFunc("/v3-flatcontainer/contoso.lib/index.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"versions":["1.0.0"]}`)
+	})
+
+	versions, err := client.ListAllVersions("Contoso.Lib")
+	require.NoError(t, err)
+	require.Len(t, versions, 1)
+	require.Equal(t, "1.0.0", versions[0].OriginalVersion)
+	require.Equal(t, []string{"internal"}, versions[0].Sources)
+
+	require.Len(t, client.SourcesFor("Newtonsoft.Json"), 2)
+
+	mapped, err := NewAggregateClient(client.Sources(), WithPackageSourceMapping(
+		config.NewPackageSourceMapping(&config.PackageSourcePatterns{Source: "internal", Patterns: []string{"Contoso.*"}}),
+	))
+	require.NoError(t, err)
+	_, err = mapped.ListAllVersions("Newtonsoft.Json")
+	require.ErrorIs(t, err, ErrPackageSourceNotMapped)
+}
+
+func TestAggregateClient_ListAllVersions_FailedSource(t *testing.T) {
+	publicMux, internalMux, client := setupAggregate(t, WithIgnoreFailedSources())
+
+	publicMux.HandleFunc("/v3-flatcontainer/newtonsoft.json/index.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"versions":["13.0.3"]}`)
+	})
+	internalMux.HandleFunc("/v3-flatcontainer/newtonsoft.json/index.json", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+
+	versions, err := client.ListAllVersions("Newtonsoft.Json")
+	require.NoError(t, err)
+	require.Len(t, versions, 1)
+
+	strict, err := NewAggregateClient(client.Sources())
+	require.NoError(t, err)
+	_, err = strict.ListAllVersions("Newtonsoft.Json")
+	require.ErrorContains(t, err, "source internal: ")
+}
+
+func TestAggregateClient_Search(t *testing.T) {
+	publicMux, internalMux, client := setupAggregate(t, WithPackageSourceMapping(contosoMapping()))
+
+	publicMux.HandleFunc("/query", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"totalHits":2,"data":[
+			{"id":"Newtonsoft.Json","version":"13.0.3","versions":[{"version":"13.0.1"},{"version":"13.0.3"}]},
+			{"id":"Contoso.Lib","version":"99.0.0","versions":[{"version":"99.0.0"}]}
+		]}`)
+	})
+	internalMux.HandleFunc("/query", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"totalHits":2,"data":[
+			{"id":"newtonsoft.json","version":"13.0.1","versions":[{"version":"13.0.1"},{"version":"12.0.3"}]},
+			{"id":"Contoso.Lib","version":"1.0.0","versions":[{"version":"1.0.0"}]}
+		]}`)
+	})
+
+	results, err := client.Search(&SearchOptions{SearchTerm: "json"})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	require.Equal(t, "Newtonsoft.Json", results[0].Metadata.PackageId)
+	require.Equal(t, []string{"nuget.org", "internal"}, results[0].Sources)
+	versions := make(map[string][]string)
+	order := make([]string, 0)
+	for _, v := range results[0].Versions {
+		versions[v.Version] = v.Sources
+		order = append(order, v.Version)
+	}
+	require.Equal(t, []string{"12.0.3", "13.0.1", "13.0.3"}, order)
+	require.Equal(t, []string{"nuget.org", "internal"}, versions["13.0.1"])
+	require.Equal(t, []string{"internal"}, versions["12.0.3"])
+
+	// the public Contoso.Lib is dropped by the package source mapping
+	require.Equal(t, "Contoso.Lib", results[1].Metadata.PackageId)
+	require.Equal(t, "1.0.0", results[1].Metadata.Version)
+	require.Equal(t, []string{"internal"}, results[1].Sources)
+}
+
+func TestAggregateClient_ListMetadata(t *testing.T) {
+	publicMux, internalMux, client := setupAggregate(t)
+
+	registration := func(versions ...string) string {
+		items := ""
+		for i, v := range versions {
+			if i > 0 {
+				items += ","
+			}
+			items += fmt.Sprintf(`{"catalogEntry":{"id":"Newtonsoft.Json","version":%q,"listed":true}}`, v)
+		}
+		return fmt.Sprintf(`{"items":[{"lower":"0.0.0","upper":"99.0.0","items":[%s]}]}`, items)
+	}
+	publicMux.HandleFunc("/v3/registration5-gz-semver2/newtonsoft.json/index.json",
+		func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, registration("13.0.1", "13.0.3"))
+		})
+	internalMux.HandleFunc("/v3/registration5-gz-semver2/newtonsoft.json/index.json",
+		func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, registration("12.0.3", "13.0.1"))
+		})
+
+	metadata, err := client.ListMetadata("Newtonsoft.Json", &ListMetadataOptions{})
+	require.NoError(t, err)
+
+	type served struct {
+		Version string
+		Source  string
+	}
+	actual := make([]served, 0, len(metadata))
+	for _, m := range metadata {
+		actual = append(actual, served{m.Version, m.Source})
+	}
+	require.Equal(t, []served{
+		{"12.0.3", "internal"},
+		{"13.0.1", "nuget.org"},
+		{"13.0.3", "nuget.org"},
+	}, actual)
+}
+
+func TestNewAggregateClient(t *testing.T) {
+	_, client := setup(t, index_V3)
+
+	tests := []struct {
+		name    string
+		sources []*SourceRepository
+		wantErr error
+	}{
+		{
+			name:    "nil client",
+			sources: []*SourceRepository{{Name: "nuget.org"}},
+			wantErr: errors.New("source client is required"),
+		},
+		{
+			name:    "empty name",
+			sources: []*SourceRepository{{Client: client}},
+			wantErr: errors.New("source name is required"),
+		},
+		{
+			name:    "duplicate source",
+			sources: []*SourceRepository{{Name: "nuget.org", Client: client}, {Name: "NuGet.org", Client: client}},
+			wantErr: errors.New("duplicate source NuGet.org"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewAggregateClient(tt.sources)
+			require.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestNewAggregateClientFromSettings(t *testing.T) {
+	_, public := createHttpServer(t, index_V3)
+	_, internal := createHttpServer(t, index_V3)
+
+	settings := config.NewSettings()
+	require.NoError(t, settings.SetPackageSource(&config.PackageSource{
+		Name:                     "nuget.org",
+		Source:                   fmt.Sprintf("%s/v3/index.json", public.URL),
+		AllowInsecureConnections: true,
+		IsEnabled:                true,
+	}))
+	require.NoError(t, settings.SetPackageSource(&config.PackageSource{
+		Name:                     "internal",
+		Source:                   fmt.Sprintf("%s/v3/index.json", internal.URL),
+		AllowInsecureConnections: true,
+		IsEnabled:                true,
+	}))
+	settings.SetPackageSourceMapping("nuget.org", "*")
+	settings.SetPackageSourceMapping("internal", "Contoso.*")
+
+	client, err := NewAggregateClientFromSettings(settings)
+	require.NoError(t, err)
+	require.Len(t, client.Sources(), 2)
+	require.Equal(t, "nuget.org", client.Sources()[0].Name)
+
+	sources := client.SourcesFor("Contoso.Lib")
+	require.Len(t, sources, 1)
+	require.Equal(t, "internal", sources[0].Name)
+
+	require.NoError(t, settings.SetPackageSource(
+		config.NewPackageSource("insecure", fmt.Sprintf("%s/v3/index.json", public.URL))))
+	client, err = NewAggregateClientFromSettings(settings)
+	require.ErrorContains(t, err, "package source insecure uses http")
+	require.Len(t, client.Sources(), 2)
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package nuget
+
+import (
+	"fmt"
+	"net/http"
+
+	nugetVersion "github.com/huhouhua/go-nuget/version"
+)
+
+// AutocompleteResource Completes package ids and versions, for type-ahead.
+// Source: https://learn.microsoft.com/en-us/nuget/api/search-autocomplete-service-resource
+type AutocompleteResource struct {
+	client *Client
+}
+
+type autocompleteOptions struct {
+	Query       string `url:"q,omitempty"`
+	Id          string `url:"id,omitempty"`
+	Skip        int    `url:"skip,omitempty"`
+	Take        int    `url:"take,omitempty"`
+	Prerelease  bool   `url:"prerelease,omitempty"`
+	SemVerLevel string `url:"semVerLevel,omitempty"`
+}
+
+type autocompleteResult struct {
+	TotalHits uint64   `json:"totalHits"`
+	Data      []string `json:"data"`
+}
+
+// PackageIDs gets the package ids matching query, semVerLevel is 2.0.0 when empty.
+func (a *AutocompleteResource) PackageIDs(
+	query string,
+	skip, take int,
+	prerelease bool,
+	semVerLevel string,
+	options ...RequestOptionFunc,
+) ([]string, *http.Response, error) {
+	result, resp, err := a.autocomplete(&autocompleteOptions{
+		Query:       query,
+		Skip:        skip,
+		Take:        take,
+		Prerelease:  prerelease,
+		SemVerLevel: semVerLevel,
+	}, options)
+	if err != nil {
+		return nil, resp, err
+	}
+	return result.Data, resp, nil
+}
+
+// PackageVersions gets the listed versions of the package id.
+func (a *AutocompleteResource) PackageVersions(
+	id string,
+	prerelease bool,
+	options ...RequestOptionFunc,
+) ([]*nugetVersion.Version, *http.Response, error) {
+	packageId, err := parseID(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	result, resp, err := a.autocomplete(&autocompleteOptions{Id: packageId, Prerelease: prerelease}, options)
+	if err != nil {
+		return nil, resp, err
+	}
+	versions := make([]*nugetVersion.Version, 0, len(result.Data))
+	for _, v := range result.Data {
+		nv, err := nugetVersion.Parse(v)
+		if err != nil {
+			return nil, resp, err
+		}
+		versions = append(versions, nv)
+	}
+	return versions, resp, nil
+}
+
+func (a *AutocompleteResource) autocomplete(
+	opt *autocompleteOptions,
+	options []RequestOptionFunc,
+) (*autocompleteResult, *http.Response, error) {
+	baseURL := a.client.getResourceURL(SearchAutocompleteService)
+	if baseURL == nil {
+		return nil, nil, fmt.Errorf("the source does not support search autocomplete")
+	}
+	req, err := a.client.NewRequest(http.MethodGet, baseURL.Path, baseURL, opt, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	addSemVer(req.URL)
+	result := &autocompleteResult{}
+	resp, err := a.client.Do(req, result, DecoderTypeJSON)
+	if err != nil {
+		return nil, resp, err
+	}
+	return result, resp, nil
+}
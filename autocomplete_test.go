@@ -0,0 +1,70 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package nuget
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	nugetVersion "github.com/huhouhua/go-nuget/version"
+)
+
+func TestAutocompleteResource_PackageIDs(t *testing.T) {
+	mux, client := setup(t, index_V3)
+
+	baseURL := client.getResourceURL(SearchAutocompleteService)
+	mux.HandleFunc(baseURL.Path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		require.Equal(t, "prerelease=true&q=newt&semVerLevel=2.0.0&skip=5&take=2", r.URL.RawQuery)
+		mustWriteHTTPResponse(t, w, "testdata/autocomplete_package_ids.json")
+	})
+
+	ids, resp, err := client.AutocompleteResource.PackageIDs("newt", 5, 2, true, "")
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.Equal(t, []string{"Newtonsoft.Json", "Newtonsoft.Json.Bson"}, ids)
+}
+
+func TestAutocompleteResource_PackageIDs_SemVerLevel(t *testing.T) {
+	mux, client := setup(t, index_V3)
+
+	baseURL := client.getResourceURL(SearchAutocompleteService)
+	mux.HandleFunc(baseURL.Path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		require.Equal(t, "q=json&semVerLevel=1.0.0", r.URL.RawQuery)
+		mustWriteHTTPResponse(t, w, "testdata/autocomplete_empty.json")
+	})
+
+	ids, _, err := client.AutocompleteResource.PackageIDs("json", 0, 0, false, "1.0.0")
+	require.NoError(t, err)
+	require.Empty(t, ids)
+}
+
+func TestAutocompleteResource_PackageVersions(t *testing.T) {
+	mux, client := setup(t, index_V3)
+
+	baseURL := client.getResourceURL(SearchAutocompleteService)
+	mux.HandleFunc(baseURL.Path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		require.Equal(t, "id=newtonsoft.json&prerelease=true&semVerLevel=2.0.0", r.URL.RawQuery)
+		mustWriteHTTPResponse(t, w, "testdata/autocomplete_package_versions.json")
+	})
+
+	versions, resp, err := client.AutocompleteResource.PackageVersions("Newtonsoft.Json", true)
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	want := make([]*nugetVersion.Version, 0, 3)
+	for _, v := range []string{"12.0.3", "13.0.1", "13.0.4-beta1"} {
+		nv, err := nugetVersion.Parse(v)
+		require.NoError(t, err)
+		want = append(want, nv)
+	}
+	require.Equal(t, want, versions)
+
+	_, _, err = client.AutocompleteResource.PackageVersions(" ", false)
+	require.EqualError(t, err, "id is empty")
+}
@@ -0,0 +1,221 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package nuget
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/huhouhua/go-nuget/meta"
+)
+
+// CatalogLeafType The kind of change a catalog leaf records.
+type CatalogLeafType string
+
+const (
+	// CatalogPackageDetails A package version was pushed, edited, listed or unlisted.
+	CatalogPackageDetails CatalogLeafType = "PackageDetails"
+
+	// CatalogPackageDelete A package version was deleted.
+	CatalogPackageDelete CatalogLeafType = "PackageDelete"
+)
+
+// CatalogResource Reads the catalog, the log of all the package changes of a source.
+// Source: https://learn.microsoft.com/en-us/nuget/api/catalog-resource
+type CatalogResource struct {
+	client *Client
+}
+
+// CatalogIndex The entry point of the catalog, listing its pages.
+type CatalogIndex struct {
+	URL             string             `json:"@id"`
+	CommitId        string             `json:"commitId"`
+	CommitTimestamp time.Time          `json:"commitTimeStamp"`
+	Count           int                `json:"count"`
+	Items           []*CatalogPageItem `json:"items"`
+}
+
+// CatalogPageItem A page of the catalog index, CommitTimestamp is the latest commit of the page.
+type CatalogPageItem struct {
+	URL             string    `json:"@id"`
+	CommitId        string    `json:"commitId"`
+	CommitTimestamp time.Time `json:"commitTimeStamp"`
+	Count           int       `json:"count"`
+}
+
+// CatalogPage A page of the catalog, listing its leaves.
+type CatalogPage struct {
+	URL             string             `json:"@id"`
+	CommitId        string             `json:"commitId"`
+	CommitTimestamp time.Time          `json:"commitTimeStamp"`
+	Count           int                `json:"count"`
+	Parent          string             `json:"parent"`
+	Items           []*CatalogLeafItem `json:"items"`
+}
+
+// CatalogLeafItem A leaf of a catalog page.
+type CatalogLeafItem struct {
+	URL             string          `json:"@id"`
+	Type            CatalogLeafType `json:"@type"`
+	CommitId        string          `json:"commitId"`
+	CommitTimestamp time.Time       `json:"commitTimeStamp"`
+	PackageId       string          `json:"nuget:id"`
+	PackageVersion  string          `json:"nuget:version"`
+}
+
+func (i *CatalogLeafItem) UnmarshalJSON(data []byte) error {
+	type leafItem CatalogLeafItem
+	if err := json.Unmarshal(data, (*leafItem)(i)); err != nil {
+		return err
+	}
+	i.Type = CatalogLeafType(strings.TrimPrefix(string(i.Type), "nuget:"))
+	return nil
+}
+
+// CatalogLeaf The document of a catalog leaf. Deletions only hold the identity of the package and Published,
+// the time of the deletion.
+type CatalogLeaf struct {
+	URL              string                         `json:"@id"`
+	Types            []string                       `json:"-"`
+	CommitId         string                         `json:"catalog:commitId"`
+	CommitTimestamp  time.Time                      `json:"catalog:commitTimeStamp"`
+	PackageId        string                         `json:"id"`
+	PackageVersion   string                         `json:"version"`
+	VerbatimVersion  string                         `json:"verbatimVersion"`
+	Published        time.Time                      `json:"published"`
+	Created          time.Time                      `json:"created"`
+	LastEdited       time.Time                      `json:"lastEdited"`
+	Listed           *bool                          `json:"listed"`
+	IsPrerelease     bool                           `json:"isPrerelease"`
+	Authors          string                         `json:"authors"`
+	Title            string                         `json:"title"`
+	Description      string                         `json:"description"`
+	Summary          string                         `json:"summary"`
+	Tags             []string                       `json:"tags"`
+	ProjectURL       string                         `json:"projectUrl"`
+	LicenseURL       string                         `json:"licenseUrl"`
+	IconURL          string                         `json:"iconUrl"`
+	DependencyGroups []*meta.PackageDependencyGroup `json:"dependencyGroups"`
+
+	LicenseExpression        string `json:"licenseExpression"`
+	RequireLicenseAcceptance bool   `json:"requireLicenseAcceptance"`
+
+	// PackageHash The base64 hash of the nupkg.
+	PackageHash string `json:"packageHash"`
+
+	// PackageHashAlgorithm The algorithm of PackageHash, Ex: SHA512
+	PackageHashAlgorithm string `json:"packageHashAlgorithm"`
+
+	// PackageSize The size of the nupkg in bytes.
+	PackageSize int64 `json:"packageSize"`
+
+	Deprecation     *PackageDeprecationMetadata     `json:"deprecation"`
+	Vulnerabilities []*PackageVulnerabilityMetadata `json:"vulnerabilities"`
+}
+
+func (l *CatalogLeaf) UnmarshalJSON(data []byte) error {
+	type leaf CatalogLeaf
+	var raw struct {
+		*leaf
+		Types json.RawMessage `json:"@type"`
+	}
+	raw.leaf = (*leaf)(l)
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	// @type is a single type or an array of types.
+	l.Types = nil
+	if len(raw.Types) > 0 && raw.Types[0] == '[' {
+		return json.Unmarshal(raw.Types, &l.Types)
+	}
+	if len(raw.Types) > 0 {
+		var t string
+		if err := json.Unmarshal(raw.Types, &t); err != nil {
+			return err
+		}
+		l.Types = []string{t}
+	}
+	return nil
+}
+
+// Type Returns the kind of the leaf, empty when unknown.
+func (l *CatalogLeaf) Type() CatalogLeafType {
+	for _, t := range []CatalogLeafType{CatalogPackageDetails, CatalogPackageDelete} {
+		if slices.Contains(l.Types, string(t)) || slices.Contains(l.Types, "nuget:"+string(t)) {
+			return t
+		}
+	}
+	return ""
+}
+
+// IsListed True when the package is listed. Leaves older than the listed property
+// mark unlisted packages with a 1900 publish year.
+func (l *CatalogLeaf) IsListed() bool {
+	if l.Listed != nil {
+		return *l.Listed
+	}
+	return l.Published.Year() != 1900
+}
+
+// Identity Returns the id and version of the package.
+func (l *CatalogLeaf) Identity() (*meta.PackageIdentity, error) {
+	return meta.NewPackageIdentity(l.PackageId, l.PackageVersion)
+}
+
+// GetIndex retrieves the catalog index.
+func (c *CatalogResource) GetIndex(options ...RequestOptionFunc) (*CatalogIndex, *http.Response, error) {
+	baseURL := c.client.getResourceURL(Catalog)
+	if baseURL == nil {
+		return nil, nil, fmt.Errorf("the source does not support catalog")
+	}
+	index := &CatalogIndex{}
+	resp, err := c.get(baseURL, index, options)
+	if err != nil {
+		return nil, resp, err
+	}
+	return index, resp, nil
+}
+
+// GetPage retrieves the catalog page at pageURL.
+func (c *CatalogResource) GetPage(pageURL string, options ...RequestOptionFunc) (*CatalogPage, *http.Response, error) {
+	u, err := url.Parse(pageURL)
+	if err != nil {
+		return nil, nil, err
+	}
+	page := &CatalogPage{}
+	resp, err := c.get(u, page, options)
+	if err != nil {
+		return nil, resp, err
+	}
+	return page, resp, nil
+}
+
+// GetLeaf retrieves the catalog leaf at leafURL. The dependency ranges are left unparsed,
+// the catalog keeps the ranges of old packages as they were pushed.
+func (c *CatalogResource) GetLeaf(leafURL string, options ...RequestOptionFunc) (*CatalogLeaf, *http.Response, error) {
+	u, err := url.Parse(leafURL)
+	if err != nil {
+		return nil, nil, err
+	}
+	leaf := &CatalogLeaf{}
+	resp, err := c.get(u, leaf, options)
+	if err != nil {
+		return nil, resp, err
+	}
+	return leaf, resp, nil
+}
+
+func (c *CatalogResource) get(u *url.URL, v any, options []RequestOptionFunc) (*http.Response, error) {
+	req, err := c.client.NewRequest(http.MethodGet, u.Path, u, nil, options)
+	if err != nil {
+		return nil, err
+	}
+	return c.client.Do(req, v, DecoderTypeJSON)
+}
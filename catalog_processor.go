@@ -0,0 +1,243 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package nuget
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// CatalogCursor Persists the commit timestamp up to which the catalog was processed.
+// Load returns the zero time when nothing was processed yet.
+type CatalogCursor interface {
+	Load() (time.Time, error)
+	Save(value time.Time) error
+}
+
+// FileCatalogCursor A cursor stored in a JSON file, {"value": "<timestamp>"} like the NuGet catalog collectors.
+type FileCatalogCursor struct {
+	Path string
+}
+
+// NewFileCatalogCursor returns a cursor stored at path.
+func NewFileCatalogCursor(path string) *FileCatalogCursor {
+	return &FileCatalogCursor{Path: path}
+}
+
+type cursorFile struct {
+	Value time.Time `json:"value"`
+}
+
+// Load reads the cursor, the zero time when the file does not exist.
+func (c *FileCatalogCursor) Load() (time.Time, error) {
+	data, err := os.ReadFile(c.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	var cursor cursorFile
+	if err = json.Unmarshal(data, &cursor); err != nil {
+		return time.Time{}, fmt.Errorf("invalid cursor %s: %w", c.Path, err)
+	}
+	return cursor.Value, nil
+}
+
+// Save writes the cursor, through a temporary file so that a crash never leaves it truncated.
+func (c *FileCatalogCursor) Save(value time.Time) error {
+	data, err := json.Marshal(cursorFile{Value: value.UTC()})
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(c.Path), 0o755); err != nil {
+		return err
+	}
+	tmp := c.Path + ".tmp"
+	if err = os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, c.Path)
+}
+
+// MemoryCatalogCursor A cursor kept in memory.
+type MemoryCatalogCursor struct {
+	Value time.Time
+}
+
+func (c *MemoryCatalogCursor) Load() (time.Time, error) {
+	return c.Value, nil
+}
+
+func (c *MemoryCatalogCursor) Save(value time.Time) error {
+	c.Value = value
+	return nil
+}
+
+// CatalogLeafHandlerFunc handles a catalog leaf. An error stops the processing,
+// the cursor is left before the commit of the leaf so that it is handled again by the next run.
+type CatalogLeafHandlerFunc func(item *CatalogLeafItem, leaf *CatalogLeaf) error
+
+// CatalogProcessorOptions What part of the catalog a CatalogProcessor walks.
+type CatalogProcessorOptions struct {
+	// DefaultMinCommitTimestamp Where to start when the cursor has no value, the beginning of the catalog when zero.
+	DefaultMinCommitTimestamp time.Time
+
+	// MaxCommitTimestamp The last commit to process, the latest commit of the catalog when zero.
+	MaxCommitTimestamp time.Time
+
+	// ExcludeRedundantLeaves Handle only the latest leaf of each package version.
+	ExcludeRedundantLeaves bool
+}
+
+// CatalogProcessor Walks the catalog from a cursor and hands each leaf to the handlers of its type,
+// commit by commit like the NuGet catalog collectors.
+type CatalogProcessor struct {
+	resource *CatalogResource
+	cursor   CatalogCursor
+	opt      CatalogProcessorOptions
+	handlers map[CatalogLeafType][]CatalogLeafHandlerFunc
+}
+
+// NewProcessor returns a processor of the catalog resuming from cursor.
+func (c *CatalogResource) NewProcessor(cursor CatalogCursor, opt *CatalogProcessorOptions) *CatalogProcessor {
+	p := &CatalogProcessor{
+		resource: c,
+		cursor:   cursor,
+		handlers: make(map[CatalogLeafType][]CatalogLeafHandlerFunc),
+	}
+	if opt != nil {
+		p.opt = *opt
+	}
+	return p
+}
+
+// Handle registers fn for the leaves of leafType. Leaves are only downloaded when a handler is registered for them.
+func (p *CatalogProcessor) Handle(leafType CatalogLeafType, fn CatalogLeafHandlerFunc) *CatalogProcessor {
+	p.handlers[leafType] = append(p.handlers[leafType], fn)
+	return p
+}
+
+// OnPackageDetails registers fn for the pushed, edited, listed and unlisted package versions.
+func (p *CatalogProcessor) OnPackageDetails(fn CatalogLeafHandlerFunc) *CatalogProcessor {
+	return p.Handle(CatalogPackageDetails, fn)
+}
+
+// OnPackageDelete registers fn for the deleted package versions.
+func (p *CatalogProcessor) OnPackageDelete(fn CatalogLeafHandlerFunc) *CatalogProcessor {
+	return p.Handle(CatalogPackageDelete, fn)
+}
+
+// Process handles the leaves committed after the cursor, oldest commit first, and moves the cursor
+// after each commit. Returns the number of leaves handled.
+func (p *CatalogProcessor) Process(options ...RequestOptionFunc) (int, error) {
+	minTimestamp, err := p.cursor.Load()
+	if err != nil {
+		return 0, err
+	}
+	if minTimestamp.IsZero() {
+		minTimestamp = p.opt.DefaultMinCommitTimestamp
+	}
+	index, _, err := p.resource.GetIndex(options...)
+	if err != nil {
+		return 0, err
+	}
+	maxTimestamp := index.CommitTimestamp
+	if !p.opt.MaxCommitTimestamp.IsZero() && p.opt.MaxCommitTimestamp.Before(maxTimestamp) {
+		maxTimestamp = p.opt.MaxCommitTimestamp
+	}
+
+	items, err := p.leafItems(index, minTimestamp, maxTimestamp, options)
+	if err != nil {
+		return 0, err
+	}
+	handled := 0
+	for start := 0; start < len(items); {
+		commit := items[start].CommitTimestamp
+		end := start
+		for end < len(items) && items[end].CommitTimestamp.Equal(commit) {
+			end++
+		}
+		for _, item := range items[start:end] {
+			n, err := p.handle(item, options)
+			handled += n
+			if err != nil {
+				return handled, err
+			}
+		}
+		if err = p.cursor.Save(commit); err != nil {
+			return handled, err
+		}
+		start = end
+	}
+	return handled, nil
+}
+
+// leafItems returns the leaves committed in (minTimestamp, maxTimestamp], oldest commit first.
+func (p *CatalogProcessor) leafItems(
+	index *CatalogIndex,
+	minTimestamp, maxTimestamp time.Time,
+	options []RequestOptionFunc,
+) ([]*CatalogLeafItem, error) {
+	var items []*CatalogLeafItem
+	for _, pageItem := range index.Items {
+		if !pageItem.CommitTimestamp.After(minTimestamp) {
+			continue
+		}
+		page, _, err := p.resource.GetPage(pageItem.URL, options...)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range page.Items {
+			if item.CommitTimestamp.After(minTimestamp) && !item.CommitTimestamp.After(maxTimestamp) {
+				items = append(items, item)
+			}
+		}
+	}
+	slices.SortStableFunc(items, func(a, b *CatalogLeafItem) int {
+		return a.CommitTimestamp.Compare(b.CommitTimestamp)
+	})
+	if !p.opt.ExcludeRedundantLeaves {
+		return items, nil
+	}
+
+	latest := make(map[string]*CatalogLeafItem, len(items))
+	for _, item := range items {
+		latest[catalogLeafKey(item)] = item
+	}
+	return slices.DeleteFunc(items, func(item *CatalogLeafItem) bool {
+		return latest[catalogLeafKey(item)] != item
+	}), nil
+}
+
+// catalogLeafKey identifies the package version of a leaf, versions compare case-insensitively.
+func catalogLeafKey(item *CatalogLeafItem) string {
+	return strings.ToLower(item.PackageId) + "/" + strings.ToLower(item.PackageVersion)
+}
+
+// handle downloads the leaf of item and hands it to the handlers of its type.
+func (p *CatalogProcessor) handle(item *CatalogLeafItem, options []RequestOptionFunc) (int, error) {
+	handlers := p.handlers[item.Type]
+	if len(handlers) == 0 {
+		return 0, nil
+	}
+	leaf, _, err := p.resource.GetLeaf(item.URL, options...)
+	if err != nil {
+		return 0, err
+	}
+	for _, fn := range handlers {
+		if err = fn(item, leaf); err != nil {
+			return 0, fmt.Errorf("handle %s %s %s: %w", item.Type, item.PackageId, item.PackageVersion, err)
+		}
+	}
+	return 1, nil
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package nuget
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatalogProcessor_Process(t *testing.T) {
+	client, leafRequests := setupCatalog(t)
+	cursor := NewFileCatalogCursor(filepath.Join(t.TempDir(), "catalog", "cursor.json"))
+
+	var details, deletes []string
+	processor := client.CatalogResource.NewProcessor(cursor, nil).
+		OnPackageDetails(func(item *CatalogLeafItem, leaf *CatalogLeaf) error {
+			details = append(details, leaf.PackageId+"/"+leaf.PackageVersion)
+			return nil
+		}).
+		OnPackageDelete(func(item *CatalogLeafItem, leaf *CatalogLeaf) error {
+			deletes = append(deletes, leaf.PackageId+"/"+leaf.PackageVersion)
+			return nil
+		})
+
+	handled, err := processor.Process(withTestServer(client))
+	require.NoError(t, err)
+	require.Equal(t, 5, handled)
+	require.Equal(t, []string{"A/1.0.0", "B/1.0.0", "A/1.0.0", "C/2.0.0-beta"}, details)
+	require.Equal(t, []string{"B/1.0.0"}, deletes)
+	value, err := cursor.Load()
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2026, 1, 4, 0, 0, 0, 0, time.UTC), value)
+
+	// Nothing was committed since the cursor.
+	handled, err = processor.Process(withTestServer(client))
+	require.NoError(t, err)
+	require.Zero(t, handled)
+	require.Equal(t, 1, leafRequests["a.1.0.0.json"])
+}
+
+func TestCatalogProcessor_Process_Options(t *testing.T) {
+	client, leafRequests := setupCatalog(t)
+	cursor := &MemoryCatalogCursor{}
+
+	var handled []*CatalogLeafItem
+	n, err := client.CatalogResource.NewProcessor(cursor, &CatalogProcessorOptions{
+		DefaultMinCommitTimestamp: time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC),
+		MaxCommitTimestamp:        time.Date(2026, 1, 3, 0, 0, 0, 0, time.UTC),
+		ExcludeRedundantLeaves:    true,
+	}).OnPackageDetails(func(item *CatalogLeafItem, _ *CatalogLeaf) error {
+		handled = append(handled, item)
+		return nil
+	}).Process(withTestServer(client))
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+	require.Equal(t, "b.1.0.0.json", filepath.Base(handled[0].URL))
+	require.Equal(t, "a.1.0.0.edit.json", filepath.Base(handled[1].URL))
+	require.Equal(t, time.Date(2026, 1, 3, 0, 0, 0, 0, time.UTC), cursor.Value)
+	// Leaves without handlers and redundant leaves are not downloaded.
+	require.Zero(t, leafRequests["a.1.0.0.json"])
+	require.Zero(t, leafRequests["b.1.0.0.delete.json"])
+}
+
+func TestCatalogProcessor_Process_HandlerError(t *testing.T) {
+	client, _ := setupCatalog(t)
+	cursor := &MemoryCatalogCursor{}
+	wantErr := errors.New("index unavailable")
+
+	n, err := client.CatalogResource.NewProcessor(cursor, nil).
+		OnPackageDetails(func(item *CatalogLeafItem, _ *CatalogLeaf) error {
+			if item.PackageId == "A" && item.CommitTimestamp.Day() == 3 {
+				return wantErr
+			}
+			return nil
+		}).Process(withTestServer(client))
+	require.ErrorIs(t, err, wantErr)
+	require.EqualError(t, err, "handle PackageDetails A 1.0.0: index unavailable")
+	require.Equal(t, 2, n)
+	// The failed commit is handled again by the next run.
+	require.Equal(t, time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC), cursor.Value)
+}
+
+func TestFileCatalogCursor(t *testing.T) {
+	cursor := NewFileCatalogCursor(filepath.Join(t.TempDir(), "cursor.json"))
+	value, err := cursor.Load()
+	require.NoError(t, err)
+	require.True(t, value.IsZero())
+
+	want := time.Date(2026, 1, 2, 3, 4, 5, 600, time.UTC)
+	require.NoError(t, cursor.Save(want))
+	data, err := os.ReadFile(cursor.Path)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"value": "2026-01-02T03:04:05.0000006Z"}`, string(data))
+	value, err = cursor.Load()
+	require.NoError(t, err)
+	require.Equal(t, want, value)
+
+	require.NoError(t, os.WriteFile(cursor.Path, []byte("{"), 0o644))
+	_, err = cursor.Load()
+	require.ErrorContains(t, err, "invalid cursor")
+}
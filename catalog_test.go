@@ -0,0 +1,80 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package nuget
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// setupCatalog serves the catalog of testdata/catalog_*.json, two pages holding five leaves,
+// counting the requests of each leaf. The documents link to http://localhost:5000, see withTestServer.
+func setupCatalog(t *testing.T) (*Client, map[string]int) {
+	mux, client := setup(t, index_V3)
+	leafRequests := make(map[string]int)
+	mux.HandleFunc("/v3/catalog0/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		name := strings.TrimPrefix(r.URL.Path, "/v3/catalog0/")
+		if leaf, ok := strings.CutPrefix(name, "data/"); ok {
+			leafRequests[leaf]++
+			name = "leaf_" + leaf
+		}
+		mustWriteHTTPResponse(t, w, "testdata/catalog_"+name)
+	})
+	return client, leafRequests
+}
+
+func TestCatalogResource_GetIndex(t *testing.T) {
+	client, _ := setupCatalog(t)
+
+	index, resp, err := client.CatalogResource.GetIndex()
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.Equal(t, time.Date(2026, 1, 4, 0, 0, 0, 0, time.UTC), index.CommitTimestamp)
+	require.Len(t, index.Items, 2)
+
+	page, _, err := client.CatalogResource.GetPage(index.Items[1].URL, withTestServer(client))
+	require.NoError(t, err)
+	require.Len(t, page.Items, 3)
+	item := page.Items[1]
+	require.Equal(t, CatalogPackageDelete, item.Type)
+	require.Equal(t, "B", item.PackageId)
+	require.Equal(t, "1.0.0", item.PackageVersion)
+	require.Equal(t, time.Date(2026, 1, 4, 0, 0, 0, 0, time.UTC), item.CommitTimestamp)
+
+	leaf, _, err := client.CatalogResource.GetLeaf(item.URL, withTestServer(client))
+	require.NoError(t, err)
+	require.Equal(t, CatalogPackageDelete, leaf.Type())
+	require.Equal(t, []string{"PackageDelete", "catalog:Permalink"}, leaf.Types)
+
+	leaf, _, err = client.CatalogResource.GetLeaf(page.Items[2].URL, withTestServer(client))
+	require.NoError(t, err)
+	require.Equal(t, CatalogPackageDetails, leaf.Type())
+	require.True(t, leaf.IsListed())
+	require.Equal(t, int64(1024), leaf.PackageSize)
+	require.Equal(t, "[13.0.1, )", leaf.DependencyGroups[0].Packages[0].VersionRangeRaw)
+	identity, err := leaf.Identity()
+	require.NoError(t, err)
+	require.Equal(t, "C", identity.Id)
+	require.Equal(t, "2.0.0-beta", identity.Version.ToNormalizedString())
+}
+
+func TestCatalogResource_GetIndex_NotSupported(t *testing.T) {
+	_, client := setup(t, index_Baget)
+	_, _, err := client.CatalogResource.GetIndex()
+	require.EqualError(t, err, "the source does not support catalog")
+}
+
+func TestCatalogLeaf_IsListed(t *testing.T) {
+	listed := false
+	require.False(t, (&CatalogLeaf{Listed: &listed, Published: time.Now()}).IsListed())
+	require.False(t, (&CatalogLeaf{Published: time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC)}).IsListed())
+	require.True(t, (&CatalogLeaf{Published: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}).IsListed())
+	require.Equal(t, CatalogLeafType(""), (&CatalogLeaf{Types: []string{"catalog:Permalink"}}).Type())
+}
@@ -21,6 +21,15 @@ func WithSourceURL(urlStr string) ClientOptionFunc {
 	}
 }
 
+// WithBasicAuth sets the credentials sent with basic authentication on every request.
+func WithBasicAuth(username, password string) ClientOptionFunc {
+	return func(c *Client) error {
+		c.username = username
+		c.password = password
+		return nil
+	}
+}
+
 // WithBackoff can be used to configure a custom backoff policy.
 func WithBackoff(backoff retryablehttp.Backoff) ClientOptionFunc {
 	return func(c *Client) error {
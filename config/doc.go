@@ -2,4 +2,5 @@
 // Use of this source code is governed by a MIT style
 // license that can be found in the LICENSE file.
 
-package framework
+// this package reads, merges and writes NuGet.config files
+package config
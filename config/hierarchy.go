@@ -0,0 +1,163 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+)
+
+const (
+	// DefaultSourceName Name of the source added when no user NuGet.config exists
+	DefaultSourceName = "nuget.org"
+
+	// DefaultSourceURL Service index of nuget.org
+	DefaultSourceURL = "https://api.nuget.org/v3/index.json"
+)
+
+// fileNames NuGet.config file names looked up in each directory, in priority order
+var fileNames = []string{"nuget.config", "NuGet.config", "NuGet.Config"}
+
+// MachineWideConfigDirectory Returns the directory holding the machine wide *.config files.
+// Ex: %ProgramFiles(x86)%\NuGet\Config on windows, /etc/opt/NuGet/Config elsewhere
+func MachineWideConfigDirectory() string {
+	if runtime.GOOS == "windows" {
+		dir := os.Getenv("ProgramFiles(x86)")
+		if dir == "" {
+			dir = os.Getenv("ProgramFiles")
+		}
+		return filepath.Join(dir, "NuGet", "Config")
+	}
+	dir := os.Getenv("NUGET_COMMON_APPLICATION_DATA")
+	if dir == "" {
+		dir = "/etc/opt"
+	}
+	return filepath.Join(dir, "NuGet", "Config")
+}
+
+// UserConfigFile Returns the path of the NuGet.config of the current user.
+// Ex: %APPDATA%\NuGet\NuGet.Config on windows, ~/.nuget/NuGet/NuGet.Config elsewhere
+func UserConfigFile() string {
+	if runtime.GOOS == "windows" {
+		return filepath.Join(os.Getenv("APPDATA"), "NuGet", "NuGet.Config")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".nuget", "NuGet", "NuGet.Config")
+}
+
+// DefaultGlobalPackagesFolder Returns the global packages folder when no NuGet.config sets one,
+// the NUGET_PACKAGES environment variable wins over ~/.nuget/packages.
+func DefaultGlobalPackagesFolder() string {
+	if dir := os.Getenv("NUGET_PACKAGES"); dir != "" {
+		return dir
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".nuget", "packages")
+}
+
+// DefaultHTTPCacheFolder Returns the folder caching the http responses of the v3 sources,
+// the NUGET_HTTP_CACHE_PATH environment variable wins over the platform default.
+// Ex: %LOCALAPPDATA%\NuGet\v3-cache on windows, ~/.local/share/NuGet/http-cache elsewhere
+func DefaultHTTPCacheFolder() string {
+	if dir := os.Getenv("NUGET_HTTP_CACHE_PATH"); dir != "" {
+		return dir
+	}
+	if runtime.GOOS == "windows" {
+		return filepath.Join(os.Getenv("LOCALAPPDATA"), "NuGet", "v3-cache")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".local", "share", "NuGet", "http-cache")
+}
+
+// LoadDefault reads the machine wide, user and solution NuGet.config files that apply to root,
+// the way the NuGet client does. Files closer to root take precedence, and when the user
+// NuGet.config does not exist nuget.org is used as the default source.
+func LoadDefault(root string) (*Settings, error) {
+	return loadDefault(root, MachineWideConfigDirectory(), UserConfigFile())
+}
+
+func loadDefault(root, machineWideDir, userFile string) (*Settings, error) {
+	s := NewSettings()
+
+	machineWide, err := filepath.Glob(filepath.Join(machineWideDir, "*.config"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(machineWide)
+	for _, name := range machineWide {
+		if err = s.readFile(name); err != nil {
+			return nil, err
+		}
+	}
+
+	if userFile != "" && fileExists(userFile) {
+		if err = s.readFile(userFile); err != nil {
+			return nil, err
+		}
+	} else if _, ok := s.sources.get(DefaultSourceName); !ok {
+		_ = s.SetPackageSource(&PackageSource{
+			Name:            DefaultSourceName,
+			Source:          DefaultSourceURL,
+			ProtocolVersion: 3,
+			IsEnabled:       true,
+		})
+	}
+
+	solution, err := solutionConfigFiles(root)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range solution {
+		if err = s.readFile(name); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// solutionConfigFiles Returns the NuGet.config files of root and its parent directories,
+// the farthest first so closer files are applied last.
+func solutionConfigFiles(root string) ([]string, error) {
+	if root == "" {
+		return nil, nil
+	}
+	dir, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for {
+		for _, name := range fileNames {
+			if path := filepath.Join(dir, name); fileExists(path) {
+				files = append([]string{path}, files...)
+				break
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return files, nil
+		}
+		dir = parent
+	}
+}
+
+func fileExists(name string) bool {
+	info, err := os.Stat(name)
+	if err != nil {
+		return false
+	}
+	return !info.IsDir()
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"math"
+	"strings"
+)
+
+// PackageSourcePatterns The package id patterns mapped to a package source.
+// A pattern is either a package id, or a prefix ending with *
+type PackageSourcePatterns struct {
+	Source   string
+	Patterns []string
+}
+
+// PackageSourceMapping Restricts which sources a package id may be restored from
+type PackageSourceMapping struct {
+	entries []*PackageSourcePatterns
+}
+
+// NewPackageSourceMapping creates a mapping from the patterns of each source
+func NewPackageSourceMapping(entries ...*PackageSourcePatterns) *PackageSourceMapping {
+	m := &PackageSourceMapping{}
+	for _, entry := range entries {
+		if entry != nil && entry.Source != "" {
+			m.entries = append(m.entries, entry)
+		}
+	}
+	return m
+}
+
+// IsEnabled True if at least one source declares patterns, packages may then only come from mapped sources.
+func (m *PackageSourceMapping) IsEnabled() bool {
+	for _, entry := range m.entries {
+		if len(entry.Patterns) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Entries Returns the patterns of each source, in declaration order.
+func (m *PackageSourceMapping) Entries() []*PackageSourcePatterns {
+	return append([]*PackageSourcePatterns(nil), m.entries...)
+}
+
+// GetConfiguredPackageSources Returns the sources a package id may be restored from.
+// A package id match wins over prefixes, and the longest prefix wins over shorter ones.
+// Ex: with contoso -> Contoso.*, nuget.org -> *, Contoso.Lib is only restored from contoso
+func (m *PackageSourceMapping) GetConfiguredPackageSources(packageID string) []string {
+	best := -1
+	var sources []string
+	for _, entry := range m.entries {
+		score := -1
+		for _, pattern := range entry.Patterns {
+			if s := matchPattern(pattern, packageID); s > score {
+				score = s
+			}
+		}
+		switch {
+		case score < 0 || score < best:
+			continue
+		case score > best:
+			best = score
+			sources = []string{entry.Source}
+		default:
+			sources = append(sources, entry.Source)
+		}
+	}
+	return sources
+}
+
+// matchPattern Returns how specific the match of a pattern is, or -1 if it doesn't match.
+func matchPattern(pattern, packageID string) int {
+	pattern = strings.TrimSpace(pattern)
+	if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+		if len(packageID) >= len(prefix) && strings.EqualFold(packageID[:len(prefix)], prefix) {
+			return len(prefix)
+		}
+		return -1
+	}
+	if strings.EqualFold(pattern, packageID) {
+		return math.MaxInt
+	}
+	return -1
+}
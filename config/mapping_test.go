@@ -0,0 +1,41 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageSourceMapping_GetConfiguredPackageSources(t *testing.T) {
+	mapping := NewPackageSourceMapping(
+		&PackageSourcePatterns{Source: "nuget.org", Patterns: []string{"*"}},
+		&PackageSourcePatterns{Source: "contoso", Patterns: []string{"Contoso.*", "NuGet.Common"}},
+		&PackageSourcePatterns{Source: "contoso-mirror", Patterns: []string{"contoso.*"}},
+		&PackageSourcePatterns{Source: "internal", Patterns: []string{"Contoso.Internal.*"}},
+		nil,
+	)
+	tests := []struct {
+		packageID string
+		want      []string
+	}{
+		{packageID: "Newtonsoft.Json", want: []string{"nuget.org"}},
+		{packageID: "Contoso.Lib", want: []string{"contoso", "contoso-mirror"}},
+		{packageID: "Contoso.Internal.Lib", want: []string{"internal"}},
+		{packageID: "nuget.common", want: []string{"contoso"}},
+		{packageID: "NuGet.Common.Extensions", want: []string{"nuget.org"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.packageID, func(t *testing.T) {
+			require.True(t, mapping.IsEnabled())
+			require.Equal(t, tt.want, mapping.GetConfiguredPackageSources(tt.packageID))
+		})
+	}
+
+	mapping = NewPackageSourceMapping(&PackageSourcePatterns{Source: "contoso", Patterns: []string{"Contoso.*"}})
+	require.Nil(t, mapping.GetConfiguredPackageSources("Newtonsoft.Json"))
+	require.False(t, NewPackageSourceMapping().IsEnabled())
+}
@@ -0,0 +1,203 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	protocolVersionAttribute          = "protocolVersion"
+	allowInsecureConnectionsAttribute = "allowInsecureConnections"
+
+	usernameKey                 = "Username"
+	passwordKey                 = "Password"
+	clearTextPasswordKey        = "ClearTextPassword"
+	validAuthenticationTypesKey = "ValidAuthenticationTypes"
+)
+
+// encodedNamePattern Matches the _xHHHH_ escapes XmlConvert uses for element names
+var encodedNamePattern = regexp.MustCompile(`_x([0-9A-Fa-f]{4})_`)
+
+// PackageSource A source of packages declared in the packageSources section
+type PackageSource struct {
+	// Name The key of the source
+	Name string
+
+	// Source The url of the service index, or a local folder
+	Source string
+
+	// ProtocolVersion The NuGet protocol version of the source, 0 when not declared
+	ProtocolVersion int
+
+	// AllowInsecureConnections Whether plain http is allowed for the source
+	AllowInsecureConnections bool
+
+	// IsEnabled False when the source is listed in disabledPackageSources
+	IsEnabled bool
+
+	// Credentials The credentials of the source, nil when none are declared
+	Credentials *PackageSourceCredential
+}
+
+// NewPackageSource creates an enabled package source
+func NewPackageSource(name, source string) *PackageSource {
+	return &PackageSource{
+		Name:      name,
+		Source:    source,
+		IsEnabled: true,
+	}
+}
+
+// IsHTTP True if the source is served over http or https.
+func (p *PackageSource) IsHTTP() bool {
+	u, err := url.Parse(p.Source)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(u.Scheme, "http") || strings.EqualFold(u.Scheme, "https")
+}
+
+// IsHTTPS True if the source is served over https.
+func (p *PackageSource) IsHTTPS() bool {
+	u, err := url.Parse(p.Source)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(u.Scheme, "https")
+}
+
+// IsLocal True if the source is a folder on the file system.
+func (p *PackageSource) IsLocal() bool {
+	return p.Source != "" && !p.IsHTTP()
+}
+
+// PackageSourceCredential Credentials of a source declared in the packageSourceCredentials section
+type PackageSourceCredential struct {
+	Username string
+
+	// Password The raw password, environment variables are expanded by GetPassword
+	Password string
+
+	// IsPasswordClearText True if read from ClearTextPassword, false for the DPAPI encrypted Password
+	IsPasswordClearText bool
+
+	// ValidAuthenticationTypes Comma separated authentication types, Ex: basic,negotiate
+	ValidAuthenticationTypes string
+}
+
+// NewPackageSourceCredential creates a credential with a clear text password
+func NewPackageSourceCredential(username, password string) *PackageSourceCredential {
+	return &PackageSourceCredential{
+		Username:            username,
+		Password:            password,
+		IsPasswordClearText: true,
+	}
+}
+
+// GetUsername Returns the username with environment variables expanded.
+func (c *PackageSourceCredential) GetUsername() string {
+	return ExpandEnv(c.Username)
+}
+
+// GetPassword Returns the password with environment variables expanded.
+// Encrypted passwords can only be decrypted by the Windows user that stored them and are not supported.
+func (c *PackageSourceCredential) GetPassword() (string, error) {
+	if !c.IsPasswordClearText && c.Password != "" {
+		return "", fmt.Errorf("encrypted passwords are not supported, use ClearTextPassword")
+	}
+	return ExpandEnv(c.Password), nil
+}
+
+// GetValidAuthenticationTypes Returns the authentication types the credential may be used with.
+func (c *PackageSourceCredential) GetValidAuthenticationTypes() []string {
+	var types []string
+	for _, t := range strings.Split(c.ValidAuthenticationTypes, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			types = append(types, t)
+		}
+	}
+	return types
+}
+
+func (c *PackageSourceCredential) toCredential(source string) *credential {
+	cred := &credential{source: source}
+	set := func(key, value string) {
+		if value != "" {
+			cred.items.set(&item{key: key, value: value, attrs: map[string]string{}})
+		}
+	}
+	set(usernameKey, c.Username)
+	if c.IsPasswordClearText {
+		set(clearTextPasswordKey, c.Password)
+	} else {
+		set(passwordKey, c.Password)
+	}
+	set(validAuthenticationTypesKey, c.ValidAuthenticationTypes)
+	return cred
+}
+
+func (s *Settings) newPackageSource(it *item) *PackageSource {
+	source := NewPackageSource(it.key, it.value)
+	source.ProtocolVersion, _ = strconv.Atoi(it.attrs[protocolVersionAttribute])
+	source.AllowInsecureConnections, _ = strconv.ParseBool(it.attrs[allowInsecureConnectionsAttribute])
+	if disabled, ok := s.disabled.get(it.key); ok {
+		source.IsEnabled = !strings.EqualFold(disabled.value, "true")
+	}
+	if i := s.credentialIndex(it.key); i >= 0 {
+		items := s.credentials[i].items
+		cred := &PackageSourceCredential{}
+		if username, ok := items.get(usernameKey); ok {
+			cred.Username = username.value
+		}
+		if password, ok := items.get(clearTextPasswordKey); ok {
+			cred.Password = password.value
+			cred.IsPasswordClearText = true
+		} else if password, ok := items.get(passwordKey); ok {
+			cred.Password = password.value
+		}
+		if types, ok := items.get(validAuthenticationTypesKey); ok {
+			cred.ValidAuthenticationTypes = types.value
+		}
+		source.Credentials = cred
+	}
+	return source
+}
+
+// encodeName escapes a source name into a valid xml element name, the way XmlConvert.EncodeLocalName does.
+// Ex: "My Feed" -> "My_x0020_Feed"
+func encodeName(name string) string {
+	var sb strings.Builder
+	for i, r := range name {
+		valid := unicode.IsLetter(r) || r == '_' ||
+			(i > 0 && (unicode.IsDigit(r) || r == '-' || r == '.'))
+		// a literal "_x" would be read back as the start of an escape
+		if r == '_' && strings.HasPrefix(name[i:], "_x") {
+			valid = false
+		}
+		if valid {
+			sb.WriteRune(r)
+		} else {
+			sb.WriteString(fmt.Sprintf("_x%04X_", r))
+		}
+	}
+	return sb.String()
+}
+
+// decodeName reverses encodeName.
+func decodeName(name string) string {
+	return encodedNamePattern.ReplaceAllStringFunc(name, func(match string) string {
+		r, err := strconv.ParseUint(match[2:6], 16, 32)
+		if err != nil {
+			return match
+		}
+		return string(rune(r))
+	})
+}
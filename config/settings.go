@@ -0,0 +1,514 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	configSection                   = "config"
+	packageSourcesSection           = "packageSources"
+	disabledPackageSourcesSection   = "disabledPackageSources"
+	packageSourceCredentialsSection = "packageSourceCredentials"
+	packageSourceMappingSection     = "packageSourceMapping"
+
+	addElement           = "add"
+	clearElement         = "clear"
+	removeElement        = "remove"
+	packageSourceElement = "packageSource"
+	packageElement       = "package"
+
+	keyAttribute     = "key"
+	valueAttribute   = "value"
+	patternAttribute = "pattern"
+
+	globalPackagesFolderKey = "globalPackagesFolder"
+)
+
+// envPattern Matches %NAME% environment variable references
+var envPattern = regexp.MustCompile(`%([^%]+)%`)
+
+// xmlConfiguration root element of a NuGet.config file
+type xmlConfiguration struct {
+	XMLName  xml.Name      `xml:"configuration"`
+	Sections []*xmlElement `xml:",any"`
+}
+
+// xmlElement any element of a NuGet.config file, sections are read generically
+// so unknown ones are skipped without failing
+type xmlElement struct {
+	XMLName  xml.Name
+	Attrs    []xml.Attr    `xml:",any,attr"`
+	Children []*xmlElement `xml:",any"`
+}
+
+func newXMLElement(name string, attrs ...string) *xmlElement {
+	e := &xmlElement{XMLName: xml.Name{Local: name}}
+	for i := 0; i+1 < len(attrs); i += 2 {
+		e.Attrs = append(e.Attrs, xml.Attr{Name: xml.Name{Local: attrs[i]}, Value: attrs[i+1]})
+	}
+	return e
+}
+
+func (e *xmlElement) attr(name string) string {
+	for _, a := range e.Attrs {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// item key/value entry of a section, origin is the file the entry was read from
+type item struct {
+	key    string
+	value  string
+	attrs  map[string]string
+	origin string
+}
+
+// itemList ordered entries of a section, keys are case-insensitive
+type itemList []*item
+
+func (l itemList) index(key string) int {
+	for i, it := range l {
+		if strings.EqualFold(it.key, key) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (l itemList) get(key string) (*item, bool) {
+	if i := l.index(key); i >= 0 {
+		return l[i], true
+	}
+	return nil, false
+}
+
+// set replaces the entry with the same key in place, or appends it
+func (l *itemList) set(it *item) {
+	if i := l.index(it.key); i >= 0 {
+		(*l)[i] = it
+		return
+	}
+	*l = append(*l, it)
+}
+
+func (l *itemList) remove(key string) {
+	if i := l.index(key); i >= 0 {
+		*l = append((*l)[:i], (*l)[i+1:]...)
+	}
+}
+
+// credential packageSourceCredentials entry of a source
+type credential struct {
+	source string
+	items  itemList
+}
+
+// Settings The merged content of one or more NuGet.config files
+type Settings struct {
+	files       []string
+	config      itemList
+	sources     itemList
+	disabled    itemList
+	credentials []*credential
+	mappings    []*PackageSourcePatterns
+}
+
+// NewSettings creates empty settings
+func NewSettings() *Settings {
+	return &Settings{}
+}
+
+// Parse reads a single NuGet.config document.
+func Parse(r io.Reader) (*Settings, error) {
+	s := NewSettings()
+	if err := s.read(r, ""); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// ParseFile reads a single NuGet.config file from the file system.
+func ParseFile(name string) (*Settings, error) {
+	return LoadFiles(name)
+}
+
+// LoadFiles reads and merges NuGet.config files, later files take precedence over earlier ones.
+// Ex: LoadFiles(machineWide, user, solution)
+func LoadFiles(names ...string) (*Settings, error) {
+	s := NewSettings()
+	for _, name := range names {
+		if err := s.readFile(name); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+func (s *Settings) readFile(name string) error {
+	file, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if abs, err := filepath.Abs(name); err == nil {
+		name = abs
+	}
+	if err = s.read(file, name); err != nil {
+		return fmt.Errorf("invalid NuGet.config %s: %w", name, err)
+	}
+	s.files = append(s.files, name)
+	return nil
+}
+
+func (s *Settings) read(r io.Reader, origin string) error {
+	var doc xmlConfiguration
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return err
+	}
+	for _, section := range doc.Sections {
+		switch section.XMLName.Local {
+		case configSection:
+			applyItems(&s.config, section, origin)
+		case packageSourcesSection:
+			applyItems(&s.sources, section, origin)
+		case disabledPackageSourcesSection:
+			applyItems(&s.disabled, section, origin)
+		case packageSourceCredentialsSection:
+			s.applyCredentials(section, origin)
+		case packageSourceMappingSection:
+			s.applyMappings(section)
+		}
+	}
+	return nil
+}
+
+// applyItems applies the add, remove and clear children of a section on top of the current entries
+func applyItems(l *itemList, section *xmlElement, origin string) {
+	for _, child := range section.Children {
+		switch child.XMLName.Local {
+		case clearElement:
+			*l = nil
+		case removeElement:
+			l.remove(child.attr(keyAttribute))
+		case addElement:
+			key := child.attr(keyAttribute)
+			if key == "" {
+				continue
+			}
+			it := &item{key: key, value: child.attr(valueAttribute), attrs: map[string]string{}, origin: origin}
+			for _, a := range child.Attrs {
+				if a.Name.Local != keyAttribute && a.Name.Local != valueAttribute {
+					it.attrs[a.Name.Local] = a.Value
+				}
+			}
+			l.set(it)
+		}
+	}
+}
+
+func (s *Settings) applyCredentials(section *xmlElement, origin string) {
+	for _, child := range section.Children {
+		if child.XMLName.Local == clearElement {
+			s.credentials = nil
+			continue
+		}
+		c := &credential{source: decodeName(child.XMLName.Local)}
+		applyItems(&c.items, child, origin)
+		s.setCredential(c)
+	}
+}
+
+func (s *Settings) applyMappings(section *xmlElement) {
+	for _, child := range section.Children {
+		switch child.XMLName.Local {
+		case clearElement:
+			s.mappings = nil
+		case packageSourceElement:
+			patterns := make([]string, 0, len(child.Children))
+			for _, p := range child.Children {
+				if p.XMLName.Local == packageElement && p.attr(patternAttribute) != "" {
+					patterns = append(patterns, p.attr(patternAttribute))
+				}
+			}
+			s.SetPackageSourceMapping(child.attr(keyAttribute), patterns...)
+		}
+	}
+}
+
+func (s *Settings) credentialIndex(source string) int {
+	for i, c := range s.credentials {
+		if strings.EqualFold(c.source, source) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *Settings) setCredential(c *credential) {
+	if i := s.credentialIndex(c.source); i >= 0 {
+		s.credentials[i] = c
+		return
+	}
+	s.credentials = append(s.credentials, c)
+}
+
+func (s *Settings) removeCredential(source string) {
+	if i := s.credentialIndex(source); i >= 0 {
+		s.credentials = append(s.credentials[:i], s.credentials[i+1:]...)
+	}
+}
+
+// Files Returns the files the settings were loaded from, lowest precedence first.
+func (s *Settings) Files() []string {
+	return append([]string(nil), s.files...)
+}
+
+// GetConfigValue Returns the value of a key of the config section, with environment variables expanded.
+func (s *Settings) GetConfigValue(key string) string {
+	if it, ok := s.config.get(key); ok {
+		return ExpandEnv(it.value)
+	}
+	return ""
+}
+
+// GetConfigPath Returns the value of a key of the config section as a path,
+// relative paths are resolved against the directory of the file that defined the key.
+// Ex: globalPackagesFolder, repositoryPath
+func (s *Settings) GetConfigPath(key string) string {
+	it, ok := s.config.get(key)
+	if !ok {
+		return ""
+	}
+	value := ExpandEnv(it.value)
+	if value == "" || filepath.IsAbs(value) || it.origin == "" {
+		return value
+	}
+	return filepath.Join(filepath.Dir(it.origin), value)
+}
+
+// GlobalPackagesFolder Returns the global packages folder, the NUGET_PACKAGES environment variable
+// wins over the globalPackagesFolder config key, which wins over ~/.nuget/packages.
+func (s *Settings) GlobalPackagesFolder() string {
+	if dir := os.Getenv("NUGET_PACKAGES"); dir != "" {
+		return dir
+	}
+	if dir := s.GetConfigPath(globalPackagesFolderKey); dir != "" {
+		return dir
+	}
+	return DefaultGlobalPackagesFolder()
+}
+
+// SetConfigValue sets a key of the config section.
+func (s *Settings) SetConfigValue(key, value string) {
+	s.config.set(&item{key: key, value: value, attrs: map[string]string{}})
+}
+
+// RemoveConfigValue removes a key of the config section.
+func (s *Settings) RemoveConfigValue(key string) {
+	s.config.remove(key)
+}
+
+// PackageSources Returns all package sources, disabled ones included, in declaration order.
+func (s *Settings) PackageSources() []*PackageSource {
+	sources := make([]*PackageSource, 0, len(s.sources))
+	for _, it := range s.sources {
+		sources = append(sources, s.newPackageSource(it))
+	}
+	return sources
+}
+
+// EnabledPackageSources Returns the package sources that are not disabled, in declaration order.
+func (s *Settings) EnabledPackageSources() []*PackageSource {
+	sources := make([]*PackageSource, 0, len(s.sources))
+	for _, source := range s.PackageSources() {
+		if source.IsEnabled {
+			sources = append(sources, source)
+		}
+	}
+	return sources
+}
+
+// GetPackageSource Returns the package source with the given name.
+func (s *Settings) GetPackageSource(name string) (*PackageSource, bool) {
+	it, ok := s.sources.get(name)
+	if !ok {
+		return nil, false
+	}
+	return s.newPackageSource(it), true
+}
+
+// SetPackageSource adds or replaces a package source, along with its enabled state and credentials.
+func (s *Settings) SetPackageSource(source *PackageSource) error {
+	if source == nil || source.Name == "" {
+		return fmt.Errorf("package source name is required")
+	}
+	if source.Source == "" {
+		return fmt.Errorf("source of package source %s is required", source.Name)
+	}
+	it := &item{key: source.Name, value: source.Source, attrs: map[string]string{}}
+	if source.ProtocolVersion > 0 {
+		it.attrs[protocolVersionAttribute] = fmt.Sprintf("%d", source.ProtocolVersion)
+	}
+	if source.AllowInsecureConnections {
+		it.attrs[allowInsecureConnectionsAttribute] = "true"
+	}
+	s.sources.set(it)
+
+	if source.IsEnabled {
+		s.disabled.remove(source.Name)
+	} else {
+		s.disabled.set(&item{key: source.Name, value: "true", attrs: map[string]string{}})
+	}
+
+	if source.Credentials == nil {
+		s.removeCredential(source.Name)
+	} else {
+		s.setCredential(source.Credentials.toCredential(source.Name))
+	}
+	return nil
+}
+
+// RemovePackageSource removes a package source with its disabled state, credentials and mapping.
+func (s *Settings) RemovePackageSource(name string) {
+	s.sources.remove(name)
+	s.disabled.remove(name)
+	s.removeCredential(name)
+	s.RemovePackageSourceMapping(name)
+}
+
+// PackageSourceMapping Returns the package source mapping.
+func (s *Settings) PackageSourceMapping() *PackageSourceMapping {
+	entries := make([]*PackageSourcePatterns, 0, len(s.mappings))
+	for _, m := range s.mappings {
+		entries = append(entries, &PackageSourcePatterns{
+			Source:   m.Source,
+			Patterns: append([]string(nil), m.Patterns...),
+		})
+	}
+	return NewPackageSourceMapping(entries...)
+}
+
+// SetPackageSourceMapping sets the package id patterns of a package source.
+// Ex: SetPackageSourceMapping("contoso", "Contoso.*", "NuGet.Common")
+func (s *Settings) SetPackageSourceMapping(source string, patterns ...string) {
+	entry := &PackageSourcePatterns{Source: source, Patterns: patterns}
+	for i, m := range s.mappings {
+		if strings.EqualFold(m.Source, source) {
+			s.mappings[i] = entry
+			return
+		}
+	}
+	s.mappings = append(s.mappings, entry)
+}
+
+// RemovePackageSourceMapping removes the package id patterns of a package source.
+func (s *Settings) RemovePackageSourceMapping(source string) {
+	for i, m := range s.mappings {
+		if strings.EqualFold(m.Source, source) {
+			s.mappings = append(s.mappings[:i], s.mappings[i+1:]...)
+			return
+		}
+	}
+}
+
+// Write writes the settings as a single NuGet.config document, values are written unexpanded.
+func (s *Settings) Write(w io.Writer) error {
+	doc := &xmlConfiguration{}
+	if len(s.sources) > 0 {
+		doc.Sections = append(doc.Sections, itemsElement(packageSourcesSection, s.sources))
+	}
+	if len(s.disabled) > 0 {
+		doc.Sections = append(doc.Sections, itemsElement(disabledPackageSourcesSection, s.disabled))
+	}
+	if len(s.credentials) > 0 {
+		section := newXMLElement(packageSourceCredentialsSection)
+		for _, c := range s.credentials {
+			child := itemsElement(encodeName(c.source), c.items)
+			section.Children = append(section.Children, child)
+		}
+		doc.Sections = append(doc.Sections, section)
+	}
+	if len(s.config) > 0 {
+		doc.Sections = append(doc.Sections, itemsElement(configSection, s.config))
+	}
+	if len(s.mappings) > 0 {
+		section := newXMLElement(packageSourceMappingSection)
+		for _, m := range s.mappings {
+			child := newXMLElement(packageSourceElement, keyAttribute, m.Source)
+			for _, pattern := range m.Patterns {
+				child.Children = append(child.Children, newXMLElement(packageElement, patternAttribute, pattern))
+			}
+			section.Children = append(section.Children, child)
+		}
+		doc.Sections = append(doc.Sections, section)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// SaveFile writes the settings to a NuGet.config file, creating its directory if needed.
+func (s *Settings) SaveFile(name string) error {
+	if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+		return err
+	}
+	file, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if err = s.Write(file); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func itemsElement(name string, items itemList) *xmlElement {
+	section := newXMLElement(name)
+	for _, it := range items {
+		child := newXMLElement(addElement, keyAttribute, it.key, valueAttribute, it.value)
+		names := make([]string, 0, len(it.attrs))
+		for attrName := range it.attrs {
+			names = append(names, attrName)
+		}
+		sort.Strings(names)
+		for _, attrName := range names {
+			child.Attrs = append(child.Attrs, xml.Attr{Name: xml.Name{Local: attrName}, Value: it.attrs[attrName]})
+		}
+		section.Children = append(section.Children, child)
+	}
+	return section
+}
+
+// ExpandEnv replaces %NAME% references with the value of the environment variable,
+// references to variables that are not set are left untouched.
+func ExpandEnv(value string) string {
+	return envPattern.ReplaceAllStringFunc(value, func(match string) string {
+		if v, ok := os.LookupEnv(match[1 : len(match)-1]); ok {
+			return v
+		}
+		return match
+	})
+}
@@ -0,0 +1,262 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFile(t *testing.T) {
+	t.Setenv("GO_NUGET_TEST_USER", "kevin")
+	t.Setenv("GO_NUGET_TEST_PASSWORD", "secret")
+
+	settings, err := ParseFile("testdata/sources.config")
+	require.NoError(t, err)
+
+	abs, err := filepath.Abs("testdata/sources.config")
+	require.NoError(t, err)
+	require.Equal(t, []string{abs}, settings.Files())
+
+	sources := settings.PackageSources()
+	require.Len(t, sources, 4)
+	require.Equal(t, &PackageSource{
+		Name:            "nuget.org",
+		Source:          "https://api.nuget.org/v3/index.json",
+		ProtocolVersion: 3,
+		IsEnabled:       true,
+	}, sources[0])
+	require.Equal(t, &PackageSource{
+		Name:      "Contoso Feed",
+		Source:    "https://contoso.com/nuget/v3/index.json",
+		IsEnabled: true,
+		Credentials: &PackageSourceCredential{
+			Username:                 "%GO_NUGET_TEST_USER%",
+			Password:                 "%GO_NUGET_TEST_PASSWORD%",
+			IsPasswordClearText:      true,
+			ValidAuthenticationTypes: "basic, negotiate",
+		},
+	}, sources[1])
+	require.True(t, sources[0].IsHTTPS())
+	require.True(t, sources[2].IsLocal())
+	require.False(t, sources[3].IsHTTPS())
+	require.Equal(t, &PackageSource{
+		Name:                     "legacy",
+		Source:                   "http://legacy.contoso.com/nuget",
+		AllowInsecureConnections: true,
+		IsEnabled:                false,
+	}, sources[3])
+
+	enabled := settings.EnabledPackageSources()
+	require.Len(t, enabled, 3)
+
+	credentials := sources[1].Credentials
+	require.Equal(t, "kevin", credentials.GetUsername())
+	password, err := credentials.GetPassword()
+	require.NoError(t, err)
+	require.Equal(t, "secret", password)
+	require.Equal(t, []string{"basic", "negotiate"}, credentials.GetValidAuthenticationTypes())
+
+	source, ok := settings.GetPackageSource("contoso feed")
+	require.True(t, ok)
+	require.Equal(t, sources[1], source)
+	_, ok = settings.GetPackageSource("missing")
+	require.False(t, ok)
+
+	require.Equal(t, "packages", settings.GetConfigValue("globalPackagesFolder"))
+	require.Equal(t, filepath.Join(filepath.Dir(abs), "packages"), settings.GetConfigPath("globalPackagesFolder"))
+	require.Equal(t, "http://%GO_NUGET_TEST_PROXY%:8080", settings.GetConfigValue("http_proxy"))
+	require.Empty(t, settings.GetConfigValue("missing"))
+
+	mapping := settings.PackageSourceMapping()
+	require.True(t, mapping.IsEnabled())
+	require.Equal(t, []*PackageSourcePatterns{
+		{Source: "nuget.org", Patterns: []string{"*"}},
+		{Source: "Contoso Feed", Patterns: []string{"Contoso.*", "NuGet.Common"}},
+	}, mapping.Entries())
+}
+
+func TestParse_Invalid(t *testing.T) {
+	_, err := Parse(strings.NewReader("<configuration>"))
+	require.Error(t, err)
+
+	_, err = ParseFile("testdata/missing.config")
+	require.Error(t, err)
+}
+
+func TestGetPassword_Encrypted(t *testing.T) {
+	settings, err := Parse(strings.NewReader(`<configuration>
+  <packageSources><add key="feed" value="https://feed.com/v3/index.json" /></packageSources>
+  <packageSourceCredentials>
+    <feed><add key="Username" value="kevin" /><add key="Password" value="AQAAANCMnd8BFdERjHoAwE" /></feed>
+  </packageSourceCredentials>
+</configuration>`))
+	require.NoError(t, err)
+	source, ok := settings.GetPackageSource("feed")
+	require.True(t, ok)
+	require.False(t, source.Credentials.IsPasswordClearText)
+	_, err = source.Credentials.GetPassword()
+	require.Equal(t, errors.New("encrypted passwords are not supported, use ClearTextPassword"), err)
+}
+
+func TestLoadFiles(t *testing.T) {
+	settings, err := LoadFiles(
+		"testdata/machine/company.config",
+		"testdata/user/NuGet.Config",
+		"testdata/solution/nuget.config",
+	)
+	require.NoError(t, err)
+
+	names := make([]string, 0)
+	for _, source := range settings.EnabledPackageSources() {
+		names = append(names, source.Name)
+	}
+	require.Equal(t, []string{"nuget.org", "solution"}, names)
+	require.Len(t, settings.PackageSources(), 3)
+	require.Equal(t, "accept", settings.GetConfigValue("signatureValidationMode"))
+
+	abs, err := filepath.Abs("testdata/solution/packages")
+	require.NoError(t, err)
+	require.Equal(t, abs, settings.GetConfigPath("globalPackagesFolder"))
+
+	t.Setenv("NUGET_PACKAGES", "")
+	require.Equal(t, abs, settings.GlobalPackagesFolder())
+	home, err := os.UserHomeDir()
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(home, ".nuget", "packages"), NewSettings().GlobalPackagesFolder())
+	t.Setenv("NUGET_PACKAGES", "/cache/packages")
+	require.Equal(t, "/cache/packages", settings.GlobalPackagesFolder())
+	require.Equal(t, "/cache/packages", DefaultGlobalPackagesFolder())
+
+	t.Setenv("NUGET_HTTP_CACHE_PATH", "/cache/http")
+	require.Equal(t, "/cache/http", DefaultHTTPCacheFolder())
+}
+
+func TestLoadDefault(t *testing.T) {
+	settings, err := loadDefault("testdata/solution/src/project", "testdata/machine", "testdata/user/NuGet.Config")
+	require.NoError(t, err)
+	require.Len(t, settings.Files(), 4)
+
+	source, ok := settings.GetPackageSource("solution")
+	require.True(t, ok)
+	require.Equal(t, "https://src.com/nuget/v3/index.json", source.Source)
+	_, ok = settings.GetPackageSource("personal")
+	require.False(t, ok)
+	require.Equal(t, []string{"solution"}, settings.PackageSourceMapping().GetConfiguredPackageSources("Any"))
+
+	// without a user config nuget.org is the default source
+	settings, err = loadDefault("", filepath.Join(t.TempDir(), "missing"), filepath.Join(t.TempDir(), "NuGet.Config"))
+	require.NoError(t, err)
+	require.Equal(t, []*PackageSource{
+		{Name: DefaultSourceName, Source: DefaultSourceURL, ProtocolVersion: 3, IsEnabled: true},
+	}, settings.PackageSources())
+}
+
+func TestSettings_Write(t *testing.T) {
+	settings := NewSettings()
+	require.NoError(t, settings.SetPackageSource(NewPackageSource("nuget.org", DefaultSourceURL)))
+	require.NoError(t, settings.SetPackageSource(&PackageSource{
+		Name:            "My Feed",
+		Source:          "https://feed.com/v3/index.json",
+		ProtocolVersion: 3,
+		Credentials:     NewPackageSourceCredential("kevin", "%FEED_PASSWORD%"),
+	}))
+	require.Equal(t, errors.New("package source name is required"), settings.SetPackageSource(nil))
+	require.Equal(t,
+		errors.New("source of package source feed is required"),
+		settings.SetPackageSource(NewPackageSource("feed", "")),
+	)
+	settings.SetConfigValue("globalPackagesFolder", "packages")
+	settings.SetConfigValue("removed", "true")
+	settings.RemoveConfigValue("removed")
+	settings.SetPackageSourceMapping("nuget.org", "*")
+	settings.SetPackageSourceMapping("My Feed", "My.*")
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, settings.Write(buf))
+	require.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<configuration>
+  <packageSources>
+    <add key="nuget.org" value="https://api.nuget.org/v3/index.json"></add>
+    <add key="My Feed" value="https://feed.com/v3/index.json" protocolVersion="3"></add>
+  </packageSources>
+  <disabledPackageSources>
+    <add key="My Feed" value="true"></add>
+  </disabledPackageSources>
+  <packageSourceCredentials>
+    <My_x0020_Feed>
+      <add key="Username" value="kevin"></add>
+      <add key="ClearTextPassword" value="%FEED_PASSWORD%"></add>
+    </My_x0020_Feed>
+  </packageSourceCredentials>
+  <config>
+    <add key="globalPackagesFolder" value="packages"></add>
+  </config>
+  <packageSourceMapping>
+    <packageSource key="nuget.org">
+      <package pattern="*"></package>
+    </packageSource>
+    <packageSource key="My Feed">
+      <package pattern="My.*"></package>
+    </packageSource>
+  </packageSourceMapping>
+</configuration>
+`, buf.String())
+
+	// round trip through a file
+	name := filepath.Join(t.TempDir(), "nested", "NuGet.Config")
+	require.NoError(t, settings.SaveFile(name))
+	saved, err := ParseFile(name)
+	require.NoError(t, err)
+	require.Equal(t, settings.PackageSources(), saved.PackageSources())
+	require.Equal(t, settings.PackageSourceMapping(), saved.PackageSourceMapping())
+
+	saved.RemovePackageSource("my feed")
+	require.Len(t, saved.PackageSources(), 1)
+	require.Equal(t, []string{"nuget.org"}, saved.PackageSourceMapping().GetConfiguredPackageSources("My.Lib"))
+}
+
+func TestExpandEnv(t *testing.T) {
+	t.Setenv("GO_NUGET_TEST_HOME", "/home/kevin")
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "%GO_NUGET_TEST_HOME%/packages", want: "/home/kevin/packages"},
+		{value: "%GO_NUGET_TEST_MISSING%", want: "%GO_NUGET_TEST_MISSING%"},
+		{value: "100%", want: "100%"},
+		{value: "", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			require.Equal(t, tt.want, ExpandEnv(tt.value))
+		})
+	}
+}
+
+func TestEncodeName(t *testing.T) {
+	tests := []struct {
+		name    string
+		encoded string
+	}{
+		{name: "nuget.org", encoded: "nuget.org"},
+		{name: "My Feed", encoded: "My_x0020_Feed"},
+		{name: "1feed", encoded: "_x0031_feed"},
+		{name: "a_xb", encoded: "a_x005F_xb"},
+		{name: "feed:v3", encoded: "feed_x003A_v3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.encoded, encodeName(tt.name))
+			require.Equal(t, tt.name, decodeName(tt.encoded))
+		})
+	}
+}
@@ -19,8 +19,8 @@ import (
 	"time"
 
 	"github.com/huhouhua/go-nuget/internal/consts"
-	"github.com/huhouhua/go-nuget/internal/meta"
 	"github.com/huhouhua/go-nuget/internal/util"
+	"github.com/huhouhua/go-nuget/meta"
 )
 
 const (
@@ -10,10 +10,10 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/huhouhua/go-nuget/framework"
 	"github.com/huhouhua/go-nuget/internal/consts"
-	"github.com/huhouhua/go-nuget/internal/framework"
-	"github.com/huhouhua/go-nuget/internal/meta"
 	"github.com/huhouhua/go-nuget/internal/util"
+	"github.com/huhouhua/go-nuget/meta"
 	nugetVersion "github.com/huhouhua/go-nuget/version"
 )
 
@@ -6,6 +6,7 @@ package creation
 
 import (
 	"archive/zip"
+	"bytes"
 	"encoding/xml"
 	"errors"
 	"fmt"
@@ -18,10 +19,11 @@ import (
 	"strings"
 	"time"
 
+	"github.com/huhouhua/go-nuget/framework"
 	"github.com/huhouhua/go-nuget/internal/consts"
-	"github.com/huhouhua/go-nuget/internal/framework"
-	"github.com/huhouhua/go-nuget/internal/meta"
 	"github.com/huhouhua/go-nuget/internal/util"
+	"github.com/huhouhua/go-nuget/meta"
+	"github.com/huhouhua/go-nuget/signing"
 
 	"github.com/huhouhua/go-nuget/version"
 )
@@ -229,6 +231,15 @@ func (p *PackageBuilder) Save(w io.Writer) error {
 	return writerPackage.Close()
 }
 
+// SaveSigned Saves the package with an author signature, see signing.Sign.
+func (p *PackageBuilder) SaveSigned(w io.Writer, opts *signing.SignOptions) error {
+	buf := &bytes.Buffer{}
+	if err := p.Save(buf); err != nil {
+		return err
+	}
+	return signing.Sign(bytes.NewReader(buf.Bytes()), int64(buf.Len()), w, opts)
+}
+
 func (p *PackageBuilder) PopulateFiles(basePath string, files []*ManifestFile) error {
 	for _, file := range files {
 		if err := p.AddFiles(basePath, file.Source, file.Target, file.Exclude); err != nil {
@@ -6,15 +6,24 @@ package creation
 
 import (
 	"archive/zip"
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"io"
 	"log"
+	"math/big"
 	"net/url"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
-	"github.com/huhouhua/go-nuget/internal/framework"
-	"github.com/huhouhua/go-nuget/internal/meta"
+	"github.com/huhouhua/go-nuget/framework"
+	"github.com/huhouhua/go-nuget/meta"
+	"github.com/huhouhua/go-nuget/signing"
 
 	nugetVersion "github.com/huhouhua/go-nuget/version"
 
@@ -57,7 +66,7 @@ func TestCreatePackage(t *testing.T) {
 	builder.EmitRequireLicenseAcceptance = true
 	builder.DevelopmentDependency = true
 	builder.Serviceable = true
-	netstandard14, err := framework.Parse("netstandard1.4")
+	netstandard14, err := framework.ParseFromDefault("netstandard1.4")
 	require.NoError(t, err)
 
 	builder.TargetFrameworks = append(builder.TargetFrameworks, netstandard14)
@@ -69,7 +78,7 @@ func TestCreatePackage(t *testing.T) {
 	// License metadata
 	builder.LicenseMetadata = NewLicense(Expression, "MIT", nugetVersion.NewVersionFrom(1, 0, 0, "", ""))
 
-	net80, err := framework.Parse("net8.0")
+	net80, err := framework.ParseFromDefault("net8.0")
 	require.NoError(t, err)
 
 	// Package assembly references
@@ -89,7 +98,7 @@ func TestCreatePackage(t *testing.T) {
 		TargetFramework: netstandard14,
 		References:      []string{"System.Xml.Linq.dll", "System.Xml.Linq.dll", "System.Xml.Linq.dll"},
 	})
-	net50, err := framework.Parse("net5.0")
+	net50, err := framework.ParseFromDefault("net5.0")
 	require.NoError(t, err)
 
 	// Framework reference groups
@@ -160,6 +169,42 @@ func TestCreatePackage(t *testing.T) {
 	unzip(t, nupkgPath, destDir)
 }
 
+func TestPackageBuilder_SaveSigned(t *testing.T) {
+	builder := NewPackageBuilder(false, true, &log.Logger{})
+	builder.Id = "MyPackage"
+	v, err := nugetVersion.Parse("1.0.0")
+	require.NoError(t, err)
+	builder.Version = v
+	builder.Description = "My signed package."
+	builder.Authors = append(builder.Authors, "Sample author")
+	builder.Files = append(builder.Files, NewPhysicalPackageFile("../testdata/README.md", "docs/README.md", nil))
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Sample author"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, builder.SaveSigned(buf, &signing.SignOptions{Certificate: cert, Signer: key}))
+
+	roots := x509.NewCertPool()
+	roots.AddCert(cert)
+	result, err := signing.Verify(bytes.NewReader(buf.Bytes()), int64(buf.Len()), &signing.VerifyOptions{Roots: roots})
+	require.NoError(t, err)
+	require.True(t, result.Valid(), "issues: %v", result.Err())
+	require.Equal(t, signing.SignatureTypeAuthor, result.Signature.Type)
+}
+
 //func TestUnzip(t *testing.T) {
 //	nupkgPath := "../_output/MyPackage.nupkg"
 //	destDir := "../_output/test"
@@ -9,7 +9,7 @@ import (
 	"strings"
 	"time"
 
-	"github.com/huhouhua/go-nuget/internal/framework"
+	"github.com/huhouhua/go-nuget/framework"
 )
 
 type PackageFile interface {
@@ -8,8 +8,8 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/huhouhua/go-nuget/internal/framework"
-	"github.com/huhouhua/go-nuget/internal/meta"
+	"github.com/huhouhua/go-nuget/framework"
+	"github.com/huhouhua/go-nuget/meta"
 )
 
 // FrameworkReferenceGroup A group of FrameworkReference with the same target framework.
@@ -14,8 +14,8 @@ import (
 	"slices"
 	"strings"
 
+	"github.com/huhouhua/go-nuget/framework"
 	"github.com/huhouhua/go-nuget/internal/consts"
-	"github.com/huhouhua/go-nuget/internal/framework"
 	"github.com/huhouhua/go-nuget/internal/util"
 )
 
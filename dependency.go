@@ -5,31 +5,182 @@
 package nuget
 
 import (
+	"fmt"
 	"net/http"
+	"strings"
 
-	"github.com/huhouhua/go-nuget/internal/framework"
+	"github.com/huhouhua/go-nuget/framework"
+	"github.com/huhouhua/go-nuget/meta"
 )
 
 type DependencyInfoResource struct {
 	client *Client
 }
 
+// SourcePackageDependencyInfo Package dependencies of a single package reduced to the nearest target framework.
+type SourcePackageDependencyInfo struct {
+	*meta.PackageIdentity
+
+	// Dependencies Package dependencies of the nearest framework group
+	Dependencies []*meta.Dependency
+
+	// Listed True if the package is listed on the source
+	Listed bool
+}
+
+// RemoteSourceDependencyInfo Package dependencies of a single package for all target frameworks.
+type RemoteSourceDependencyInfo struct {
+	*meta.PackageIdentity
+
+	// DependencyGroups Package dependencies grouped by target framework
+	DependencyGroups []*meta.PackageDependencyGroup
+
+	// Listed True if the package is listed on the source
+	Listed bool
+}
+
 // ResolvePackage Retrieve dependency info for a single package.
-// Returns dependency info for the given package if it exists. If the package is not found null is
+// Returns dependency info for the given package if it exists. If the package is not found ErrNotFound is returned.
 func (d *DependencyInfoResource) ResolvePackage(
 	id, version string,
 	fw *framework.Framework,
 	options ...RequestOptionFunc,
-) (*http.Response, error) {
-	return nil, nil
+) (*SourcePackageDependencyInfo, *http.Response, error) {
+	if fw == nil {
+		return nil, nil, fmt.Errorf("framework is required")
+	}
+	info, resp, err := d.client.FindPackageResource.GetDependencyInfo(id, version, options...)
+	if err != nil {
+		return nil, resp, err
+	}
+	listed := true
+	if d.client.getResourceURL(RegistrationsBaseURL) != nil {
+		var metadata *PackageSearchMetadataRegistration
+		if metadata, resp, err = d.client.MetadataResource.GetMetadata(id, version, options...); err != nil {
+			return nil, resp, err
+		}
+		listed = metadata.IsListed
+	}
+	dependencies, err := getNearestDependencies(info.DependencyGroups, fw)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &SourcePackageDependencyInfo{
+		PackageIdentity: info.PackageIdentity,
+		Dependencies:    dependencies,
+		Listed:          listed,
+	}, resp, nil
 }
 
 // ResolveAllPackage Retrieve the available packages and their dependencies.
-func (d *DependencyInfoResource) ResolveAllPackage() {
-
+func (d *DependencyInfoResource) ResolveAllPackage(
+	id string,
+	fw *framework.Framework,
+	options ...RequestOptionFunc,
+) ([]*SourcePackageDependencyInfo, *http.Response, error) {
+	if fw == nil {
+		return nil, nil, fmt.Errorf("framework is required")
+	}
+	infos, resp, err := d.ResolveAllPackageFromRemote(id, options...)
+	if err != nil {
+		return nil, resp, err
+	}
+	packages := make([]*SourcePackageDependencyInfo, 0, len(infos))
+	for _, info := range infos {
+		dependencies, err := getNearestDependencies(info.DependencyGroups, fw)
+		if err != nil {
+			return nil, resp, err
+		}
+		packages = append(packages, &SourcePackageDependencyInfo{
+			PackageIdentity: info.PackageIdentity,
+			Dependencies:    dependencies,
+			Listed:          info.Listed,
+		})
+	}
+	return packages, resp, nil
 }
 
 // ResolveAllPackageFromRemote Retrieve the available packages and their dependencies.
-func (d *DependencyInfoResource) ResolveAllPackageFromRemote() {
+func (d *DependencyInfoResource) ResolveAllPackageFromRemote(
+	id string,
+	options ...RequestOptionFunc,
+) ([]*RemoteSourceDependencyInfo, *http.Response, error) {
+	if d.client.getResourceURL(RegistrationsBaseURL) == nil {
+		return nil, nil, fmt.Errorf("the source does not support %s", RegistrationsBaseURL)
+	}
+	opt := &ListMetadataOptions{
+		IncludePrerelease: true,
+		IncludeUnlisted:   true,
+	}
+	list, resp, err := d.client.MetadataResource.ListMetadata(id, opt, options...)
+	if err != nil {
+		return nil, resp, err
+	}
+	packages := make([]*RemoteSourceDependencyInfo, 0, len(list))
+	for _, metadata := range list {
+		info, err := newDependencyInfoFromRegistration(metadata)
+		if err != nil {
+			return nil, resp, err
+		}
+		packages = append(packages, &RemoteSourceDependencyInfo{
+			PackageIdentity:  info.PackageIdentity,
+			DependencyGroups: info.DependencyGroups,
+			Listed:           metadata.IsListed,
+		})
+	}
+	return packages, resp, nil
+}
+
+// newDependencyInfoFromRegistration creates a PackageDependencyInfo from a registration catalog entry.
+func newDependencyInfoFromRegistration(
+	metadata *PackageSearchMetadataRegistration,
+) (*meta.PackageDependencyInfo, error) {
+	identity, err := metadata.Identity()
+	if err != nil {
+		return nil, err
+	}
+	info := meta.NewPackageDependencyInfo(identity, nil, nil)
+	for _, group := range metadata.DependencySets {
+		if group != nil {
+			info.DependencyGroups = append(info.DependencyGroups, group)
+		}
+	}
+	return info, nil
+}
+
+// getNearestDependencies returns the dependencies of the group nearest to the target framework.
+// Groups without a target framework apply to every framework and are used when no specific group matches.
+func getNearestDependencies(
+	groups []*meta.PackageDependencyGroup,
+	target *framework.Framework,
+) ([]*meta.Dependency, error) {
+	frameworks := make([]*framework.Framework, len(groups))
+	specific := make([]*framework.Framework, 0, len(groups))
+	for i, group := range groups {
+		fw, err := parseGroupFramework(group.TargetFramework)
+		if err != nil {
+			return nil, err
+		}
+		frameworks[i] = fw
+		if fw.IsSpecificFramework() {
+			specific = append(specific, fw)
+		}
+	}
+	nearest := framework.GetNearest(target, specific)
+	dependencies := make([]*meta.Dependency, 0)
+	for i, group := range groups {
+		fw := frameworks[i]
+		if (nearest != nil && fw.Equals(nearest)) || (nearest == nil && !fw.IsSpecificFramework()) {
+			dependencies = append(dependencies, group.Packages...)
+		}
+	}
+	return dependencies, nil
+}
 
+// parseGroupFramework parses the target framework of a dependency group, an empty value means any framework.
+func parseGroupFramework(targetFramework string) (*framework.Framework, error) {
+	if strings.TrimSpace(targetFramework) == "" {
+		return framework.NewFramework(framework.Any), nil
+	}
+	return framework.ParseFromDefault(targetFramework)
 }
@@ -0,0 +1,433 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package nuget
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/huhouhua/go-nuget/framework"
+	"github.com/huhouhua/go-nuget/meta"
+
+	nugetVersion "github.com/huhouhua/go-nuget/version"
+)
+
+// DependencyProvider provides the package versions and dependency information walked by the graph resolver.
+// FindPackageResource implements this interface.
+type DependencyProvider interface {
+	// ListAllVersions gets all package versions for a package ID.
+	ListAllVersions(id string, options ...RequestOptionFunc) ([]*nugetVersion.Version, *http.Response, error)
+
+	// GetDependencyInfo gets dependency information for a specific package.
+	GetDependencyInfo(
+		id, version string,
+		options ...RequestOptionFunc,
+	) (*meta.PackageDependencyInfo, *http.Response, error)
+}
+
+// GraphNode A package selected in the dependency graph.
+type GraphNode struct {
+	*meta.PackageIdentity
+
+	// Range The version range that selected this package
+	Range *nugetVersion.VersionRange
+
+	// Depth Distance from the root dependencies, root dependencies have a depth of 0
+	Depth int
+
+	// Parent The package that first brought this package into the graph, nil for root dependencies
+	Parent *GraphNode
+
+	// Dependencies The selected packages this package depends on
+	Dependencies []*GraphNode
+}
+
+// path returns the packages from the root dependency down to this node.
+func (n *GraphNode) path() []string {
+	path := make([]string, 0, n.Depth+1)
+	for current := n; current != nil; current = current.Parent {
+		path = append([]string{fmt.Sprintf("%s %s", current.Id, current.Version.OriginalVersion)}, path...)
+	}
+	return path
+}
+
+// DependencyGraph The transitive dependency graph of a set of root dependencies.
+type DependencyGraph struct {
+	// Roots The root dependencies of the graph
+	Roots []*GraphNode
+
+	// Downgrades The package downgrades detected while walking the graph
+	Downgrades []*DowngradeError
+
+	selected map[string]*GraphNode
+}
+
+// Packages returns the selected package of every id in the graph ordered by id.
+func (g *DependencyGraph) Packages() []*meta.PackageIdentity {
+	packages := make([]*meta.PackageIdentity, 0, len(g.selected))
+	for _, node := range g.selected {
+		packages = append(packages, node.PackageIdentity)
+	}
+	sort.Slice(packages, func(i, j int) bool {
+		return strings.ToLower(packages[i].Id) < strings.ToLower(packages[j].Id)
+	})
+	return packages
+}
+
+// Find returns the selected node for the package id, or nil if the package is not part of the graph.
+func (g *DependencyGraph) Find(id string) *GraphNode {
+	return g.selected[strings.ToLower(id)]
+}
+
+// DowngradeError A package was resolved to a lower version than a transitive dependency requires,
+// because a dependency nearer to the root selected the lower version.
+type DowngradeError struct {
+	// Id The package id that was downgraded
+	Id string
+
+	// Requested The version range requested by the transitive dependency
+	Requested *nugetVersion.VersionRange
+
+	// Resolved The version selected by the nearer dependency
+	Resolved *nugetVersion.Version
+
+	// Path The dependency path that requested the higher version
+	Path []string
+}
+
+func (e *DowngradeError) Error() string {
+	return fmt.Sprintf("detected package downgrade: %s from %s to %s. %s",
+		e.Id, e.Requested.MinVersion.OriginalVersion, e.Resolved.OriginalVersion, strings.Join(e.Path, " -> "))
+}
+
+// CycleError A package depends on itself through its own dependencies.
+type CycleError struct {
+	// Id The package id that closes the cycle
+	Id string
+
+	// Path The dependency path forming the cycle
+	Path []string
+}
+
+func (e *CycleError) Error() string {
+	return fmt.Sprintf("cycle detected: %s", strings.Join(e.Path, " -> "))
+}
+
+// VersionConflictError Dependencies at the same depth require version ranges of a package that the selected
+// version does not all satisfy, like the NU1107 error of NuGet.
+type VersionConflictError struct {
+	// Id The package id in conflict
+	Id string
+
+	// Paths The dependency paths requesting the package, one per dependency
+	Paths [][]string
+}
+
+func (e *VersionConflictError) Error() string {
+	paths := make([]string, 0, len(e.Paths))
+	for _, path := range e.Paths {
+		paths = append(paths, strings.Join(path, " -> "))
+	}
+	return fmt.Sprintf("version conflict detected for %s: %s", e.Id, strings.Join(paths, ", "))
+}
+
+// DependencyGraphResolver resolves the transitive dependency graph of a set of root dependencies for a target
+// framework, applying the NuGet rules:
+//   - lowest applicable version: a dependency resolves to the lowest version satisfying its range
+//   - direct dependency wins: the dependency nearest to the root decides the version of a package
+//   - cousin dependencies: between dependencies at the same depth the highest version wins
+type DependencyGraphResolver struct {
+	provider  DependencyProvider
+	framework *framework.Framework
+	versions  map[string][]*nugetVersion.Version
+}
+
+// NewDependencyGraphResolver creates a resolver walking the given provider for the target framework.
+func NewDependencyGraphResolver(provider DependencyProvider, fw *framework.Framework) *DependencyGraphResolver {
+	return &DependencyGraphResolver{
+		provider:  provider,
+		framework: fw,
+		versions:  make(map[string][]*nugetVersion.Version),
+	}
+}
+
+// pendingDependency is a dependency waiting to be resolved at the current depth.
+type pendingDependency struct {
+	dependency *meta.Dependency
+	parent     *GraphNode
+}
+
+// Resolve walks the dependencies of the roots and returns the resolved graph.
+// A *CycleError is returned when a package depends on itself, a *VersionConflictError when dependencies at the same
+// depth require versions of a package that conflict. When downgrades are detected the graph is returned together
+// with the joined *DowngradeError values.
+func (r *DependencyGraphResolver) Resolve(
+	roots []*meta.Dependency,
+	options ...RequestOptionFunc,
+) (*DependencyGraph, error) {
+	if r.framework == nil {
+		return nil, fmt.Errorf("framework is required")
+	}
+	graph := &DependencyGraph{
+		Roots:      make([]*GraphNode, 0, len(roots)),
+		Downgrades: make([]*DowngradeError, 0),
+		selected:   make(map[string]*GraphNode),
+	}
+	level := make([]*pendingDependency, 0, len(roots))
+	for _, root := range roots {
+		level = append(level, &pendingDependency{dependency: root})
+	}
+	for depth := 0; len(level) > 0; depth++ {
+		order, byId, err := groupPendingDependencies(level)
+		if err != nil {
+			return nil, err
+		}
+		next := make([]*pendingDependency, 0)
+		for _, id := range order {
+			pending := byId[id]
+			if node, ok := graph.selected[id]; ok {
+				// nearest wins, the package was already selected closer to the root
+				for _, p := range pending {
+					graph.addEdge(p.parent, node)
+					if downgrade := checkDowngrade(p, node); downgrade != nil {
+						graph.Downgrades = append(graph.Downgrades, downgrade)
+					}
+				}
+				continue
+			}
+			node, err := r.selectCousin(pending, depth, options...)
+			if err != nil {
+				return nil, err
+			}
+			graph.selected[id] = node
+			for _, p := range pending {
+				graph.addEdge(p.parent, node)
+			}
+			dependencies, err := r.getDependencies(node, options...)
+			if err != nil {
+				return nil, err
+			}
+			for _, dependency := range dependencies {
+				next = append(next, &pendingDependency{dependency: dependency, parent: node})
+			}
+		}
+		level = next
+	}
+	if len(graph.Downgrades) > 0 {
+		errs := make([]error, 0, len(graph.Downgrades))
+		for _, downgrade := range graph.Downgrades {
+			errs = append(errs, downgrade)
+		}
+		return graph, errors.Join(errs...)
+	}
+	return graph, nil
+}
+
+// groupPendingDependencies groups the dependencies of a depth by package id, keeping the order of first appearance.
+// The version range of a dependency is parsed into a copy, the dependencies given by the caller are left untouched.
+func groupPendingDependencies(level []*pendingDependency) ([]string, map[string][]*pendingDependency, error) {
+	order := make([]string, 0)
+	byId := make(map[string][]*pendingDependency)
+	for _, p := range level {
+		id, err := parseID(p.dependency.Id)
+		if err != nil {
+			return nil, nil, err
+		}
+		if p.dependency.VersionRange == nil {
+			dependency := *p.dependency
+			if err = dependency.Parse(); err != nil {
+				return nil, nil, err
+			}
+			p.dependency = &dependency
+		}
+		if err = checkCycle(id, p); err != nil {
+			return nil, nil, err
+		}
+		if _, ok := byId[id]; !ok {
+			order = append(order, id)
+		}
+		byId[id] = append(byId[id], p)
+	}
+	return order, byId, nil
+}
+
+// checkCycle returns a *CycleError if the dependency is one of its own ancestors.
+func checkCycle(id string, p *pendingDependency) error {
+	for ancestor := p.parent; ancestor != nil; ancestor = ancestor.Parent {
+		if strings.EqualFold(ancestor.Id, id) {
+			return &CycleError{
+				Id:   p.dependency.Id,
+				Path: append(p.parent.path(), p.dependency.Id),
+			}
+		}
+	}
+	return nil
+}
+
+// checkDowngrade returns a *DowngradeError if the selected node is lower than the dependency requires.
+func checkDowngrade(p *pendingDependency, node *GraphNode) *DowngradeError {
+	versionRange := p.dependency.VersionRange
+	if versionRange == nil || !versionRange.HasLowerBound() || versionRange.Satisfies(node.Version) ||
+		node.Version.Semver.Compare(versionRange.MinVersion.Semver) >= 0 {
+		return nil
+	}
+	path := make([]string, 0)
+	if p.parent != nil {
+		path = p.parent.path()
+	}
+	return &DowngradeError{
+		Id:        node.Id,
+		Requested: versionRange,
+		Resolved:  node.Version,
+		Path:      append(path, fmt.Sprintf("%s (>= %s)", p.dependency.Id, versionRange.MinVersion.OriginalVersion)),
+	}
+}
+
+// selectCousin resolves the lowest applicable version of every dependency on the package and selects the highest.
+// A *VersionConflictError is returned when the selected version does not satisfy the range of every dependency.
+func (r *DependencyGraphResolver) selectCousin(
+	pending []*pendingDependency,
+	depth int,
+	options ...RequestOptionFunc,
+) (*GraphNode, error) {
+	var selected *GraphNode
+	for _, p := range pending {
+		v, err := r.findBestMatch(p.dependency, options...)
+		if err != nil {
+			return nil, err
+		}
+		if selected != nil && v.Semver.Compare(selected.Version.Semver) <= 0 {
+			continue
+		}
+		selected = &GraphNode{
+			PackageIdentity: &meta.PackageIdentity{Id: p.dependency.Id, Version: v},
+			Range:           p.dependency.VersionRange,
+			Depth:           depth,
+			Parent:          p.parent,
+			Dependencies:    make([]*GraphNode, 0),
+		}
+	}
+	if err := checkConflict(pending, selected); err != nil {
+		return nil, err
+	}
+	return selected, nil
+}
+
+// checkConflict returns a *VersionConflictError if the selected node does not satisfy the range of a dependency.
+func checkConflict(pending []*pendingDependency, node *GraphNode) *VersionConflictError {
+	conflict := false
+	paths := make([][]string, 0, len(pending))
+	for _, p := range pending {
+		versionRange := p.dependency.VersionRange
+		if versionRange != nil && !versionRange.Satisfies(node.Version) {
+			conflict = true
+		}
+		path := make([]string, 0)
+		if p.parent != nil {
+			path = p.parent.path()
+		}
+		requested := p.dependency.Id
+		if versionRange != nil {
+			pretty, _ := versionRange.PrettyPrint()
+			requested = fmt.Sprintf("%s %s", p.dependency.Id, pretty)
+		}
+		paths = append(paths, append(path, requested))
+	}
+	if !conflict {
+		return nil
+	}
+	return &VersionConflictError{Id: node.Id, Paths: paths}
+}
+
+// findBestMatch returns the lowest available version satisfying the dependency range, or the highest for floating
+// ranges. Prerelease versions are only considered when the lower bound of the range is a prerelease.
+func (r *DependencyGraphResolver) findBestMatch(
+	dependency *meta.Dependency,
+	options ...RequestOptionFunc,
+) (*nugetVersion.Version, error) {
+	versions, err := r.listAllVersions(dependency.Id, options...)
+	if err != nil {
+		return nil, err
+	}
+	versionRange := dependency.VersionRange
+	allowPrerelease := versionRange != nil && versionRange.HasLowerBound() &&
+		versionRange.MinVersion.Semver.Prerelease() != ""
+	var best *nugetVersion.Version
+	for _, v := range versions {
+		if v.Semver.Prerelease() != "" && !allowPrerelease {
+			continue
+		}
+		if versionRange != nil && !versionRange.Satisfies(v) {
+			continue
+		}
+		if best == nil {
+			best = v
+			continue
+		}
+		floating := versionRange != nil && versionRange.IsFloating()
+		if (floating && v.Semver.GreaterThan(best.Semver)) || (!floating && v.Semver.LessThan(best.Semver)) {
+			best = v
+		}
+	}
+	if best == nil {
+		versionRangeRaw := ""
+		if versionRange != nil {
+			versionRangeRaw, _ = versionRange.PrettyPrint()
+		}
+		return nil, fmt.Errorf("unable to find package %s %s", dependency.Id, versionRangeRaw)
+	}
+	return best, nil
+}
+
+// listAllVersions returns the cached versions of the package id.
+func (r *DependencyGraphResolver) listAllVersions(
+	id string,
+	options ...RequestOptionFunc,
+) ([]*nugetVersion.Version, error) {
+	key := strings.ToLower(id)
+	if versions, ok := r.versions[key]; ok {
+		return versions, nil
+	}
+	versions, _, err := r.provider.ListAllVersions(id, options...)
+	if err != nil {
+		return nil, err
+	}
+	r.versions[key] = versions
+	return versions, nil
+}
+
+// getDependencies returns the dependencies of the node nearest to the target framework.
+func (r *DependencyGraphResolver) getDependencies(
+	node *GraphNode,
+	options ...RequestOptionFunc,
+) ([]*meta.Dependency, error) {
+	info, _, err := r.provider.GetDependencyInfo(node.Id, node.Version.OriginalVersion, options...)
+	if err != nil {
+		return nil, err
+	}
+	return getNearestDependencies(info.DependencyGroups, r.framework)
+}
+
+// addEdge links the parent to the selected node, root dependencies are added to the graph roots.
+func (g *DependencyGraph) addEdge(parent, node *GraphNode) {
+	if parent == nil {
+		g.Roots = append(g.Roots, node)
+		return
+	}
+	parent.Dependencies = append(parent.Dependencies, node)
+}
+
+// ResolveGraph resolves the transitive dependency graph of the root dependencies for the target framework.
+// See DependencyGraphResolver for the rules applied.
+func (d *DependencyInfoResource) ResolveGraph(
+	roots []*meta.Dependency,
+	fw *framework.Framework,
+	options ...RequestOptionFunc,
+) (*DependencyGraph, error) {
+	return NewDependencyGraphResolver(d.client.FindPackageResource, fw).Resolve(roots, options...)
+}
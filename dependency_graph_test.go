@@ -0,0 +1,272 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package nuget
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/huhouhua/go-nuget/framework"
+	"github.com/huhouhua/go-nuget/meta"
+
+	nugetVersion "github.com/huhouhua/go-nuget/version"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDependencyProvider serves package versions and dependencies from memory,
+// packages are keyed by "id/version" and dependencies are written as "id range".
+type fakeDependencyProvider map[string][]string
+
+func (f fakeDependencyProvider) ListAllVersions(
+	id string,
+	_ ...RequestOptionFunc,
+) ([]*nugetVersion.Version, *http.Response, error) {
+	versions := make([]*nugetVersion.Version, 0)
+	for key := range f {
+		parts := strings.Split(key, "/")
+		if strings.EqualFold(parts[0], id) {
+			v, err := nugetVersion.Parse(parts[1])
+			if err != nil {
+				return nil, nil, err
+			}
+			versions = append(versions, v)
+		}
+	}
+	if len(versions) == 0 {
+		return nil, nil, ErrNotFound
+	}
+	return versions, nil, nil
+}
+
+func (f fakeDependencyProvider) GetDependencyInfo(
+	id, version string,
+	_ ...RequestOptionFunc,
+) (*meta.PackageDependencyInfo, *http.Response, error) {
+	dependencies, ok := f[fmt.Sprintf("%s/%s", strings.ToLower(id), version)]
+	if !ok {
+		return nil, nil, ErrNotFound
+	}
+	packages := make([]*meta.Dependency, 0, len(dependencies))
+	for _, dependency := range dependencies {
+		parts := strings.Split(dependency, " ")
+		packages = append(packages, &meta.Dependency{Id: parts[0], VersionRaw: parts[1]})
+	}
+	group, err := meta.NewPackageDependencyGroup("", packages...)
+	if err != nil {
+		return nil, nil, err
+	}
+	identity, err := meta.NewPackageIdentity(id, version)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &meta.PackageDependencyInfo{
+		PackageIdentity:  identity,
+		DependencyGroups: []*meta.PackageDependencyGroup{group},
+	}, nil, nil
+}
+
+func resolvedVersions(graph *DependencyGraph) map[string]string {
+	versions := make(map[string]string)
+	for _, identity := range graph.Packages() {
+		versions[identity.Id] = identity.Version.OriginalVersion
+	}
+	return versions
+}
+
+func TestDependencyGraphResolver_Resolve(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider fakeDependencyProvider
+		roots    []string
+		want     map[string]string
+	}{
+		{
+			name: "lowest applicable version",
+			provider: fakeDependencyProvider{
+				"a/1.0.0": {"b 1.0.0"},
+				"b/1.0.0": {},
+				"b/1.5.0": {},
+				"b/2.0.0": {},
+			},
+			roots: []string{"a [1.0.0, )"},
+			want:  map[string]string{"a": "1.0.0", "b": "1.0.0"},
+		},
+		{
+			name: "lowest available version when the minimum does not exist",
+			provider: fakeDependencyProvider{
+				"a/1.0.0": {"b 1.1.0"},
+				"b/1.2.0": {},
+				"b/2.0.0": {},
+			},
+			roots: []string{"a 1.0.0"},
+			want:  map[string]string{"a": "1.0.0", "b": "1.2.0"},
+		},
+		{
+			name: "direct dependency wins",
+			provider: fakeDependencyProvider{
+				"a/1.0.0": {"b 1.0.0"},
+				"b/1.0.0": {},
+				"b/1.5.0": {},
+			},
+			roots: []string{"a 1.0.0", "b 1.5.0"},
+			want:  map[string]string{"a": "1.0.0", "b": "1.5.0"},
+		},
+		{
+			name: "cousin dependencies select the highest version",
+			provider: fakeDependencyProvider{
+				"a/1.0.0": {"c 1.0.0"},
+				"b/1.0.0": {"c 2.0.0"},
+				"c/1.0.0": {},
+				"c/2.0.0": {"d 1.0.0"},
+				"d/1.0.0": {},
+			},
+			roots: []string{"a 1.0.0", "b 1.0.0"},
+			want:  map[string]string{"a": "1.0.0", "b": "1.0.0", "c": "2.0.0", "d": "1.0.0"},
+		},
+		{
+			name: "dependencies of rejected versions are not walked",
+			provider: fakeDependencyProvider{
+				"a/1.0.0": {"b 1.0.0"},
+				"b/1.0.0": {"c 1.0.0"},
+				"b/2.0.0": {},
+				"c/1.0.0": {},
+			},
+			roots: []string{"a 1.0.0", "b 2.0.0"},
+			want:  map[string]string{"a": "1.0.0", "b": "2.0.0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roots := make([]*meta.Dependency, 0, len(tt.roots))
+			for _, root := range tt.roots {
+				id, versionRange, _ := strings.Cut(root, " ")
+				roots = append(roots, &meta.Dependency{Id: id, VersionRaw: versionRange})
+			}
+			graph, err := NewDependencyGraphResolver(tt.provider, framework.Net80).Resolve(roots)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, resolvedVersions(graph))
+		})
+	}
+}
+
+func TestDependencyGraphResolver_Downgrade(t *testing.T) {
+	provider := fakeDependencyProvider{
+		"a/1.0.0": {"b 2.0.0"},
+		"b/1.0.0": {},
+		"b/2.0.0": {},
+	}
+	roots := []*meta.Dependency{
+		{Id: "a", VersionRaw: "1.0.0"},
+		{Id: "b", VersionRaw: "1.0.0"},
+	}
+	graph, err := NewDependencyGraphResolver(provider, framework.Net80).Resolve(roots)
+	require.Error(t, err)
+	require.NotNil(t, graph)
+	require.Equal(t, map[string]string{"a": "1.0.0", "b": "1.0.0"}, resolvedVersions(graph))
+
+	var downgrade *DowngradeError
+	require.True(t, errors.As(err, &downgrade))
+	require.Equal(t, "b", downgrade.Id)
+	require.Equal(t, "1.0.0", downgrade.Resolved.OriginalVersion)
+	require.Equal(t, []string{"a 1.0.0", "b (>= 2.0.0)"}, downgrade.Path)
+	require.Equal(t, []*DowngradeError{downgrade}, graph.Downgrades)
+	require.Equal(t, "detected package downgrade: b from 2.0.0 to 1.0.0. a 1.0.0 -> b (>= 2.0.0)", downgrade.Error())
+}
+
+func TestDependencyGraphResolver_Cycle(t *testing.T) {
+	provider := fakeDependencyProvider{
+		"a/1.0.0": {"b 1.0.0"},
+		"b/1.0.0": {"c 1.0.0"},
+		"c/1.0.0": {"a 1.0.0"},
+	}
+	graph, err := NewDependencyGraphResolver(provider, framework.Net80).
+		Resolve([]*meta.Dependency{{Id: "a", VersionRaw: "1.0.0"}})
+	require.Nil(t, graph)
+
+	var cycle *CycleError
+	require.True(t, errors.As(err, &cycle))
+	require.Equal(t, "a", cycle.Id)
+	require.Equal(t, "cycle detected: a 1.0.0 -> b 1.0.0 -> c 1.0.0 -> a", cycle.Error())
+}
+
+func TestDependencyGraphResolver_Conflict(t *testing.T) {
+	provider := fakeDependencyProvider{
+		"a/1.0.0": {"c [1.0.0,2.0.0)"},
+		"b/1.0.0": {"c [3.0.0,)"},
+		"c/1.0.0": {},
+		"c/3.0.0": {},
+	}
+	roots := []*meta.Dependency{
+		{Id: "a", VersionRaw: "1.0.0"},
+		{Id: "b", VersionRaw: "1.0.0"},
+	}
+	graph, err := NewDependencyGraphResolver(provider, framework.Net80).Resolve(roots)
+	require.Nil(t, graph)
+
+	var conflict *VersionConflictError
+	require.True(t, errors.As(err, &conflict))
+	require.Equal(t, "c", conflict.Id)
+	require.Equal(t, [][]string{
+		{"a 1.0.0", "c (>= 1.0.0 && < 2.0.0)"},
+		{"b 1.0.0", "c (>= 3.0.0)"},
+	}, conflict.Paths)
+	require.Equal(t, "version conflict detected for c: a 1.0.0 -> c (>= 1.0.0 && < 2.0.0), b 1.0.0 -> c (>= 3.0.0)",
+		conflict.Error())
+
+	// the roots given by the caller are not modified by the resolver.
+	require.Nil(t, roots[0].VersionRange)
+	require.Nil(t, roots[1].VersionRange)
+}
+
+func TestDependencyGraphResolver_ErrorScenarios(t *testing.T) {
+	provider := fakeDependencyProvider{
+		"a/1.0.0": {"b 3.0.0"},
+		"b/1.0.0": {},
+	}
+	_, err := NewDependencyGraphResolver(provider, nil).Resolve(nil)
+	require.Equal(t, errors.New("framework is required"), err)
+
+	_, err = NewDependencyGraphResolver(provider, framework.Net80).
+		Resolve([]*meta.Dependency{{Id: "a", VersionRaw: "1.0.0"}})
+	require.Equal(t, errors.New("unable to find package b (>= 3.0.0)"), err)
+
+	_, err = NewDependencyGraphResolver(provider, framework.Net80).
+		Resolve([]*meta.Dependency{{Id: "c", VersionRaw: "1.0.0"}})
+	require.Equal(t, ErrNotFound, err)
+}
+
+// setupDependencyGraph serves the versions and the nuspec of TestDependency from the flat container.
+func setupDependencyGraph(t *testing.T) *Client {
+	mux, client := setup(t, index_V3)
+
+	baseURL := client.getResourceURL(PackageBaseAddress)
+	u := fmt.Sprintf("%s/testdependency/index.json", baseURL.Path)
+	mux.HandleFunc(u, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"versions":["1.0.0"]}`)
+	})
+
+	u = fmt.Sprintf("%s/testdependency/1.0.0/testdependency.nuspec", baseURL.Path)
+	mux.HandleFunc(u, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		mustWriteHTTPResponse(t, w, "testdata/testDependency.nuspec")
+	})
+	return client
+}
+
+func TestDependencyInfoResource_ResolveGraph(t *testing.T) {
+	client := setupDependencyGraph(t)
+
+	_, err := client.DependencyResource.ResolveGraph(
+		[]*meta.Dependency{{Id: "TestDependency", VersionRaw: "1.0.0"}},
+		framework.NetStandard20,
+	)
+	// the root package resolves, the flat container does not serve the versions of newtonsoft.json
+	require.Equal(t, ErrNotFound, err)
+}
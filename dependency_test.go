@@ -3,3 +3,194 @@
 // license that can be found in the LICENSE file.
 
 package nuget
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/huhouhua/go-nuget/framework"
+	"github.com/huhouhua/go-nuget/meta"
+
+	nugetVersion "github.com/huhouhua/go-nuget/version"
+
+	"github.com/stretchr/testify/require"
+)
+
+func setupDependency(t *testing.T) *Client {
+	mux, client := setup(t, index_V3)
+
+	baseURL := client.getResourceURL(PackageBaseAddress)
+	u := fmt.Sprintf("%s/testdependency/1.0.0/testdependency.nuspec", baseURL.Path)
+	mux.HandleFunc(u, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		mustWriteHTTPResponse(t, w, "testdata/testDependency.nuspec")
+	})
+
+	registrationURL := client.getResourceURL(RegistrationsBaseURL)
+	u = fmt.Sprintf("%s/testdependency/index.json", registrationURL.Path)
+	mux.HandleFunc(u, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		mustWriteHTTPResponse(t, w, "testdata/testDependency_registration.json")
+	})
+	return client
+}
+
+func dependencyIds(dependencies []*meta.Dependency) []string {
+	ids := make([]string, 0, len(dependencies))
+	for _, dependency := range dependencies {
+		ids = append(ids, dependency.Id)
+	}
+	return ids
+}
+
+func TestDependencyInfoResource_ResolvePackage(t *testing.T) {
+	client := setupDependency(t)
+
+	tests := []struct {
+		name      string
+		framework *framework.Framework
+		want      []string
+	}{
+		{
+			name:      "exact framework match",
+			framework: framework.Net48,
+			want:      []string{"Newtonsoft.Json", "Microsoft.Extensions.Logging"},
+		},
+		{
+			name:      "nearest lower framework version",
+			framework: framework.Net481,
+			want:      []string{"Newtonsoft.Json", "Microsoft.Extensions.Logging"},
+		},
+		{
+			name:      "netstandard group",
+			framework: framework.NetStandard21,
+			want:      []string{"Newtonsoft.Json"},
+		},
+		{
+			name:      "no compatible group",
+			framework: framework.Net45,
+			want:      []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, resp, err := client.DependencyResource.ResolvePackage("TestDependency", "1.0.0", tt.framework)
+			require.NoError(t, err)
+			require.NotNil(t, resp)
+			require.Equal(t, "TestDependency", info.Id)
+			require.Equal(t, nugetVersion.NewVersionFrom(1, 0, 0, "", ""), info.Version)
+			require.False(t, info.Listed)
+			require.Equal(t, tt.want, dependencyIds(info.Dependencies))
+		})
+	}
+}
+
+func TestDependencyInfoResource_ResolvePackage_ErrorScenarios(t *testing.T) {
+	client := setupDependency(t)
+
+	_, _, err := client.DependencyResource.ResolvePackage("TestDependency", "1.0.0", nil)
+	require.Equal(t, errors.New("framework is required"), err)
+
+	_, _, err = client.DependencyResource.ResolvePackage("TestDependency", "3.0.0", framework.Net48)
+	require.Equal(t, ErrNotFound, err)
+
+	_, _, err = client.DependencyResource.ResolvePackage("", "1.0.0", framework.Net48)
+	require.Equal(t, errors.New("id is empty"), err)
+}
+
+func TestDependencyInfoResource_ResolveAllPackage(t *testing.T) {
+	client := setupDependency(t)
+
+	list, resp, err := client.DependencyResource.ResolveAllPackage("TestDependency", framework.Net48)
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.Len(t, list, 2)
+
+	require.Equal(t, "1.0.0", list[0].Version.OriginalVersion)
+	require.False(t, list[0].Listed)
+	require.Equal(t, []string{"Newtonsoft.Json", "Microsoft.Extensions.Logging"}, dependencyIds(list[0].Dependencies))
+
+	require.Equal(t, "2.0.0", list[1].Version.OriginalVersion)
+	require.True(t, list[1].Listed)
+	require.Equal(t, []string{"Newtonsoft.Json"}, dependencyIds(list[1].Dependencies))
+
+	_, _, err = client.DependencyResource.ResolveAllPackage("TestDependency", nil)
+	require.Equal(t, errors.New("framework is required"), err)
+}
+
+func TestDependencyInfoResource_ResolveAllPackageFromRemote(t *testing.T) {
+	client := setupDependency(t)
+
+	list, _, err := client.DependencyResource.ResolveAllPackageFromRemote("TestDependency")
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	require.Len(t, list[0].DependencyGroups, 2)
+	require.Equal(t, ".NETFramework4.8", list[0].DependencyGroups[0].TargetFramework)
+	require.Equal(t, ".NETStandard2.0", list[0].DependencyGroups[1].TargetFramework)
+	require.Len(t, list[1].DependencyGroups, 1)
+
+	_, _, err = client.DependencyResource.ResolveAllPackageFromRemote("unknown")
+	require.Equal(t, ErrNotFound, err)
+}
+
+func TestGetNearestDependencies(t *testing.T) {
+	newGroup := func(targetFramework string, ids ...string) *meta.PackageDependencyGroup {
+		dependencies := make([]*meta.Dependency, 0, len(ids))
+		for _, id := range ids {
+			dependencies = append(dependencies, &meta.Dependency{Id: id})
+		}
+		return &meta.PackageDependencyGroup{TargetFramework: targetFramework, Packages: dependencies}
+	}
+	tests := []struct {
+		name   string
+		groups []*meta.PackageDependencyGroup
+		target *framework.Framework
+		want   []string
+	}{
+		{
+			name:   "highest compatible version wins",
+			groups: []*meta.PackageDependencyGroup{newGroup("net45", "A"), newGroup("net472", "B"), newGroup("net48", "C")},
+			target: framework.Net472,
+			want:   []string{"B"},
+		},
+		{
+			name:   "any groups are merged when nothing specific matches",
+			groups: []*meta.PackageDependencyGroup{newGroup("Any", "A"), newGroup("Any", "B"), newGroup("net48", "C")},
+			target: framework.Net60,
+			want:   []string{"A", "B"},
+		},
+		{
+			name:   "empty target framework applies to everything",
+			groups: []*meta.PackageDependencyGroup{newGroup("", "A")},
+			target: framework.NetStandard20,
+			want:   []string{"A"},
+		},
+		{
+			name:   "platform specific group is not used for a platform neutral target",
+			groups: []*meta.PackageDependencyGroup{newGroup("net6.0-windows", "A"), newGroup("net5.0", "B")},
+			target: framework.Net60,
+			want:   []string{"B"},
+		},
+		{
+			name:   "netstandard group is used by net5.0 and later",
+			groups: []*meta.PackageDependencyGroup{newGroup("net48", "A"), newGroup("netstandard2.0", "B")},
+			target: framework.Net80,
+			want:   []string{"B"},
+		},
+		{
+			name:   "framework group wins over netstandard",
+			groups: []*meta.PackageDependencyGroup{newGroup("net461", "A"), newGroup("netstandard2.0", "B")},
+			target: framework.Net48,
+			want:   []string{"A"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getNearestDependencies(tt.groups, tt.target)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, dependencyIds(got))
+		})
+	}
+}
@@ -0,0 +1,248 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package nuget
+
+import (
+	"crypto/sha512"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ErrPackageHashMismatch The downloaded nupkg does not match the expected SHA512 hash.
+var ErrPackageHashMismatch = errors.New("package hash mismatch")
+
+// ProgressFunc reports the bytes transferred so far and the total size, total is -1 when unknown.
+type ProgressFunc func(transferred, total int64)
+
+type DownloadOptions struct {
+	// Version The package version to download.
+	Version string
+
+	// Offset Resumes a download with a Range request, the first Offset bytes are not transferred again.
+	Offset int64
+
+	// SHA512 The expected base64 SHA512 hash of the whole nupkg, verified once the download completes.
+	SHA512 string
+
+	// VerifyHash Verifies the download against the packageHash of the registration when SHA512 is empty.
+	VerifyHash bool
+
+	// Progress Called after each chunk written, the transferred bytes include Offset.
+	Progress ProgressFunc
+}
+
+// OpenNupkg downloads a specific package version as a stream, the caller must close it.
+// The package is never read into memory, the hash is verified when the stream reaches EOF
+// and a mismatch is returned as ErrPackageHashMismatch in place of io.EOF.
+func (f *FindPackageResource) OpenNupkg(
+	id string,
+	opt *DownloadOptions,
+	options ...RequestOptionFunc,
+) (io.ReadCloser, *http.Response, error) {
+	packageId, err := parseID(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if opt == nil || strings.TrimSpace(opt.Version) == "" {
+		return nil, nil, fmt.Errorf("version is required")
+	}
+	if opt.Offset < 0 {
+		return nil, nil, fmt.Errorf("offset must not be negative")
+	}
+	expectedHash, err := f.expectedHash(packageId, opt, options...)
+	if err != nil {
+		return nil, nil, err
+	}
+	if expectedHash != "" && opt.Offset > 0 {
+		return nil, nil, fmt.Errorf("hash verification of a resumed download requires DownloadNupkgToFile")
+	}
+
+	packageId, version := PathEscape(packageId), PathEscape(opt.Version)
+	baseURL := f.client.getResourceURL(PackageBaseAddress)
+	u := fmt.Sprintf("%s/%s/%s/%s.%s.nupkg", baseURL.Path, packageId, version, packageId, version)
+
+	req, err := f.client.NewRequest(http.MethodGet, u, baseURL, nil, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Accept", "application/octet-stream")
+	if opt.Offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", opt.Offset))
+	}
+	resp, err := f.client.DoStream(req)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	reader := &downloadReader{body: resp.Body, transferred: opt.Offset, total: -1, progress: opt.Progress}
+	switch {
+	case resp.StatusCode == http.StatusPartialContent:
+		reader.total = contentRangeTotal(resp.Header.Get("Content-Range"))
+	case opt.Offset > 0:
+		// The server ignored the Range header, skip the bytes the caller already has.
+		if _, err = io.CopyN(io.Discard, resp.Body, opt.Offset); err != nil {
+			_ = resp.Body.Close()
+			return nil, resp, err
+		}
+		if resp.ContentLength >= 0 {
+			reader.total = resp.ContentLength
+		}
+	case resp.ContentLength >= 0:
+		reader.total = resp.ContentLength
+	}
+	if expectedHash != "" {
+		reader.hash, reader.expectedHash = sha512.New(), expectedHash
+	}
+	return reader, resp, nil
+}
+
+// DownloadNupkg downloads a specific package version and streams it to w.
+func (f *FindPackageResource) DownloadNupkg(
+	id string,
+	w io.Writer,
+	opt *DownloadOptions,
+	options ...RequestOptionFunc,
+) (*http.Response, error) {
+	reader, resp, err := f.OpenNupkg(id, opt, options...)
+	if err != nil {
+		return resp, err
+	}
+	defer reader.Close()
+	_, err = io.Copy(w, reader)
+	return resp, err
+}
+
+// DownloadNupkgToFile downloads a specific package version to path. An existing file at path is taken
+// as a partially downloaded package and resumed. The hash covers the resumed part too, the file is
+// removed when it does not match, so the next call starts over.
+func (f *FindPackageResource) DownloadNupkgToFile(
+	id, path string,
+	opt *DownloadOptions,
+	options ...RequestOptionFunc,
+) (*http.Response, error) {
+	if opt == nil {
+		return nil, fmt.Errorf("version is required")
+	}
+	packageId, err := parseID(id)
+	if err != nil {
+		return nil, err
+	}
+	expectedHash, err := f.expectedHash(packageId, opt, options...)
+	if err != nil {
+		return nil, err
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	// Hash the part already downloaded, the offset is where it ends.
+	h := sha512.New()
+	offset, err := io.Copy(h, file)
+	if err != nil {
+		return nil, err
+	}
+	rangeOpt := &DownloadOptions{Version: opt.Version, Offset: offset, Progress: opt.Progress}
+	resp, err := f.DownloadNupkg(id, io.MultiWriter(file, h), rangeOpt, options...)
+	if err != nil {
+		if resp == nil || resp.StatusCode != http.StatusRequestedRangeNotSatisfiable {
+			return resp, err
+		}
+		// Nothing left past the offset: the file is complete when it matches the hash,
+		// otherwise it is not a prefix of the package.
+		if expectedHash == "" || base64.StdEncoding.EncodeToString(h.Sum(nil)) != expectedHash {
+			_ = file.Close()
+			_ = os.Remove(path)
+			return resp, err
+		}
+		return resp, nil
+	}
+	if expectedHash != "" && base64.StdEncoding.EncodeToString(h.Sum(nil)) != expectedHash {
+		_ = file.Close()
+		_ = os.Remove(path)
+		return resp, ErrPackageHashMismatch
+	}
+	return resp, nil
+}
+
+// expectedHash returns the hash the download is verified against, empty when it isn't verified.
+func (f *FindPackageResource) expectedHash(
+	id string,
+	opt *DownloadOptions,
+	options ...RequestOptionFunc,
+) (string, error) {
+	if opt.SHA512 != "" || !opt.VerifyHash {
+		return opt.SHA512, nil
+	}
+	metadata, _, err := f.client.MetadataResource.GetMetadata(id, opt.Version, options...)
+	if err != nil {
+		return "", err
+	}
+	if metadata.PackageHash == "" {
+		return "", fmt.Errorf("registration of %s %s has no package hash", id, opt.Version)
+	}
+	if metadata.PackageHashAlgorithm != "" && !strings.EqualFold(metadata.PackageHashAlgorithm, "SHA512") {
+		return "", fmt.Errorf("unsupported package hash algorithm %s", metadata.PackageHashAlgorithm)
+	}
+	return metadata.PackageHash, nil
+}
+
+// downloadReader reports the progress of a download and verifies its hash at EOF.
+type downloadReader struct {
+	body         io.ReadCloser
+	transferred  int64
+	total        int64
+	progress     ProgressFunc
+	hash         hash.Hash
+	expectedHash string
+}
+
+func (d *downloadReader) Read(p []byte) (int, error) {
+	n, err := d.body.Read(p)
+	if n > 0 {
+		d.transferred += int64(n)
+		if d.hash != nil {
+			d.hash.Write(p[:n])
+		}
+		if d.progress != nil {
+			d.progress(d.transferred, d.total)
+		}
+	}
+	if errors.Is(err, io.EOF) && d.hash != nil &&
+		base64.StdEncoding.EncodeToString(d.hash.Sum(nil)) != d.expectedHash {
+		return n, ErrPackageHashMismatch
+	}
+	return n, err
+}
+
+func (d *downloadReader) Close() error {
+	return d.body.Close()
+}
+
+// contentRangeTotal returns the complete length of a Content-Range header, -1 when unknown.
+// Ex: bytes 100-199/200
+func contentRangeTotal(value string) int64 {
+	i := strings.LastIndex(value, "/")
+	if i < 0 {
+		return -1
+	}
+	total, err := strconv.ParseInt(value[i+1:], 10, 64)
+	if err != nil {
+		return -1
+	}
+	return total
+}
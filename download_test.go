@@ -0,0 +1,154 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package nuget
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// setupDownload serves testdata/test.1.0.0.nupkg with Range support, rangeSupport false ignores the Range header.
+func setupDownload(t *testing.T, rangeSupport bool) (*Client, []byte, string) {
+	mux, client := setup(t, index_V3)
+	data, err := os.ReadFile("testdata/test.1.0.0.nupkg")
+	require.NoError(t, err)
+	hash := sha512.Sum512(data)
+	packageHash := base64.StdEncoding.EncodeToString(hash[:])
+
+	baseURL := client.getResourceURL(PackageBaseAddress)
+	mux.HandleFunc(fmt.Sprintf("%s/test/1.0.0/test.1.0.0.nupkg", baseURL.Path), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		if !rangeSupport {
+			r.Header.Del("Range")
+		}
+		http.ServeContent(w, r, "test.1.0.0.nupkg", time.Time{}, bytes.NewReader(data))
+	})
+
+	registrationURL := client.getResourceURL(RegistrationsBaseURL)
+	mux.HandleFunc(fmt.Sprintf("%s/test/index.json", registrationURL.Path), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprintf(w, `{"items":[{"lower":"1.0.0","upper":"1.0.0","items":[{"catalogEntry":{
+			"id":"test","version":"1.0.0","listed":true,
+			"packageHash":%q,"packageHashAlgorithm":"SHA512","packageSize":%d}}]}]}`, packageHash, len(data))
+	})
+	return client, data, packageHash
+}
+
+func TestFindPackageResource_DownloadNupkg(t *testing.T) {
+	client, data, packageHash := setupDownload(t, true)
+
+	var transferred, total int64
+	buf := &bytes.Buffer{}
+	resp, err := client.FindPackageResource.DownloadNupkg("test", buf, &DownloadOptions{
+		Version:    "1.0.0",
+		VerifyHash: true,
+		Progress: func(n, size int64) {
+			transferred, total = n, size
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, data, buf.Bytes())
+	require.Equal(t, int64(len(data)), transferred)
+	require.Equal(t, int64(len(data)), total)
+
+	buf.Reset()
+	_, err = client.FindPackageResource.DownloadNupkg("test", buf, &DownloadOptions{Version: "1.0.0", Offset: 10})
+	require.NoError(t, err)
+	require.Equal(t, data[10:], buf.Bytes())
+
+	_, err = client.FindPackageResource.DownloadNupkg("test", io.Discard, &DownloadOptions{Version: "1.0.0", SHA512: "AAAA"})
+	require.ErrorIs(t, err, ErrPackageHashMismatch)
+
+	_, err = client.FindPackageResource.DownloadNupkg("test", io.Discard, &DownloadOptions{
+		Version: "1.0.0",
+		Offset:  10,
+		SHA512:  packageHash,
+	})
+	require.Error(t, err)
+
+	_, err = client.FindPackageResource.DownloadNupkg("missing", io.Discard, &DownloadOptions{Version: "1.0.0"})
+	require.ErrorIs(t, err, ErrNotFound)
+
+	_, err = client.FindPackageResource.DownloadNupkg("test", io.Discard, &DownloadOptions{})
+	require.Error(t, err)
+}
+
+func TestFindPackageResource_OpenNupkg(t *testing.T) {
+	client, data, packageHash := setupDownload(t, true)
+
+	reader, _, err := client.FindPackageResource.OpenNupkg("test", &DownloadOptions{Version: "1.0.0", SHA512: packageHash})
+	require.NoError(t, err)
+	got, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	require.NoError(t, reader.Close())
+	require.Equal(t, data, got)
+
+	archive, err := NewPackageArchiveReader(bytes.NewReader(got))
+	require.NoError(t, err)
+	nuspec, err := archive.Nuspec()
+	require.NoError(t, err)
+	require.NotEmpty(t, nuspec.Metadata.ID)
+}
+
+func TestFindPackageResource_DownloadNupkgToFile(t *testing.T) {
+	for _, rangeSupport := range []bool{true, false} {
+		t.Run(fmt.Sprintf("range support %v", rangeSupport), func(t *testing.T) {
+			client, data, _ := setupDownload(t, rangeSupport)
+			path := filepath.Join(t.TempDir(), "test.1.0.0.nupkg")
+
+			// Resume a partial download.
+			require.NoError(t, os.WriteFile(path, data[:100], 0644))
+			var first int64 = -1
+			_, err := client.FindPackageResource.DownloadNupkgToFile("test", path, &DownloadOptions{
+				Version:    "1.0.0",
+				VerifyHash: true,
+				Progress: func(n, _ int64) {
+					if first < 0 {
+						first = n
+					}
+				},
+			})
+			require.NoError(t, err)
+			require.Greater(t, first, int64(100))
+			got, err := os.ReadFile(path)
+			require.NoError(t, err)
+			require.Equal(t, data, got)
+
+			// A corrupted partial download is removed.
+			corrupted := append([]byte("corrupted"), data[9:200]...)
+			require.NoError(t, os.WriteFile(path, corrupted, 0644))
+			_, err = client.FindPackageResource.DownloadNupkgToFile("test", path, &DownloadOptions{
+				Version:    "1.0.0",
+				VerifyHash: true,
+			})
+			require.ErrorIs(t, err, ErrPackageHashMismatch)
+			require.NoFileExists(t, path)
+		})
+	}
+
+	client, data, packageHash := setupDownload(t, true)
+	path := filepath.Join(t.TempDir(), "test.1.0.0.nupkg")
+	require.NoError(t, os.WriteFile(path, data, 0644))
+	_, err := client.FindPackageResource.DownloadNupkgToFile("test", path, &DownloadOptions{Version: "1.0.0", SHA512: packageHash})
+	require.NoError(t, err)
+	require.FileExists(t, path)
+}
+
+func TestContentRangeTotal(t *testing.T) {
+	require.Equal(t, int64(200), contentRangeTotal("bytes 100-199/200"))
+	require.Equal(t, int64(-1), contentRangeTotal("bytes 100-199/*"))
+	require.Equal(t, int64(-1), contentRangeTotal(""))
+}
@@ -0,0 +1,200 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package framework
+
+import (
+	"math"
+	"strings"
+
+	"github.com/huhouhua/go-nuget/internal/consts"
+	"github.com/huhouhua/go-nuget/internal/util"
+	"github.com/huhouhua/go-nuget/version"
+)
+
+var (
+	compatibilityProvider *CompatibilityProvider
+
+	// maxVersion Upper bound used for compatibility mappings that support all versions of a framework
+	maxVersion = version.NewVersionFrom(math.MaxInt32, 0, 0, "", "")
+)
+
+// GetCompatibilityProviderInstance Returns the compatibility provider backed by the default framework mappings.
+func GetCompatibilityProviderInstance() *CompatibilityProvider {
+	instanceLock.Lock()
+	defer instanceLock.Unlock()
+	return compatibilityInstance()
+}
+
+// compatibilityInstance must be called with the instance lock held.
+func compatibilityInstance() *CompatibilityProvider {
+	if compatibilityProvider == nil {
+		compatibilityProvider = NewCompatibilityProvider(*providerInstance())
+	}
+	return compatibilityProvider
+}
+
+// IsCompatible True if assets built for the candidate framework can be used by the target framework,
+// using the default framework mappings.
+func IsCompatible(target, candidate *Framework) bool {
+	return GetCompatibilityProviderInstance().IsCompatible(target, candidate)
+}
+
+// CompatibilityProvider Decides whether a project targeting one framework can consume assets of another.
+type CompatibilityProvider struct {
+	mappings FrameworkNameProvider
+}
+
+func NewCompatibilityProvider(mappings FrameworkNameProvider) *CompatibilityProvider {
+	return &CompatibilityProvider{
+		mappings: mappings,
+	}
+}
+
+// FallbackFramework A framework with an ordered list of frameworks to use when nothing is compatible
+// with the primary framework, as AssetTargetFallback does in project files.
+type FallbackFramework struct {
+	*Framework
+
+	// Fallback Frameworks tried in order when nothing is compatible with the primary framework
+	Fallback []*Framework
+}
+
+func NewFallbackFramework(framework *Framework, fallback ...*Framework) *FallbackFramework {
+	return &FallbackFramework{
+		Framework: framework,
+		Fallback:  fallback,
+	}
+}
+
+// IsCompatible True if the target framework can use assets built for the candidate framework.
+// Ex: net48 can use netstandard2.0, net6.0-windows can use net5.0, net45 can use portable-net45+win8
+func (c *CompatibilityProvider) IsCompatible(target, candidate *Framework) bool {
+	if target == nil || candidate == nil {
+		return false
+	}
+	// any is compatible with everything
+	if target.IsAny() || candidate.IsAny() {
+		return true
+	}
+	// unsupported frameworks are only compatible with any
+	if target.IsUnsupported() || candidate.IsUnsupported() {
+		return false
+	}
+	// agnostic assets can be used by every framework
+	if candidate.IsAgnostic() {
+		return true
+	}
+	if target.IsAgnostic() {
+		return false
+	}
+	if target.IsPCL() || candidate.IsPCL() {
+		return c.isPCLCompatible(target, candidate)
+	}
+	for _, fw := range c.getCompatibleTargets(target) {
+		if c.isCompatibleWithTarget(fw, candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsCompatibleWithFallback True if the primary framework or one of its fallback frameworks can use the candidate.
+func (c *CompatibilityProvider) IsCompatibleWithFallback(target *FallbackFramework, candidate *Framework) bool {
+	if target == nil {
+		return false
+	}
+	if c.IsCompatible(target.Framework, candidate) {
+		return true
+	}
+	return util.Some(target.Fallback, func(fw *Framework) bool {
+		return c.IsCompatible(fw, candidate)
+	})
+}
+
+// isPCLCompatible Every framework of the target must be compatible with a framework of the candidate.
+func (c *CompatibilityProvider) isPCLCompatible(target, candidate *Framework) bool {
+	if target.IsPCL() && !candidate.IsPCL() {
+		return c.isCompatibleWithTarget(target, candidate)
+	}
+	targetFrameworks := []*Framework{target}
+	if target.IsPCL() {
+		frameworks, err := c.mappings.GetPortableFrameworksWithInclude(target.Profile, false)
+		if err != nil {
+			return false
+		}
+		targetFrameworks = frameworks
+	}
+	candidateFrameworks := []*Framework{candidate}
+	if candidate.IsPCL() {
+		frameworks, err := c.mappings.GetPortableFrameworksWithInclude(candidate.Profile, true)
+		if err != nil {
+			return false
+		}
+		candidateFrameworks = frameworks
+	}
+	if len(targetFrameworks) == 0 || len(targetFrameworks) > len(candidateFrameworks) {
+		return false
+	}
+	for _, fw := range targetFrameworks {
+		compatible := util.Some(candidateFrameworks, func(other *Framework) bool {
+			return c.IsCompatible(fw, other)
+		})
+		if !compatible {
+			return false
+		}
+	}
+	return true
+}
+
+// getCompatibleTargets Get the target with all equivalent frameworks and the frameworks
+// it supports through one way compatibility mappings.
+func (c *CompatibilityProvider) getCompatibleTargets(target *Framework) []*Framework {
+	results := make([]*Framework, 0)
+	toProcess := []*Framework{target}
+	for len(toProcess) > 0 {
+		current := toProcess[len(toProcess)-1]
+		toProcess = toProcess[:len(toProcess)-1]
+
+		seen := util.Some(results, func(fw *Framework) bool {
+			return fw.Equals(current)
+		})
+		if seen {
+			continue
+		}
+		results = append(results, current)
+		toProcess = append(toProcess, c.mappings.getAllEquivalentFrameworks(current)...)
+		for _, supported := range c.mappings.getCompatibilityMappings(current) {
+			if supported.AllFrameworkVersions() {
+				supported = NewFrameworkWithVersion(supported.Framework, maxVersion)
+			}
+			toProcess = append(toProcess, supported)
+		}
+	}
+	return results
+}
+
+// isCompatibleWithTarget Direct compatibility check without mappings: same identifier and profile,
+// the target version is at least the candidate version and the candidate platform is the target platform.
+func (c *CompatibilityProvider) isCompatibleWithTarget(target, candidate *Framework) bool {
+	if !strings.EqualFold(target.Framework, candidate.Framework) ||
+		!isProfileCompatible(target, candidate) ||
+		target.Version.Semver.LessThan(candidate.Version.Semver) {
+		return false
+	}
+	if candidate.Platform == "" {
+		return true
+	}
+	return strings.EqualFold(target.Platform, candidate.Platform) &&
+		!target.PlatformVersion.Semver.LessThan(candidate.PlatformVersion.Semver)
+}
+
+// isProfileCompatible The full .NET Framework also supports its client profile.
+func isProfileCompatible(target, candidate *Framework) bool {
+	if strings.EqualFold(target.Profile, candidate.Profile) {
+		return true
+	}
+	return strings.EqualFold(target.Framework, consts.Net) && target.Profile == "" &&
+		strings.EqualFold(candidate.Profile, "Client")
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package framework
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func mustParse(t *testing.T, folderName string) *Framework {
+	t.Helper()
+	fw, err := ParseFromDefault(folderName)
+	require.NoError(t, err)
+	return fw
+}
+
+func TestIsCompatible(t *testing.T) {
+	tests := []struct {
+		target    string
+		candidate string
+		want      bool
+	}{
+		// same identifier
+		{target: "net48", candidate: "net45", want: true},
+		{target: "net45", candidate: "net48", want: false},
+		{target: "net40", candidate: "net40-client", want: true},
+		{target: "net40-client", candidate: "net40", want: false},
+
+		// netstandard
+		{target: "net45", candidate: "netstandard1.1", want: true},
+		{target: "net45", candidate: "netstandard1.2", want: false},
+		{target: "net461", candidate: "netstandard2.0", want: true},
+		{target: "net48", candidate: "netstandard2.1", want: false},
+		{target: "netcoreapp1.0", candidate: "netstandard1.6", want: true},
+		{target: "netcoreapp2.1", candidate: "netstandard2.1", want: false},
+		{target: "netcoreapp3.1", candidate: "netstandard2.1", want: true},
+		{target: "net8.0", candidate: "netstandard2.0", want: true},
+		{target: "uap10.0", candidate: "netstandard1.4", want: true},
+		{target: "uap10.0", candidate: "win81", want: true},
+		{target: "monoandroid10", candidate: "netstandard2.1", want: true},
+		{target: "netstandard2.0", candidate: "net461", want: false},
+
+		// net5.0 and later
+		{target: "net6.0", candidate: "netcoreapp3.1", want: true},
+		{target: "net6.0", candidate: "net7.0", want: false},
+		{target: "net6.0-windows", candidate: "net6.0", want: true},
+		{target: "net6.0-windows", candidate: "net5.0-windows", want: true},
+		{target: "net6.0", candidate: "net6.0-windows", want: false},
+		{target: "net6.0-windows7.0", candidate: "net6.0-windows10.0.19041", want: false},
+		{target: "net6.0-windows10.0.19041", candidate: "net6.0-windows7.0", want: true},
+		{target: "net6.0-android", candidate: "monoandroid10", want: true},
+		{target: "net6.0-ios", candidate: "monoandroid10", want: false},
+
+		// portable class libraries
+		{target: "net45", candidate: "portable-net45+win8", want: true},
+		{target: "net40", candidate: "portable-net45+win8", want: false},
+		{target: "monoandroid10", candidate: "portable-net45+win8", want: true},
+		{target: "portable-net45+win8", candidate: "portable-net45+win8+wpa81", want: true},
+		{target: "portable-net45+win8+wpa81", candidate: "portable-net45+win8", want: false},
+		{target: "portable-net45+win8", candidate: "net45", want: false},
+
+		// special frameworks
+		{target: "net48", candidate: "any", want: true},
+		{target: "net48", candidate: "agnostic", want: true},
+		{target: "any", candidate: "net48", want: true},
+		{target: "net48", candidate: "unsupported", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target+" "+tt.candidate, func(t *testing.T) {
+			actual := IsCompatible(mustParse(t, tt.target), mustParse(t, tt.candidate))
+			require.Equal(t, tt.want, actual)
+		})
+	}
+	require.False(t, IsCompatible(nil, Net48))
+	require.False(t, IsCompatible(Net48, nil))
+}
+
+func TestIsCompatibleWithFallback(t *testing.T) {
+	provider := GetCompatibilityProviderInstance()
+	target := NewFallbackFramework(Net60, Net461, Net48)
+
+	require.True(t, provider.IsCompatibleWithFallback(target, NetStandard20))
+	require.True(t, provider.IsCompatibleWithFallback(target, Net472))
+	require.False(t, provider.IsCompatibleWithFallback(target, Net481))
+	require.False(t, provider.IsCompatibleWithFallback(nil, Net481))
+}
@@ -0,0 +1,6 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+// this package provides parsing, formatting and compatibility of NuGet target frameworks
+package framework
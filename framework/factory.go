@@ -15,13 +15,13 @@ import (
 	nugetVersion "github.com/huhouhua/go-nuget/version"
 )
 
-// Parse Creates a NuGetFramework from a folder name using the given provider.
-func Parse(folderName string) (*Framework, error) {
-	return ParseFromDefault(folderName, GetProviderInstance())
+// ParseFromDefault Creates a NuGetFramework from a folder name or a .NET FrameworkName using the default mappings.
+func ParseFromDefault(folderName string) (*Framework, error) {
+	return Parse(folderName, GetProviderInstance())
 }
 
-// ParseFromDefault Creates a NuGetFramework from a folder name using the given provider.
-func ParseFromDefault(folderName string, provider FrameworkNameProvider) (*Framework, error) {
+// Parse Creates a NuGetFramework from a folder name or a .NET FrameworkName using the given provider.
+func Parse(folderName string, provider FrameworkNameProvider) (*Framework, error) {
 	if strings.Contains(folderName, ",") {
 		return ParseFrameworkName(folderName, provider)
 	}
@@ -39,9 +39,8 @@ func ParseFrameworkName(frameworkName string, provider FrameworkNameProvider) (*
 		}
 	}
 	// if the first part is a special framework, ignore the rest
-	framework := parseSpecialFramework(parts[0])
-	if framework == nil {
-		return nil, fmt.Errorf("failed to parse special framework from: %q", parts[0])
+	if framework := parseSpecialFramework(parts[0]); framework != nil {
+		return framework, nil
 	}
 	frameworkStr, profile, version, err := parseFrameworkNameParts(provider, parts)
 	if err != nil {
@@ -137,9 +136,11 @@ func ParseFolder(folderName string, provider FrameworkNameProvider) (*Framework,
 	if strings.TrimSpace(framework) == "" {
 		return result, nil
 	}
-	nv, err := provider.GetVersion(version)
-	if strings.TrimSpace(version) != "" || err != nil {
-		return result, nil
+	nv := consts.EmptyVersion
+	if strings.TrimSpace(version) != "" {
+		if nv, err = provider.GetVersion(version); err != nil {
+			return result, nil
+		}
 	}
 	profileShort := profile
 	if nv.Semver.Major() >= 5 &&
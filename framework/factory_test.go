@@ -7,6 +7,9 @@ package framework
 import (
 	"testing"
 
+	"github.com/huhouhua/go-nuget/internal/consts"
+	"github.com/huhouhua/go-nuget/version"
+
 	"github.com/stretchr/testify/require"
 )
 
@@ -151,3 +154,44 @@ func TestParseCommonFramework(t *testing.T) {
 		})
 	}
 }
+
+func TestParseFrameworkName(t *testing.T) {
+	tests := []struct {
+		input string
+		want  *Framework
+	}{
+		{input: "Any", want: NewFramework(Any)},
+		{input: "Agnostic, Version=v1.0", want: NewFramework(Agnostic)},
+		{input: ".NETFramework, Version=v4.5", want: Net45},
+		{input: ".NETPortable, Version=v0.0, Profile=Profile7", want: NewFrameworkWithProfile(
+			consts.Portable, consts.EmptyVersion, "Profile7")},
+	}
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			actual, err := ParseFrameworkName(tc.input, GetProviderInstance())
+			require.NoError(t, err)
+			require.True(t, tc.want.Equals(actual))
+		})
+	}
+}
+
+func TestParseFolder(t *testing.T) {
+	tests := []struct {
+		input string
+		want  *Framework
+	}{
+		{input: "win", want: NewFramework(consts.Windows)},
+		{input: "win8", want: Win8},
+		{input: "xamarinios10", want: NewFrameworkWithVersion(consts.XamarinIOs, version.NewVersionFrom(1, 0, 0, "", ""))},
+		{input: "net6.0-windows7.0", want: NewFrameworkWithPlatform(
+			consts.NetCoreApp, version.NewVersionFrom(6, 0, 0, "", ""), "windows", version.NewVersionFrom(7, 0, 0, "", ""))},
+		{input: "foo45", want: NewFramework(Unsupported)},
+	}
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			actual, err := ParseFolder(tc.input, GetProviderInstance())
+			require.NoError(t, err)
+			require.True(t, tc.want.Equals(actual))
+		})
+	}
+}
@@ -221,7 +221,7 @@ func (f *Framework) getShortFolderName(mappings FrameworkNameProvider) (string,
 		if strings.TrimSpace(framework.Platform) != "" {
 			sb.WriteString("-")
 			sb.WriteString(strings.ToLower(framework.Platform))
-			if framework.PlatformVersion.Semver.Equal(consts.EmptyVersion.Semver) {
+			if !framework.PlatformVersion.Semver.Equal(consts.EmptyVersion.Semver) {
 				sb.WriteString(mappings.GetVersionString(framework.Framework, framework.PlatformVersion.Semver))
 			}
 		}
@@ -276,8 +276,7 @@ func (f *Framework) Equals(other *Framework) bool {
 		strings.EqualFold(f.Framework, other.Framework) &&
 		strings.EqualFold(f.Profile, other.Profile) &&
 		strings.EqualFold(f.Platform, other.Platform) &&
-		f.PlatformVersion.Semver.Equal(other.PlatformVersion.Semver) &&
-		!f.IsUnsupported()
+		f.PlatformVersion.Semver.Equal(other.PlatformVersion.Semver)
 }
 
 func getDisplayVersion(v *semver.Version) string {
@@ -0,0 +1,42 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package framework
+
+import (
+	"testing"
+
+	"github.com/huhouhua/go-nuget/internal/consts"
+	"github.com/huhouhua/go-nuget/version"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFramework_Equals(t *testing.T) {
+	require.True(t, NewFramework(Unsupported).Equals(NewFramework(Unsupported)))
+	require.True(t, Net45.Equals(NewFrameworkWithVersion(consts.Net, version.NewVersionFrom(4, 5, 0, "", ""))))
+	require.False(t, Net45.Equals(Net4))
+	require.False(t, NewFramework(Unsupported).Equals(NewFramework(Any)))
+}
+
+func TestFramework_GetShortFolderName(t *testing.T) {
+	tests := []struct {
+		framework *Framework
+		want      string
+	}{
+		{framework: Net45, want: "net45"},
+		{framework: NewFramework(Unsupported), want: "unsupported"},
+		{framework: NewFrameworkWithPlatform(
+			consts.NetCoreApp, version.NewVersionFrom(6, 0, 0, "", ""), "windows", consts.EmptyVersion), want: "net6.0-windows"},
+		{framework: NewFrameworkWithPlatform(consts.NetCoreApp, version.NewVersionFrom(6, 0, 0, "", ""), "windows",
+			version.NewVersionFrom(7, 0, 0, "", "")), want: "net6.0-windows7.0"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.want, func(t *testing.T) {
+			actual, err := tc.framework.GetShortFolderName()
+			require.NoError(t, err)
+			require.Equal(t, tc.want, actual)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package framework
+
+import "github.com/huhouhua/go-nuget/internal/consts"
+
+// Framework identifiers, used to construct frameworks and custom mappings.
+const (
+	IdentifierNetCoreApp             = consts.NetCoreApp
+	IdentifierNetStandardApp         = consts.NetStandardApp
+	IdentifierNetStandard            = consts.NetStandard
+	IdentifierNetPlatform            = consts.NetPlatform
+	IdentifierNet                    = consts.Net
+	IdentifierNetCore                = consts.NetCore
+	IdentifierWinRT                  = consts.WinRT
+	IdentifierNetMicro               = consts.NetMicro
+	IdentifierPortable               = consts.Portable
+	IdentifierWindowsPhone           = consts.WindowsPhone
+	IdentifierWindows                = consts.Windows
+	IdentifierWindowsPhoneApp        = consts.WindowsPhoneApp
+	IdentifierDnx                    = consts.Dnx
+	IdentifierDnxCore                = consts.DnxCore
+	IdentifierAspNet                 = consts.AspNet
+	IdentifierAspNetCore             = consts.AspNetCore
+	IdentifierSilverlight            = consts.Silverlight
+	IdentifierNative                 = consts.FrameworkNative
+	IdentifierMonoAndroid            = consts.MonoAndroid
+	IdentifierMonoTouch              = consts.MonoTouch
+	IdentifierMonoMac                = consts.MonoMac
+	IdentifierXamarinIOs             = consts.XamarinIOs
+	IdentifierXamarinMac             = consts.XamarinMac
+	IdentifierXamarinPlayStation3    = consts.XamarinPlayStation3
+	IdentifierXamarinPlayStation4    = consts.XamarinPlayStation4
+	IdentifierXamarinPlayStationVita = consts.XamarinPlayStationVita
+	IdentifierXamarinWatchOS         = consts.XamarinWatchOS
+	IdentifierXamarinTVOS            = consts.XamarinTVOS
+	IdentifierXamarinXbox360         = consts.XamarinXbox360
+	IdentifierXamarinXboxOne         = consts.XamarinXboxOne
+	IdentifierUAP                    = consts.UAP
+	IdentifierTizen                  = consts.Tizen
+	IdentifierNanoFramework          = consts.NanoFramework
+)
@@ -12,19 +12,64 @@ import (
 )
 
 var (
-	frameworkNameProvider FrameworkNameProvider
-	once                  sync.Once
+	instanceLock           sync.Mutex
+	frameworkNameProvider  *FrameworkNameProvider
+	customMappings         []FrameworkMappings
+	customPortableMappings []PortableFrameworkMappings
 )
 
+// GetProviderInstance Returns the name provider built from the default and the registered framework mappings.
 func GetProviderInstance() FrameworkNameProvider {
-	once.Do(func() {
-		mappings := []FrameworkMappings{&DefaultFrameworkMappings{}}
-		portableMappings := []PortableFrameworkMappings{&DefaultPortableFrameworkMappings{}}
-		frameworkNameProvider = *NewFrameworkNameProvider(mappings, portableMappings)
-	})
+	instanceLock.Lock()
+	defer instanceLock.Unlock()
+	return *providerInstance()
+}
+
+// RegisterFrameworkMappings Adds custom framework mappings to the default name provider. They are applied after
+// the default mappings and are used by Parse, GetShortFolderName, IsCompatible and GetNearest.
+func RegisterFrameworkMappings(mappings ...FrameworkMappings) {
+	instanceLock.Lock()
+	defer instanceLock.Unlock()
+	for _, mapping := range mappings {
+		if mapping != nil {
+			customMappings = append(customMappings, mapping)
+		}
+	}
+	resetInstances()
+}
+
+// RegisterPortableFrameworkMappings Adds custom portable profiles to the default name provider.
+func RegisterPortableFrameworkMappings(mappings ...PortableFrameworkMappings) {
+	instanceLock.Lock()
+	defer instanceLock.Unlock()
+	for _, mapping := range mappings {
+		if mapping != nil {
+			customPortableMappings = append(customPortableMappings, mapping)
+		}
+	}
+	resetInstances()
+}
+
+// providerInstance must be called with the instance lock held.
+func providerInstance() *FrameworkNameProvider {
+	if frameworkNameProvider == nil {
+		mappings := append([]FrameworkMappings{&DefaultFrameworkMappings{}}, customMappings...)
+		portableMappings := append(
+			[]PortableFrameworkMappings{&DefaultPortableFrameworkMappings{}},
+			customPortableMappings...,
+		)
+		frameworkNameProvider = NewFrameworkNameProvider(mappings, portableMappings)
+	}
 	return frameworkNameProvider
 }
 
+// resetInstances drops the cached instances so they are rebuilt with the registered mappings.
+func resetInstances() {
+	frameworkNameProvider = nil
+	compatibilityProvider = nil
+	frameworkReducer = nil
+}
+
 type KeyValuePair[K comparable, V any] struct {
 	Key   K
 	Value V
@@ -67,6 +112,21 @@ type FrameworkMappings interface {
 	// GetFullNameReplacementMap Rewrite full framework names to the given value. Ex: .NETPlatform,Version=v0.0 ‒>
 	// .NETPlatform,Version=v5.0
 	GetFullNameReplacementMap() []*KeyValuePair[*Framework, *Framework]
+
+	// GetCompatibilityMappings One way compatibility between framework identifiers.
+	// Ex: net461 and later support netstandard2.0 and lower
+	GetCompatibilityMappings() []*OneWayCompatibilityMapping
+}
+
+// OneWayCompatibilityMapping Frameworks of the target identifier at or above the target version
+// (and on the target platform, if any) support the supported identifier up to the supported version.
+// A supported framework without a version matches all of its versions.
+type OneWayCompatibilityMapping struct {
+	// TargetFramework The lowest framework the mapping applies to
+	TargetFramework *Framework
+
+	// SupportedFramework The highest framework supported by the target
+	SupportedFramework *Framework
 }
 
 type DefaultFrameworkMappings struct {
@@ -441,6 +501,71 @@ func (d *DefaultFrameworkMappings) GetFullNameReplacementMap() []*KeyValuePair[*
 	}
 }
 
+func (d *DefaultFrameworkMappings) GetCompatibilityMappings() []*OneWayCompatibilityMapping {
+	allVersions := func(identifier string) *Framework {
+		return NewFrameworkWithVersion(identifier, consts.EmptyVersion)
+	}
+	net5Platform := func(platform string) *Framework {
+		return NewFrameworkWithPlatform(consts.NetCoreApp, consts.Version6, platform, consts.EmptyVersion)
+	}
+	return []*OneWayCompatibilityMapping{
+		// UAP supports Win81 and WPA81
+		{TargetFramework: allVersions(consts.UAP), SupportedFramework: Win81},
+		{TargetFramework: allVersions(consts.UAP), SupportedFramework: WPA81},
+		// UAP supports NetCore50
+		{TargetFramework: UAP10, SupportedFramework: NetCore50},
+		// Win projects support WinRT
+		{
+			TargetFramework:    allVersions(consts.Windows),
+			SupportedFramework: NewFrameworkWithVersion(consts.WinRT, version.NewVersionFrom(4, 5, 0, "", "")),
+		},
+
+		// .NET Framework
+		{TargetFramework: Net45, SupportedFramework: NetStandard11},
+		{TargetFramework: Net451, SupportedFramework: NetStandard12},
+		{TargetFramework: Net46, SupportedFramework: NetStandard13},
+		{TargetFramework: Net461, SupportedFramework: NetStandard20},
+
+		// Windows Store and Windows Phone
+		{TargetFramework: Win8, SupportedFramework: NetStandard11},
+		{TargetFramework: Win81, SupportedFramework: NetStandard12},
+		{TargetFramework: WP8, SupportedFramework: NetStandard10},
+		{TargetFramework: WPA81, SupportedFramework: NetStandard12},
+		{TargetFramework: UAP10, SupportedFramework: NetStandard14},
+		{
+			TargetFramework:    NewFrameworkWithVersion(consts.UAP, version.NewVersionFrom(10, 0, 15064, "", "")),
+			SupportedFramework: NetStandard20,
+		},
+
+		// .NET Core and .NET 5+
+		{TargetFramework: DnxCore50, SupportedFramework: NetStandard15},
+		{TargetFramework: NetCoreApp10, SupportedFramework: NetStandard16},
+		{TargetFramework: NetCoreApp20, SupportedFramework: NetStandard20},
+		{TargetFramework: NetCoreApp30, SupportedFramework: NetStandard21},
+
+		// Tizen
+		{TargetFramework: Tizen3, SupportedFramework: NetStandard16},
+		{TargetFramework: Tizen4, SupportedFramework: NetStandard20},
+		{TargetFramework: Tizen6, SupportedFramework: NetStandard21},
+
+		// Mono and Xamarin support every netstandard version up to 2.1
+		{TargetFramework: allVersions(consts.MonoAndroid), SupportedFramework: NetStandard21},
+		{TargetFramework: allVersions(consts.MonoMac), SupportedFramework: NetStandard21},
+		{TargetFramework: allVersions(consts.MonoTouch), SupportedFramework: NetStandard21},
+		{TargetFramework: allVersions(consts.XamarinIOs), SupportedFramework: NetStandard21},
+		{TargetFramework: allVersions(consts.XamarinMac), SupportedFramework: NetStandard21},
+		{TargetFramework: allVersions(consts.XamarinTVOS), SupportedFramework: NetStandard21},
+		{TargetFramework: allVersions(consts.XamarinWatchOS), SupportedFramework: NetStandard21},
+
+		// net6.0 platforms support the Xamarin frameworks they replace
+		{TargetFramework: net5Platform("android"), SupportedFramework: allVersions(consts.MonoAndroid)},
+		{TargetFramework: net5Platform("ios"), SupportedFramework: allVersions(consts.XamarinIOs)},
+		{TargetFramework: net5Platform("maccatalyst"), SupportedFramework: allVersions(consts.XamarinIOs)},
+		{TargetFramework: net5Platform("macos"), SupportedFramework: allVersions(consts.XamarinMac)},
+		{TargetFramework: net5Platform("tvos"), SupportedFramework: allVersions(consts.XamarinTVOS)},
+	}
+}
+
 // DefaultPortableFrameworkMappings Contains the standard portable framework mappings
 type DefaultPortableFrameworkMappings struct {
 }
@@ -529,7 +654,7 @@ func (d *DefaultPortableFrameworkMappings) GetProfileFrameworkMap() []*KeyValueP
 }
 
 func createProfileFrameworks(profile int, framework ...*Framework) *KeyValuePair[int, []*Framework] {
-	frameworkPtrs := make([]*Framework, len(framework))
+	frameworkPtrs := make([]*Framework, 0, len(framework))
 	frameworkPtrs = append(frameworkPtrs, framework...)
 	return &KeyValuePair[int, []*Framework]{
 		Key:   profile,
@@ -0,0 +1,86 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package framework
+
+import (
+	"testing"
+
+	"github.com/huhouhua/go-nuget/version"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFactoryMapping(t *testing.T) {
+	instance := GetProviderInstance()
+	require.NotNil(t, instance)
+	require.Equal(t, instance, GetProviderInstance())
+}
+
+func TestCreateProfileFrameworks(t *testing.T) {
+	profile := createProfileFrameworks(14, Net4, SL5)
+	require.Equal(t, 14, profile.Key)
+	require.Equal(t, []*Framework{Net4, SL5}, profile.Value)
+}
+
+type customFrameworkMappings struct{}
+
+func (c *customFrameworkMappings) GetIdentifierSynonymsMap() []*KeyValuePair[string, string] {
+	return nil
+}
+
+func (c *customFrameworkMappings) GetIdentifierShortNameMap() []*KeyValuePair[string, string] {
+	return []*KeyValuePair[string, string]{{Key: "MyFramework", Value: "myfw"}}
+}
+
+func (c *customFrameworkMappings) GetProfileShortNamesMap() []*FrameworkSpecificMapping {
+	return nil
+}
+
+func (c *customFrameworkMappings) GetEquivalentFrameworkMap() []*KeyValuePair[*Framework, *Framework] {
+	return nil
+}
+
+func (c *customFrameworkMappings) GetShortNameReplacementMap() []*KeyValuePair[*Framework, *Framework] {
+	return nil
+}
+
+func (c *customFrameworkMappings) GetFullNameReplacementMap() []*KeyValuePair[*Framework, *Framework] {
+	return nil
+}
+
+func (c *customFrameworkMappings) GetCompatibilityMappings() []*OneWayCompatibilityMapping {
+	return []*OneWayCompatibilityMapping{
+		{
+			TargetFramework:    NewFrameworkWithVersion("MyFramework", version.NewVersionFrom(1, 0, 0, "", "")),
+			SupportedFramework: NetStandard20,
+		},
+	}
+}
+
+func TestRegisterFrameworkMappings(t *testing.T) {
+	t.Cleanup(func() {
+		instanceLock.Lock()
+		defer instanceLock.Unlock()
+		customMappings = nil
+		resetInstances()
+	})
+	fw, err := ParseFromDefault("myfw1.0")
+	require.NoError(t, err)
+	require.True(t, fw.IsUnsupported())
+
+	RegisterFrameworkMappings(&customFrameworkMappings{}, nil)
+
+	fw, err = ParseFromDefault("myfw1.0")
+	require.NoError(t, err)
+	require.True(t, NewFrameworkWithVersion("MyFramework", version.NewVersionFrom(1, 0, 0, "", "")).Equals(fw))
+
+	shortName, err := fw.GetShortFolderName()
+	require.NoError(t, err)
+	require.Equal(t, "myfw10", shortName)
+
+	require.True(t, IsCompatible(fw, NetStandard20))
+	require.False(t, IsCompatible(fw, NetStandard21))
+	require.Equal(t, NetStandard20, GetNearest(fw, []*Framework{Net48, NetStandard13, NetStandard20}))
+}
@@ -48,6 +48,9 @@ type FrameworkNameProvider struct {
 	// Rewrite mappings
 	shortNameRewrites []*KeyValuePair[*Framework, *Framework]
 	fullNameRewrites  []*KeyValuePair[*Framework, *Framework]
+
+	// one way compatibility mappings
+	compatibilityMappings []*OneWayCompatibilityMapping
 }
 
 func NewFrameworkNameProvider(
@@ -65,6 +68,7 @@ func NewFrameworkNameProvider(
 		equivalentFrameworkMap:       make([]*KeyValuePair[*Framework, []*Framework], 0),
 		shortNameRewrites:            make([]*KeyValuePair[*Framework, *Framework], 0),
 		fullNameRewrites:             make([]*KeyValuePair[*Framework, *Framework], 0),
+		compatibilityMappings:        make([]*OneWayCompatibilityMapping, 0),
 	}
 
 	provider.initMappings(mappings)
@@ -207,7 +211,7 @@ func (f *FrameworkNameProvider) GetPortableFrameworks(shortPortableProfiles stri
 	}
 	var result []*Framework
 	for _, name := range shortNames {
-		if framework, err := ParseFromDefault(name, *f); err != nil {
+		if framework, err := Parse(name, *f); err != nil {
 			return nil, err
 		} else {
 			if strings.TrimSpace(framework.Profile) != "" {
@@ -422,6 +426,9 @@ func (p *FrameworkNameProvider) initMappings(mappings []FrameworkMappings) {
 		// add rewrite rules
 		p.addShortNameRewriteMappings(mapping.GetShortNameReplacementMap())
 		p.addFullNameRewriteMappings(mapping.GetFullNameReplacementMap())
+
+		// add compatibility mappings
+		p.addCompatibilityMappings(mapping.GetCompatibilityMappings())
 	}
 }
 
@@ -575,3 +582,26 @@ func (p *FrameworkNameProvider) addFullNameRewriteMappings(mappings []*KeyValueP
 		}
 	}
 }
+
+func (p *FrameworkNameProvider) addCompatibilityMappings(mappings []*OneWayCompatibilityMapping) {
+	for _, mapping := range mappings {
+		if mapping != nil && mapping.TargetFramework != nil && mapping.SupportedFramework != nil {
+			p.compatibilityMappings = append(p.compatibilityMappings, mapping)
+		}
+	}
+}
+
+// getCompatibilityMappings Get the frameworks the target supports through one way compatibility mappings
+func (p *FrameworkNameProvider) getCompatibilityMappings(target *Framework) []*Framework {
+	supported := make([]*Framework, 0)
+	for _, mapping := range p.compatibilityMappings {
+		from := mapping.TargetFramework
+		if !strings.EqualFold(target.Framework, from.Framework) ||
+			target.Version.Semver.LessThan(from.Version.Semver) ||
+			(from.Platform != "" && !strings.EqualFold(target.Platform, from.Platform)) {
+			continue
+		}
+		supported = append(supported, mapping.SupportedFramework)
+	}
+	return supported
+}
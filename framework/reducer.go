@@ -0,0 +1,180 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package framework
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/huhouhua/go-nuget/internal/util"
+)
+
+var frameworkReducer *FrameworkReducer
+
+// GetReducerInstance Returns the framework reducer backed by the default framework mappings.
+func GetReducerInstance() *FrameworkReducer {
+	instanceLock.Lock()
+	defer instanceLock.Unlock()
+	if frameworkReducer == nil {
+		frameworkReducer = NewFrameworkReducer(*providerInstance(), compatibilityInstance())
+	}
+	return frameworkReducer
+}
+
+// GetNearest Returns the framework from possible that is nearest to the target framework,
+// using the default framework mappings. Nil is returned when nothing is compatible.
+func GetNearest(target *Framework, possible []*Framework) *Framework {
+	return GetReducerInstance().GetNearest(target, possible)
+}
+
+// FrameworkReducer Reduces a list of frameworks to the ones best matching a project framework.
+type FrameworkReducer struct {
+	mappings FrameworkNameProvider
+	compat   *CompatibilityProvider
+}
+
+func NewFrameworkReducer(mappings FrameworkNameProvider, compat *CompatibilityProvider) *FrameworkReducer {
+	return &FrameworkReducer{
+		mappings: mappings,
+		compat:   compat,
+	}
+}
+
+// GetNearest Returns the framework from possible that is nearest to the target framework.
+// Ex: net48 with [net45, netstandard2.0] -> net45, net8.0 with [netstandard2.1, net6.0] -> net6.0
+func (r *FrameworkReducer) GetNearest(target *Framework, possible []*Framework) *Framework {
+	if target == nil {
+		return nil
+	}
+	compatible := make([]*Framework, 0, len(possible))
+	for _, fw := range r.distinct(possible) {
+		if r.compat.IsCompatible(target, fw) {
+			compatible = append(compatible, fw)
+		}
+	}
+	if len(compatible) == 0 {
+		return nil
+	}
+	// an exact match always wins
+	for _, fw := range compatible {
+		if fw.Equals(target) {
+			return fw
+		}
+	}
+	reduced := r.ReduceUpwards(compatible)
+	if len(reduced) > 1 {
+		reduced = r.preferNonPCL(reduced)
+	}
+	if len(reduced) > 1 {
+		reduced = r.preferIdentifier(target, reduced)
+	}
+	if len(reduced) > 1 {
+		sort.SliceStable(reduced, func(i, j int) bool {
+			return r.less(reduced[i], reduced[j])
+		})
+	}
+	return reduced[0]
+}
+
+// GetNearestWithFallback Returns the framework nearest to the primary framework, if nothing is compatible
+// the fallback frameworks are tried in order.
+func (r *FrameworkReducer) GetNearestWithFallback(target *FallbackFramework, possible []*Framework) *Framework {
+	if target == nil {
+		return nil
+	}
+	if nearest := r.GetNearest(target.Framework, possible); nearest != nil {
+		return nearest
+	}
+	for _, fallback := range target.Fallback {
+		if nearest := r.GetNearest(fallback, possible); nearest != nil {
+			return nearest
+		}
+	}
+	return nil
+}
+
+// ReduceUpwards Remove the frameworks that are supported by another framework in the list.
+// Ex: net45, net403, net40 -> net45
+func (r *FrameworkReducer) ReduceUpwards(frameworks []*Framework) []*Framework {
+	frameworks = r.distinct(frameworks)
+	results := make([]*Framework, 0, len(frameworks))
+	for _, fw := range frameworks {
+		supersededBy := util.Some(frameworks, func(other *Framework) bool {
+			return !other.Equals(fw) && r.compat.IsCompatible(other, fw) && !r.compat.IsCompatible(fw, other)
+		})
+		if !supersededBy {
+			results = append(results, fw)
+		}
+	}
+	return results
+}
+
+// preferNonPCL Portable frameworks are only used when nothing else is left.
+func (r *FrameworkReducer) preferNonPCL(frameworks []*Framework) []*Framework {
+	nonPCL := util.Filter(frameworks, func(fw *Framework) bool {
+		return !fw.IsPCL()
+	})
+	if len(nonPCL) > 0 {
+		return nonPCL
+	}
+	// prefer the portable profile with the least frameworks
+	sort.SliceStable(frameworks, func(i, j int) bool {
+		return r.portableFrameworkCount(frameworks[i]) < r.portableFrameworkCount(frameworks[j])
+	})
+	return frameworks[:1]
+}
+
+// preferIdentifier Frameworks with the identifier of the target win over ones reached through mappings.
+// Ex: net48 with [net45, netstandard2.0] -> net45
+func (r *FrameworkReducer) preferIdentifier(target *Framework, frameworks []*Framework) []*Framework {
+	same := util.Filter(frameworks, func(fw *Framework) bool {
+		return strings.EqualFold(fw.Framework, target.Framework)
+	})
+	if len(same) > 0 {
+		return same
+	}
+	return frameworks
+}
+
+func (r *FrameworkReducer) portableFrameworkCount(fw *Framework) int {
+	frameworks, err := r.mappings.GetPortableFrameworksWithInclude(fw.Profile, false)
+	if err != nil || len(frameworks) == 0 {
+		return len(r.mappings.portableFrameworkMap) + 1
+	}
+	return len(frameworks)
+}
+
+// less Orders by identifier, then the highest version, profile and platform, to keep the result stable.
+func (r *FrameworkReducer) less(a, b *Framework) bool {
+	if !strings.EqualFold(a.Framework, b.Framework) {
+		return strings.ToLower(a.Framework) < strings.ToLower(b.Framework)
+	}
+	if !a.Version.Semver.Equal(b.Version.Semver) {
+		return a.Version.Semver.GreaterThan(b.Version.Semver)
+	}
+	if !strings.EqualFold(a.Profile, b.Profile) {
+		return strings.ToLower(a.Profile) < strings.ToLower(b.Profile)
+	}
+	if !strings.EqualFold(a.Platform, b.Platform) {
+		return strings.ToLower(a.Platform) < strings.ToLower(b.Platform)
+	}
+	return a.PlatformVersion.Semver.GreaterThan(b.PlatformVersion.Semver)
+}
+
+func (r *FrameworkReducer) distinct(frameworks []*Framework) []*Framework {
+	results := make([]*Framework, 0, len(frameworks))
+	for _, fw := range frameworks {
+		if fw == nil {
+			continue
+		}
+		seen := util.Some(results, func(other *Framework) bool {
+			return other.Equals(fw)
+		})
+		if !seen {
+			results = append(results, fw)
+		}
+	}
+	return results
+}
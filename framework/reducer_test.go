@@ -0,0 +1,68 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package framework
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetNearest(t *testing.T) {
+	tests := []struct {
+		target   string
+		possible []string
+		want     string
+	}{
+		{target: "net48", possible: []string{"net40", "net45", "net472"}, want: "net472"},
+		{target: "net48", possible: []string{"netstandard2.0", "net45"}, want: "net45"},
+		{target: "net48", possible: []string{"netstandard1.3", "netstandard2.0"}, want: "netstandard2.0"},
+		{target: "net48", possible: []string{"net48", "net45"}, want: "net48"},
+		{target: "net8.0", possible: []string{"netstandard2.1", "net6.0", "netcoreapp3.1"}, want: "net6.0"},
+		{target: "net8.0", possible: []string{"netstandard2.0", "net462"}, want: "netstandard2.0"},
+		{target: "net6.0-windows", possible: []string{"net6.0", "net5.0-windows"}, want: "net6.0"},
+		{target: "net6.0-windows", possible: []string{"net5.0", "net5.0-windows"}, want: "net5.0-windows"},
+		{target: "net6.0", possible: []string{"any", "netstandard2.0"}, want: "netstandard2.0"},
+		{target: "net6.0", possible: []string{"any", "net48"}, want: "any"},
+		{
+			target:   "net45",
+			possible: []string{"portable-net45+win8+wpa81", "portable-net45+win8"},
+			want:     "portable-net45+win8",
+		},
+		{target: "net45", possible: []string{"portable-net45+win8", "netstandard1.1"}, want: "netstandard1.1"},
+		{target: "net40", possible: []string{"net45", "netstandard2.0"}, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target+" "+tt.want, func(t *testing.T) {
+			possible := make([]*Framework, 0, len(tt.possible))
+			for _, p := range tt.possible {
+				possible = append(possible, mustParse(t, p))
+			}
+			actual := GetNearest(mustParse(t, tt.target), possible)
+			if tt.want == "" {
+				require.Nil(t, actual)
+				return
+			}
+			require.NotNil(t, actual)
+			require.True(t, mustParse(t, tt.want).Equals(actual))
+		})
+	}
+}
+
+func TestGetNearestWithFallback(t *testing.T) {
+	reducer := GetReducerInstance()
+	possible := []*Framework{Net45, Net472}
+
+	require.Nil(t, reducer.GetNearest(Net60, possible))
+	require.Equal(t, Net472, reducer.GetNearestWithFallback(NewFallbackFramework(Net60, Net48), possible))
+	require.Equal(t, Net45, reducer.GetNearestWithFallback(NewFallbackFramework(Net60, Net46, Net48), possible))
+	require.Nil(t, reducer.GetNearestWithFallback(NewFallbackFramework(Net60, Net4), possible))
+}
+
+func TestReduceUpwards(t *testing.T) {
+	reducer := GetReducerInstance()
+	actual := reducer.ReduceUpwards([]*Framework{Net4, Net45, Net403, NetStandard10, Net45})
+	require.Equal(t, []*Framework{Net45}, actual)
+}
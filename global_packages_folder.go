@@ -0,0 +1,337 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package nuget
+
+import (
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/huhouhua/go-nuget/internal/consts"
+	"github.com/huhouhua/go-nuget/meta"
+
+	nugetVersion "github.com/huhouhua/go-nuget/version"
+)
+
+const (
+	// packageMetadataFile written last, its presence marks a complete extraction
+	packageMetadataFile    = ".nupkg.metadata"
+	packageMetadataVersion = 2
+	hashFileExtension      = ".sha512"
+)
+
+// extractionWaitTimeout how long to wait for another process extracting the same package
+var extractionWaitTimeout = 30 * time.Second
+
+// PackageDownloader downloads a nupkg, FindPackageResource and LocalFolderResource implement this interface.
+type PackageDownloader interface {
+	// DownloadNupkg downloads a specific package version and streams it to w.
+	DownloadNupkg(id string, w io.Writer, opt *DownloadOptions, options ...RequestOptionFunc) (*http.Response, error)
+}
+
+// GlobalPackagesFolder the {id}/{version}/ package cache shared with dotnet restore, Ex: ~/.nuget/packages
+type GlobalPackagesFolder struct {
+	root string
+}
+
+// GlobalPackage A package extracted in the global packages folder
+type GlobalPackage struct {
+	*meta.PackageIdentity
+
+	// Path The install directory of the package
+	Path string
+
+	// ContentHash The base64 SHA512 hash of the nupkg
+	ContentHash string
+
+	// Source The source the package was downloaded from
+	Source string
+}
+
+// packageMetadata content of the .nupkg.metadata file
+type packageMetadata struct {
+	Version     int    `json:"version"`
+	ContentHash string `json:"contentHash"`
+	Source      string `json:"source"`
+}
+
+// NewGlobalPackagesFolder returns the global packages folder at root, the folder is created on first extraction.
+func NewGlobalPackagesFolder(root string) *GlobalPackagesFolder {
+	return &GlobalPackagesFolder{root: root}
+}
+
+// Root returns the folder the packages are extracted to.
+func (g *GlobalPackagesFolder) Root() string {
+	return g.root
+}
+
+// GetInstallPath returns the directory of a package version, Ex: newtonsoft.json/13.0.1
+func (g *GlobalPackagesFolder) GetInstallPath(id string, version *nugetVersion.Version) string {
+	return filepath.Join(g.root, strings.ToLower(id), strings.ToLower(version.ToNormalizedString()))
+}
+
+// GetPackageFilePath returns the path of the nupkg, Ex: newtonsoft.json/13.0.1/newtonsoft.json.13.0.1.nupkg
+func (g *GlobalPackagesFolder) GetPackageFilePath(id string, version *nugetVersion.Version) string {
+	return filepath.Join(g.GetInstallPath(id, version), packageFileName(id, version))
+}
+
+// GetHashPath returns the path of the nupkg hash file, Ex: newtonsoft.json.13.0.1.nupkg.sha512
+func (g *GlobalPackagesFolder) GetHashPath(id string, version *nugetVersion.Version) string {
+	return g.GetPackageFilePath(id, version) + hashFileExtension
+}
+
+// GetManifestFilePath returns the path of the extracted nuspec, Ex: newtonsoft.json/13.0.1/newtonsoft.json.nuspec
+func (g *GlobalPackagesFolder) GetManifestFilePath(id string, version *nugetVersion.Version) string {
+	return filepath.Join(g.GetInstallPath(id, version), strings.ToLower(id)+consts.NuspecExtension)
+}
+
+// GetMetadataPath returns the path of the .nupkg.metadata file.
+func (g *GlobalPackagesFolder) GetMetadataPath(id string, version *nugetVersion.Version) string {
+	return filepath.Join(g.GetInstallPath(id, version), packageMetadataFile)
+}
+
+// Exists true if the package version is completely extracted.
+func (g *GlobalPackagesFolder) Exists(id, version string) bool {
+	_, err := g.GetPackage(id, version)
+	return err == nil
+}
+
+// GetPackage returns a package extracted in the folder, or ErrNotFound.
+func (g *GlobalPackagesFolder) GetPackage(id, version string) (*GlobalPackage, error) {
+	identity, err := newGlobalPackageIdentity(id, version)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(g.GetMetadataPath(identity.Id, identity.Version))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%s %s: %w", id, version, ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var metadata packageMetadata
+	if err = json.Unmarshal(data, &metadata); err != nil {
+		return nil, fmt.Errorf("invalid %s of %s %s: %w", packageMetadataFile, id, version, err)
+	}
+	return &GlobalPackage{
+		PackageIdentity: identity,
+		Path:            g.GetInstallPath(identity.Id, identity.Version),
+		ContentHash:     metadata.ContentHash,
+		Source:          metadata.Source,
+	}, nil
+}
+
+// OpenPackage returns a reader over the nupkg of an extracted package, the caller must Close it.
+func (g *GlobalPackagesFolder) OpenPackage(id, version string) (*PackageArchiveReader, error) {
+	pkg, err := g.GetPackage(id, version)
+	if err != nil {
+		return nil, err
+	}
+	return OpenPackageArchiveReader(g.GetPackageFilePath(pkg.Id, pkg.Version))
+}
+
+// GetOrAdd returns the package from the folder, downloading and extracting it only when it isn't there yet.
+// source is recorded in the .nupkg.metadata file, Ex: https://api.nuget.org/v3/index.json
+func (g *GlobalPackagesFolder) GetOrAdd(
+	id, version, source string,
+	downloader PackageDownloader,
+	options ...RequestOptionFunc,
+) (*GlobalPackage, error) {
+	if pkg, err := g.GetPackage(id, version); err == nil || !errors.Is(err, ErrNotFound) {
+		return pkg, err
+	}
+	if downloader == nil {
+		return nil, fmt.Errorf("downloader is required")
+	}
+	identity, err := newGlobalPackageIdentity(id, version)
+	if err != nil {
+		return nil, err
+	}
+	// downloaded next to the install path, the nupkg is then moved in place without being copied.
+	dir := filepath.Dir(g.GetInstallPath(identity.Id, identity.Version))
+	packagePath, contentHash, err := writePackageFile(dir, func(w io.Writer) error {
+		_, err := downloader.DownloadNupkg(id, w, &DownloadOptions{Version: version}, options...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(packagePath)
+	return g.addPackageFile(packagePath, contentHash, source)
+}
+
+// AddPackage extracts a nupkg the way NuGet lays out the global packages folder:
+// the nupkg, its .sha512 hash, the lowercase nuspec, the package files and the .nupkg.metadata file.
+// Extraction happens in a temporary directory moved in place once complete, so concurrent processes
+// extracting the same package never observe a partial directory. An existing package is left untouched.
+// The nupkg is streamed to a temporary file in the folder, it is never read into memory.
+func (g *GlobalPackagesFolder) AddPackage(reader io.Reader, source string) (*GlobalPackage, error) {
+	packagePath, contentHash, err := writePackageFile(g.root, func(w io.Writer) error {
+		_, err := io.Copy(w, reader)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(packagePath)
+	return g.addPackageFile(packagePath, contentHash, source)
+}
+
+// addPackageFile extracts the nupkg at packagePath, the file is moved into the package directory.
+func (g *GlobalPackagesFolder) addPackageFile(packagePath, contentHash, source string) (*GlobalPackage, error) {
+	archive, err := OpenPackageArchiveReader(packagePath)
+	if err != nil {
+		return nil, err
+	}
+	// the archive is closed before the nupkg is moved next to the extracted files.
+	identity, tempDir, err := g.extractPackageArchive(archive)
+	if closeErr := archive.Close(); err == nil {
+		err = closeErr
+	}
+	if tempDir != "" {
+		defer os.RemoveAll(tempDir)
+	}
+	if err != nil {
+		return nil, err
+	}
+	id, version := identity.Id, identity.Version.ToNormalizedString()
+	if tempDir == "" {
+		return g.GetPackage(id, version)
+	}
+
+	metadata := &packageMetadata{
+		Version:     packageMetadataVersion,
+		ContentHash: contentHash,
+		Source:      source,
+	}
+	if err = writeGlobalPackage(tempDir, packagePath, identity, metadata); err != nil {
+		return nil, fmt.Errorf("extract %s %s: %w", id, version, err)
+	}
+	if err = g.moveInPlace(tempDir, g.GetInstallPath(id, identity.Version), id, version); err != nil {
+		return nil, err
+	}
+	return g.GetPackage(id, version)
+}
+
+// extractPackageArchive extracts the package with its nuspec to a temporary directory next to the install path.
+// The directory is empty when the package is already in the folder.
+func (g *GlobalPackagesFolder) extractPackageArchive(
+	archive *PackageArchiveReader,
+) (*meta.PackageIdentity, string, error) {
+	nuspec, err := archive.Nuspec()
+	if err != nil {
+		return nil, "", err
+	}
+	identity, err := nuspec.GetIdentity()
+	if err != nil {
+		return nil, "", err
+	}
+	id, version := identity.Id, identity.Version.ToNormalizedString()
+	if _, err = g.GetPackage(id, version); err == nil || !errors.Is(err, ErrNotFound) {
+		return identity, "", err
+	}
+
+	installPath := g.GetInstallPath(id, identity.Version)
+	if err = os.MkdirAll(filepath.Dir(installPath), 0755); err != nil {
+		return nil, "", err
+	}
+	tempDir, err := os.MkdirTemp(filepath.Dir(installPath), "."+filepath.Base(installPath)+"-")
+	if err != nil {
+		return nil, "", err
+	}
+	if _, err = archive.ExtractTo(tempDir, PackageSaveModeNuspec|PackageSaveModeFiles); err != nil {
+		return nil, tempDir, fmt.Errorf("extract %s %s: %w", id, version, err)
+	}
+	return identity, tempDir, nil
+}
+
+// writePackageFile writes a nupkg to a temporary file in dir and returns its path and base64 SHA512 hash,
+// the hash is computed while the file is written.
+func writePackageFile(dir string, write func(w io.Writer) error) (string, string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", "", err
+	}
+	file, err := os.CreateTemp(dir, ".nupkg-")
+	if err != nil {
+		return "", "", err
+	}
+	hash := sha512.New()
+	err = write(io.MultiWriter(file, hash))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(file.Name())
+		return "", "", err
+	}
+	return file.Name(), base64.StdEncoding.EncodeToString(hash.Sum(nil)), nil
+}
+
+// moveInPlace renames the extracted directory to the install path. When another process got there first
+// its extraction is kept, a directory left incomplete past the wait timeout is replaced.
+func (g *GlobalPackagesFolder) moveInPlace(tempDir, installPath, id, version string) error {
+	err := os.Rename(tempDir, installPath)
+	if err == nil {
+		return nil
+	}
+	deadline := time.Now().Add(extractionWaitTimeout)
+	for {
+		if g.Exists(id, version) {
+			return nil
+		}
+		if _, statErr := os.Stat(installPath); errors.Is(statErr, fs.ErrNotExist) {
+			return os.Rename(tempDir, installPath)
+		}
+		if time.Now().After(deadline) {
+			if err = os.RemoveAll(installPath); err != nil {
+				return err
+			}
+			return os.Rename(tempDir, installPath)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// writeGlobalPackage moves the nupkg at packagePath next to the package extracted in dir,
+// then writes the hash and metadata files.
+func writeGlobalPackage(
+	dir, packagePath string,
+	identity *meta.PackageIdentity,
+	metadata *packageMetadata,
+) error {
+	packageFile := filepath.Join(dir, packageFileName(identity.Id, identity.Version))
+	if err := os.Rename(packagePath, packageFile); err != nil {
+		return err
+	}
+	if err := os.WriteFile(packageFile+hashFileExtension, []byte(metadata.ContentHash), 0644); err != nil {
+		return err
+	}
+	content, err := json.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, packageMetadataFile), content, 0644)
+}
+
+// packageFileName Ex: newtonsoft.json.13.0.1.nupkg
+func packageFileName(id string, version *nugetVersion.Version) string {
+	return strings.ToLower(fmt.Sprintf("%s.%s%s", id, version.ToNormalizedString(), consts.PackageExtension))
+}
+
+func newGlobalPackageIdentity(id, version string) (*meta.PackageIdentity, error) {
+	if _, err := parseID(id); err != nil {
+		return nil, err
+	}
+	return meta.NewPackageIdentity(id, version)
+}
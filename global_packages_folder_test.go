@@ -0,0 +1,236 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package nuget
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	nugetVersion "github.com/huhouhua/go-nuget/version"
+
+	"github.com/stretchr/testify/require"
+)
+
+type countingDownloader struct {
+	mu    sync.Mutex
+	count int
+	data  []byte
+}
+
+func (d *countingDownloader) DownloadNupkg(
+	id string,
+	w io.Writer,
+	opt *DownloadOptions,
+	options ...RequestOptionFunc,
+) (*http.Response, error) {
+	d.mu.Lock()
+	d.count++
+	d.mu.Unlock()
+	_, err := w.Write(d.data)
+	return nil, err
+}
+
+func TestGlobalPackagesFolder_AddPackage(t *testing.T) {
+	folder := NewGlobalPackagesFolder(t.TempDir())
+	data := buildTestPackage(t, "Contoso.Lib", "1.0.0.0", nil,
+		"lib/net8.0/Contoso.Lib.dll",
+		"lib/net8.0/a%2Bb.txt",
+		"[Content_Types].xml",
+		"_rels/.rels",
+		"package/services/metadata/core-properties/abc.psmdcp",
+		".signature.p7s",
+	)
+
+	pkg, err := folder.AddPackage(bytes.NewReader(data), "https://api.nuget.org/v3/index.json")
+	require.NoError(t, err)
+
+	hash := sha512.Sum512(data)
+	contentHash := base64.StdEncoding.EncodeToString(hash[:])
+	installPath := filepath.Join(folder.Root(), "contoso.lib", "1.0.0")
+	require.Equal(t, "Contoso.Lib", pkg.Id)
+	require.Equal(t, "1.0.0", pkg.Version.ToNormalizedString())
+	require.Equal(t, installPath, pkg.Path)
+	require.Equal(t, contentHash, pkg.ContentHash)
+	require.Equal(t, "https://api.nuget.org/v3/index.json", pkg.Source)
+
+	var files []string
+	err = filepath.Walk(installPath, func(path string, info os.FileInfo, err error) error {
+		require.NoError(t, err)
+		if !info.IsDir() {
+			rel, err := filepath.Rel(installPath, path)
+			require.NoError(t, err)
+			files = append(files, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{
+		".nupkg.metadata",
+		".signature.p7s",
+		"contoso.lib.1.0.0.nupkg",
+		"contoso.lib.1.0.0.nupkg.sha512",
+		"contoso.lib.nuspec",
+		"lib/net8.0/Contoso.Lib.dll",
+		"lib/net8.0/a+b.txt",
+	}, files)
+
+	metadata, err := os.ReadFile(filepath.Join(installPath, ".nupkg.metadata"))
+	require.NoError(t, err)
+	require.Equal(t, fmt.Sprintf(`{
+  "version": 2,
+  "contentHash": "%s",
+  "source": "https://api.nuget.org/v3/index.json"
+}`, contentHash), string(metadata))
+
+	sha, err := os.ReadFile(filepath.Join(installPath, "contoso.lib.1.0.0.nupkg.sha512"))
+	require.NoError(t, err)
+	require.Equal(t, contentHash, string(sha))
+
+	nupkg, err := os.ReadFile(filepath.Join(installPath, "contoso.lib.1.0.0.nupkg"))
+	require.NoError(t, err)
+	require.Equal(t, data, nupkg)
+
+	// the id folder holds no leftover temporary directory
+	entries, err := os.ReadDir(filepath.Join(folder.Root(), "contoso.lib"))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	// adding it again keeps the existing extraction
+	again, err := folder.AddPackage(bytes.NewReader(data), "https://other/v3/index.json")
+	require.NoError(t, err)
+	require.Equal(t, "https://api.nuget.org/v3/index.json", again.Source)
+}
+
+func TestGlobalPackagesFolder_GetPackage(t *testing.T) {
+	folder := NewGlobalPackagesFolder(t.TempDir())
+	_, err := folder.AddPackage(bytes.NewReader(buildTestPackage(t, "Contoso.Lib", "1.0.0", nil)), "")
+	require.NoError(t, err)
+
+	require.True(t, folder.Exists("contoso.lib", "1.0"))
+	require.False(t, folder.Exists("contoso.lib", "2.0.0"))
+
+	pkg, err := folder.GetPackage("CONTOSO.LIB", "1.0.0")
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(folder.Root(), "contoso.lib", "1.0.0"), pkg.Path)
+
+	_, err = folder.GetPackage("contoso.lib", "2.0.0")
+	require.ErrorIs(t, err, ErrNotFound)
+	_, err = folder.GetPackage("", "1.0.0")
+	require.Equal(t, errors.New("id is empty"), err)
+
+	reader, err := folder.OpenPackage("contoso.lib", "1.0.0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = reader.Close() })
+	nuspec, err := reader.Nuspec()
+	require.NoError(t, err)
+	require.Equal(t, "Contoso.Lib", nuspec.GetId())
+
+	// an extraction without .nupkg.metadata is incomplete
+	v, err := nugetVersion.Parse("3.0.0")
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(folder.GetInstallPath("contoso.lib", v), 0755))
+	require.False(t, folder.Exists("contoso.lib", "3.0.0"))
+}
+
+func TestGlobalPackagesFolder_Paths(t *testing.T) {
+	folder := NewGlobalPackagesFolder("/packages")
+	v, err := nugetVersion.Parse("13.0.1-Beta+sha")
+	require.NoError(t, err)
+
+	dir := filepath.Join("/packages", "newtonsoft.json", "13.0.1-beta")
+	require.Equal(t, dir, folder.GetInstallPath("Newtonsoft.Json", v))
+	nupkg := filepath.Join(dir, "newtonsoft.json.13.0.1-beta.nupkg")
+	require.Equal(t, nupkg, folder.GetPackageFilePath("Newtonsoft.Json", v))
+	require.Equal(t, nupkg+".sha512", folder.GetHashPath("Newtonsoft.Json", v))
+	require.Equal(t, filepath.Join(dir, "newtonsoft.json.nuspec"), folder.GetManifestFilePath("Newtonsoft.Json", v))
+	require.Equal(t, filepath.Join(dir, ".nupkg.metadata"), folder.GetMetadataPath("Newtonsoft.Json", v))
+}
+
+func TestGlobalPackagesFolder_GetOrAdd(t *testing.T) {
+	folder := NewGlobalPackagesFolder(t.TempDir())
+	downloader := &countingDownloader{data: buildTestPackage(t, "Contoso.Lib", "1.0.0", nil, "lib/net8.0/a.dll")}
+
+	var wg sync.WaitGroup
+	errs := make([]error, 8)
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = folder.GetOrAdd("Contoso.Lib", "1.0.0", "https://contoso/v3/index.json", downloader)
+		}()
+	}
+	wg.Wait()
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+	require.True(t, folder.Exists("Contoso.Lib", "1.0.0"))
+
+	downloader.count = 0
+	pkg, err := folder.GetOrAdd("Contoso.Lib", "1.0.0", "https://contoso/v3/index.json", downloader)
+	require.NoError(t, err)
+	require.Equal(t, 0, downloader.count)
+	require.Equal(t, "https://contoso/v3/index.json", pkg.Source)
+
+	_, err = folder.GetOrAdd("Contoso.Lib", "2.0.0", "", nil)
+	require.Equal(t, errors.New("downloader is required"), err)
+}
+
+func TestGlobalPackagesFolder_GetOrAdd_DownloadFails(t *testing.T) {
+	folder := NewGlobalPackagesFolder(t.TempDir())
+	downloader := &countingDownloader{data: []byte("truncated")}
+
+	_, err := folder.GetOrAdd("Contoso.Lib", "1.0.0", "", downloader)
+	require.Error(t, err)
+	// the partial download is removed
+	entries, err := os.ReadDir(filepath.Join(folder.Root(), "contoso.lib"))
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func TestGlobalPackagesFolder_GetOrAdd_FromSource(t *testing.T) {
+	mux, client := setup(t, index_V3)
+	data := buildTestPackage(t, "Contoso.Lib", "1.0.0", nil, "lib/net8.0/a.dll")
+	mux.HandleFunc("/v3-flatcontainer/contoso%2Elib/1%2E0%2E0/contoso%2Elib%2E1%2E0%2E0.nupkg",
+		func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, http.MethodGet)
+			_, err := w.Write(data)
+			require.NoError(t, err)
+		})
+
+	folder := NewGlobalPackagesFolder(t.TempDir())
+	pkg, err := folder.GetOrAdd("Contoso.Lib", "1.0.0", client.SourceURL().String(), client.FindPackageResource)
+	require.NoError(t, err)
+	require.Equal(t, client.SourceURL().String(), pkg.Source)
+}
+
+func TestGlobalPackagesFolder_IncompleteExtraction(t *testing.T) {
+	defaultTimeout := extractionWaitTimeout
+	extractionWaitTimeout = 200 * time.Millisecond
+	t.Cleanup(func() { extractionWaitTimeout = defaultTimeout })
+
+	folder := NewGlobalPackagesFolder(t.TempDir())
+	v, err := nugetVersion.Parse("1.0.0")
+	require.NoError(t, err)
+
+	// a crashed process left a partial directory behind
+	installPath := folder.GetInstallPath("Contoso.Lib", v)
+	require.NoError(t, os.MkdirAll(installPath, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(installPath, "partial"), []byte{}, 0644))
+
+	_, err = folder.AddPackage(bytes.NewReader(buildTestPackage(t, "Contoso.Lib", "1.0.0", nil)), "")
+	require.NoError(t, err)
+	require.True(t, folder.Exists("Contoso.Lib", "1.0.0"))
+	require.NoFileExists(t, filepath.Join(installPath, "partial"))
+}
@@ -0,0 +1,404 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package nuget
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	retryablehttp "github.com/hashicorp/go-retryablehttp"
+
+	"github.com/huhouhua/go-nuget/config"
+)
+
+const (
+	// headerCacheMode carries the CacheMode of a request to Client.Do, it is never sent to the server.
+	headerCacheMode = "X-Go-Nuget-Cache-Mode"
+
+	// IndexService pseudo service type of the service index, used to configure its cache ttl.
+	IndexService ServiceType = "ServiceIndex"
+)
+
+// ErrCacheMiss The response is not in the http cache, returned by CacheModeOfflineOnly requests.
+var ErrCacheMiss = errors.New("response is not in the http cache")
+
+// CacheMode How a request uses the http cache of the client.
+type CacheMode int
+
+const (
+	// CacheModeDefault serves fresh responses from the cache, stale ones are revalidated
+	// with the server using ETag and Last-Modified.
+	CacheModeDefault CacheMode = iota
+
+	// CacheModeNoCache always goes to the server, the response still refreshes the cache.
+	CacheModeNoCache
+
+	// CacheModeOfflineOnly never goes to the server, cached responses are served whatever their age
+	// and ErrCacheMiss is returned when there is none.
+	CacheModeOfflineOnly
+)
+
+// defaultCacheTTLs how long the responses of each resource are fresh, resources missing here are not cached.
+var defaultCacheTTLs = map[ServiceType]time.Duration{
+	IndexService:         40 * time.Minute,
+	PackageBaseAddress:   30 * time.Minute,
+	RegistrationsBaseURL: 30 * time.Minute,
+}
+
+// HTTPCacheEntry A cached response of a GET request.
+type HTTPCacheEntry struct {
+	URL          string    `json:"url"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"lastModified,omitempty"`
+	ContentType  string    `json:"contentType,omitempty"`
+	StoredAt     time.Time `json:"storedAt"`
+	Body         []byte    `json:"-"`
+}
+
+// HTTPCache describes the interface that all (custom) http response caches must implement.
+// Get returns ErrCacheMiss when the key is not cached.
+type HTTPCache interface {
+	Get(key string) (*HTTPCacheEntry, error)
+	Set(key string, entry *HTTPCacheEntry) error
+	Delete(key string) error
+}
+
+// WithCacheMode sets how this one request uses the http cache.
+func WithCacheMode(mode CacheMode) RequestOptionFunc {
+	return func(req *retryablehttp.Request) error {
+		req.Header.Set(headerCacheMode, strconv.Itoa(int(mode)))
+		return nil
+	}
+}
+
+// WithNoCache bypasses the http cache for this one request, the response still refreshes the cache.
+func WithNoCache() RequestOptionFunc {
+	return WithCacheMode(CacheModeNoCache)
+}
+
+// WithOfflineOnly serves this one request from the http cache only, without going to the server.
+func WithOfflineOnly() RequestOptionFunc {
+	return WithCacheMode(CacheModeOfflineOnly)
+}
+
+// WithHTTPCache caches the GET responses of the V3 resources in cache.
+func WithHTTPCache(cache HTTPCache) ClientOptionFunc {
+	return func(c *Client) error {
+		c.cache = cache
+		return nil
+	}
+}
+
+// WithDefaultHTTPCache caches the GET responses of the V3 resources on disk,
+// in the http cache folder shared with the NuGet client.
+func WithDefaultHTTPCache() ClientOptionFunc {
+	return func(c *Client) error {
+		c.cache = NewDiskHTTPCache(config.DefaultHTTPCacheFolder())
+		return nil
+	}
+}
+
+// WithCacheTTL sets how long the responses of a resource are fresh, a ttl of zero disables caching the resource.
+// Ex: WithCacheTTL(IndexService, time.Hour)
+func WithCacheTTL(serviceType ServiceType, ttl time.Duration) ClientOptionFunc {
+	return func(c *Client) error {
+		if ttl < 0 {
+			return fmt.Errorf("cache ttl of %s must not be negative", serviceType)
+		}
+		c.cacheTTLs[serviceType] = ttl
+		return nil
+	}
+}
+
+// httpCachePolicy how one request is served from and stored in the http cache.
+type httpCachePolicy struct {
+	cache HTTPCache
+	key   string
+	ttl   time.Duration
+	mode  CacheMode
+}
+
+// cachePolicy returns the cache policy of the request, nil when the response must not be cached.
+// Only GET requests of the V3 resources with a ttl whose response is decoded are cached,
+// raw downloads such as nupkgs are left to the global packages folder.
+func (c *Client) cachePolicy(req *retryablehttp.Request, decoder DecoderType) (*httpCachePolicy, error) {
+	mode := CacheModeDefault
+	if v := req.Header.Get(headerCacheMode); v != "" {
+		m, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cache mode %s", v)
+		}
+		mode = CacheMode(m)
+		req.Header.Del(headerCacheMode)
+	}
+	if c.cache == nil || req.Method != http.MethodGet || decoder == DecoderEmpty {
+		if mode == CacheModeOfflineOnly {
+			return nil, ErrCacheMiss
+		}
+		return nil, nil
+	}
+	ttl, ok := c.cacheTTL(req.URL)
+	if !ok || ttl <= 0 {
+		if mode == CacheModeOfflineOnly {
+			return nil, ErrCacheMiss
+		}
+		return nil, nil
+	}
+	return &httpCachePolicy{cache: c.cache, key: httpCacheKey(req.URL), ttl: ttl, mode: mode}, nil
+}
+
+// cacheTTL returns the ttl of the resource serving u, the resource with the longest matching url wins.
+func (c *Client) cacheTTL(u *url.URL) (time.Duration, bool) {
+	if c.sourceURL != nil && strings.EqualFold(u.Host, c.sourceURL.Host) && u.Path == c.sourceURL.Path {
+		ttl, ok := c.cacheTTLs[IndexService]
+		return ttl, ok
+	}
+	var match ServiceType
+	var length int
+	for serviceType, svcURL := range c.serviceURLs {
+		if !strings.EqualFold(u.Host, svcURL.Host) || len(svcURL.Path) <= length {
+			continue
+		}
+		if u.Path == svcURL.Path || strings.HasPrefix(u.Path, strings.TrimSuffix(svcURL.Path, "/")+"/") {
+			match, length = serviceType, len(svcURL.Path)
+		}
+	}
+	if length == 0 {
+		return 0, false
+	}
+	ttl, ok := c.cacheTTLs[match]
+	return ttl, ok
+}
+
+// lookup returns the cached entry and whether it can be served without going to the server.
+func (p *httpCachePolicy) lookup() (*HTTPCacheEntry, bool, error) {
+	if p.mode == CacheModeNoCache {
+		return nil, false, nil
+	}
+	entry, err := p.cache.Get(p.key)
+	if errors.Is(err, ErrCacheMiss) {
+		if p.mode == CacheModeOfflineOnly {
+			return nil, false, ErrCacheMiss
+		}
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if p.mode == CacheModeOfflineOnly || time.Since(entry.StoredAt) < p.ttl {
+		return entry, true, nil
+	}
+	return entry, false, nil
+}
+
+// revalidate makes the request conditional on the stale entry.
+func (p *httpCachePolicy) revalidate(req *retryablehttp.Request, entry *HTTPCacheEntry) {
+	if entry.ETag != "" {
+		req.Header.Set("If-None-Match", entry.ETag)
+	}
+	if entry.LastModified != "" {
+		req.Header.Set("If-Modified-Since", entry.LastModified)
+	}
+}
+
+// store caches a successful response, or serves the stale entry when the server did not modify it.
+// The returned response replaces resp, its body is read from memory.
+func (p *httpCachePolicy) store(resp *http.Response, stale *HTTPCacheEntry) (*http.Response, error) {
+	switch {
+	case resp.StatusCode == http.StatusNotModified && stale != nil:
+		stale.StoredAt = time.Now()
+		if err := p.cache.Set(p.key, stale); err != nil {
+			return resp, err
+		}
+		return stale.response(resp.Request), nil
+	case resp.StatusCode == http.StatusOK:
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return resp, err
+		}
+		entry := &HTTPCacheEntry{
+			URL:          resp.Request.URL.String(),
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+			ContentType:  resp.Header.Get("Content-Type"),
+			StoredAt:     time.Now(),
+			Body:         body,
+		}
+		if err = p.cache.Set(p.key, entry); err != nil {
+			return resp, err
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		return resp, nil
+	}
+	return resp, nil
+}
+
+// response returns the entry as a 200 response to req.
+func (e *HTTPCacheEntry) response(req *http.Request) *http.Response {
+	header := make(http.Header)
+	if e.ETag != "" {
+		header.Set("ETag", e.ETag)
+	}
+	if e.LastModified != "" {
+		header.Set("Last-Modified", e.LastModified)
+	}
+	if e.ContentType != "" {
+		header.Set("Content-Type", e.ContentType)
+	}
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
+
+// httpCacheKey returns the cache key of the url.
+func httpCacheKey(u *url.URL) string {
+	sum := sha256.Sum256([]byte(u.String()))
+	return hex.EncodeToString(sum[:])
+}
+
+// MemoryHTTPCache An in memory HTTPCache, safe for concurrent use.
+type MemoryHTTPCache struct {
+	mu      sync.RWMutex
+	entries map[string]*HTTPCacheEntry
+}
+
+// NewMemoryHTTPCache returns an empty in memory http cache.
+func NewMemoryHTTPCache() *MemoryHTTPCache {
+	return &MemoryHTTPCache{entries: make(map[string]*HTTPCacheEntry)}
+}
+
+// Get returns a copy of the cached entry.
+func (m *MemoryHTTPCache) Get(key string) (*HTTPCacheEntry, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	entry, ok := m.entries[key]
+	if !ok {
+		return nil, ErrCacheMiss
+	}
+	e := *entry
+	return &e, nil
+}
+
+// Set caches a copy of the entry.
+func (m *MemoryHTTPCache) Set(key string, entry *HTTPCacheEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e := *entry
+	m.entries[key] = &e
+	return nil
+}
+
+// Delete removes the entry from the cache.
+func (m *MemoryHTTPCache) Delete(key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.entries, key)
+	return nil
+}
+
+// DiskHTTPCache A HTTPCache storing each entry as a {key}.dat body and a {key}.json header file under root.
+type DiskHTTPCache struct {
+	root string
+}
+
+// NewDiskHTTPCache returns the disk http cache at root, the folder is created on first write.
+func NewDiskHTTPCache(root string) *DiskHTTPCache {
+	return &DiskHTTPCache{root: root}
+}
+
+// Root returns the folder the entries are stored in.
+func (d *DiskHTTPCache) Root() string {
+	return d.root
+}
+
+// Get reads the entry from disk.
+func (d *DiskHTTPCache) Get(key string) (*HTTPCacheEntry, error) {
+	data, err := os.ReadFile(d.path(key, ".json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrCacheMiss
+	}
+	if err != nil {
+		return nil, err
+	}
+	var entry HTTPCacheEntry
+	if err = json.Unmarshal(data, &entry); err != nil {
+		// A corrupted entry is a miss, the next response overwrites it.
+		return nil, ErrCacheMiss
+	}
+	if entry.Body, err = os.ReadFile(d.path(key, ".dat")); errors.Is(err, os.ErrNotExist) {
+		return nil, ErrCacheMiss
+	} else if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// Set writes the entry to disk, the body first so a readable header always has its body.
+func (d *DiskHTTPCache) Set(key string, entry *HTTPCacheEntry) error {
+	if err := os.MkdirAll(d.root, 0755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if err = writeFileAtomic(d.path(key, ".dat"), entry.Body); err != nil {
+		return err
+	}
+	return writeFileAtomic(d.path(key, ".json"), data)
+}
+
+// Delete removes the entry from disk.
+func (d *DiskHTTPCache) Delete(key string) error {
+	for _, ext := range []string{".json", ".dat"} {
+		if err := os.Remove(d.path(key, ext)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *DiskHTTPCache) path(key, ext string) string {
+	return filepath.Join(d.root, key+ext)
+}
+
+// writeFileAtomic writes data to a temp file renamed to name, readers never see a partial file.
+func writeFileAtomic(name string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(name), filepath.Base(name)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err = f.Write(data); err != nil {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		return err
+	}
+	if err = f.Close(); err != nil {
+		_ = os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), name)
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package nuget
+
+import (
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// setupHTTPCache creates a client caching in cache, the versions of newtonsoft.json are served with an ETag.
+func setupHTTPCache(t *testing.T, cache HTTPCache, options ...ClientOptionFunc) (*Client, *int32, *int32) {
+	mux, client := setup(t, index_V3)
+	for _, fn := range append([]ClientOptionFunc{WithHTTPCache(cache)}, options...) {
+		require.NoError(t, fn(client))
+	}
+
+	var hits, notModified int32
+	baseURL := client.getResourceURL(PackageBaseAddress)
+	mux.HandleFunc(fmt.Sprintf("%s/newtonsoft.json/index.json", baseURL.Path), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		require.Empty(t, r.Header.Get(headerCacheMode))
+		atomic.AddInt32(&hits, 1)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			atomic.AddInt32(&notModified, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		mustWriteHTTPResponse(t, w, "testdata/list_all_versions.json")
+	})
+	return client, &hits, &notModified
+}
+
+func TestHTTPCache_Fresh(t *testing.T) {
+	client, hits, _ := setupHTTPCache(t, NewMemoryHTTPCache())
+
+	for i := 0; i < 3; i++ {
+		versions, resp, err := client.FindPackageResource.ListAllVersions("newtonsoft.json")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Len(t, versions, 2)
+	}
+	require.Equal(t, int32(1), atomic.LoadInt32(hits))
+}
+
+func TestHTTPCache_Revalidate(t *testing.T) {
+	client, hits, notModified := setupHTTPCache(t, NewMemoryHTTPCache(), WithCacheTTL(PackageBaseAddress, time.Nanosecond))
+
+	for i := 0; i < 2; i++ {
+		versions, _, err := client.FindPackageResource.ListAllVersions("newtonsoft.json")
+		require.NoError(t, err)
+		require.Len(t, versions, 2)
+	}
+	require.Equal(t, int32(2), atomic.LoadInt32(hits))
+	require.Equal(t, int32(1), atomic.LoadInt32(notModified))
+}
+
+func TestHTTPCache_Modes(t *testing.T) {
+	client, hits, _ := setupHTTPCache(t, NewMemoryHTTPCache())
+
+	_, _, err := client.FindPackageResource.ListAllVersions("newtonsoft.json", WithOfflineOnly())
+	require.ErrorIs(t, err, ErrCacheMiss)
+	require.Equal(t, int32(0), atomic.LoadInt32(hits))
+
+	_, _, err = client.FindPackageResource.ListAllVersions("newtonsoft.json", WithNoCache())
+	require.NoError(t, err)
+	_, _, err = client.FindPackageResource.ListAllVersions("newtonsoft.json", WithNoCache())
+	require.NoError(t, err)
+	require.Equal(t, int32(2), atomic.LoadInt32(hits))
+
+	versions, _, err := client.FindPackageResource.ListAllVersions("newtonsoft.json", WithOfflineOnly())
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+	require.Equal(t, int32(2), atomic.LoadInt32(hits))
+
+	// Search is not cached, offline requests can not be served.
+	_, _, err = client.SearchResource.Search(&SearchOptions{SearchTerm: "newtonsoft"}, WithOfflineOnly())
+	require.ErrorIs(t, err, ErrCacheMiss)
+
+	_, _, err = client.FindPackageResource.ListAllVersions("newtonsoft.json", WithCacheMode(CacheModeDefault))
+	require.NoError(t, err)
+	require.Equal(t, int32(2), atomic.LoadInt32(hits))
+}
+
+func TestHTTPCache_DisabledResource(t *testing.T) {
+	client, hits, _ := setupHTTPCache(t, NewMemoryHTTPCache(), WithCacheTTL(PackageBaseAddress, 0))
+
+	for i := 0; i < 2; i++ {
+		_, _, err := client.FindPackageResource.ListAllVersions("newtonsoft.json")
+		require.NoError(t, err)
+	}
+	require.Equal(t, int32(2), atomic.LoadInt32(hits))
+
+	require.Error(t, WithCacheTTL(PackageBaseAddress, -time.Second)(client))
+}
+
+func TestDiskHTTPCache(t *testing.T) {
+	root := t.TempDir()
+	client, hits, _ := setupHTTPCache(t, NewDiskHTTPCache(root))
+
+	_, _, err := client.FindPackageResource.ListAllVersions("newtonsoft.json")
+	require.NoError(t, err)
+
+	// A new cache over the same folder serves the response offline.
+	require.NoError(t, WithHTTPCache(NewDiskHTTPCache(root))(client))
+	versions, _, err := client.FindPackageResource.ListAllVersions("newtonsoft.json", WithOfflineOnly())
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+	require.Equal(t, int32(1), atomic.LoadInt32(hits))
+
+	cache := NewDiskHTTPCache(root)
+	require.Equal(t, root, cache.Root())
+	entry := &HTTPCacheEntry{URL: "https://example.org/index.json", ETag: `"v2"`, StoredAt: time.Now(), Body: []byte("{}")}
+	require.NoError(t, cache.Set("key", entry))
+
+	got, err := cache.Get("key")
+	require.NoError(t, err)
+	require.Equal(t, entry.ETag, got.ETag)
+	require.Equal(t, entry.Body, got.Body)
+
+	require.NoError(t, cache.Delete("key"))
+	require.NoError(t, cache.Delete("key"))
+	_, err = cache.Get("key")
+	require.ErrorIs(t, err, ErrCacheMiss)
+}
@@ -0,0 +1,408 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package nuget
+
+import (
+	"crypto/sha512"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/huhouhua/go-nuget/internal/consts"
+	"github.com/huhouhua/go-nuget/meta"
+
+	nugetVersion "github.com/huhouhua/go-nuget/version"
+)
+
+// LocalFolderResource reads packages from a folder of .nupkg files, without any HTTP server.
+// Both the flat layout {id}.{version}.nupkg and the hierarchical layout
+// {id}/{version}/{id}.{version}.nupkg used by nuget add and the global packages folder are supported.
+// Methods mirror FindPackageResource and PackageSearchResource, request options are ignored
+// and the returned *http.Response is always nil.
+type LocalFolderResource struct {
+	root string
+}
+
+// localPackage a .nupkg found in the folder
+type localPackage struct {
+	id      string
+	version *nugetVersion.Version
+	path    string
+}
+
+var _ DependencyProvider = (*LocalFolderResource)(nil)
+
+// NewLocalFolderResource returns a resource reading the packages of the root folder.
+func NewLocalFolderResource(root string) (*LocalFolderResource, error) {
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", root)
+	}
+	return &LocalFolderResource{root: root}, nil
+}
+
+// Root returns the folder the packages are read from.
+func (l *LocalFolderResource) Root() string {
+	return l.root
+}
+
+// ListAllVersions gets all package versions for a package ID, sorted ascending.
+func (l *LocalFolderResource) ListAllVersions(
+	id string,
+	options ...RequestOptionFunc,
+) ([]*nugetVersion.Version, *http.Response, error) {
+	packages, err := l.findPackages(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(packages) == 0 {
+		return nil, nil, fmt.Errorf("%s: %w", id, ErrNotFound)
+	}
+	versions := make([]*nugetVersion.Version, 0, len(packages))
+	for _, pkg := range packages {
+		versions = append(versions, pkg.version)
+	}
+	return versions, nil, nil
+}
+
+// GetDependencyInfo gets dependency information for a specific package.
+func (l *LocalFolderResource) GetDependencyInfo(
+	id, version string,
+	options ...RequestOptionFunc,
+) (*meta.PackageDependencyInfo, *http.Response, error) {
+	nuspec, err := l.GetNuspec(id, version)
+	if err != nil {
+		return nil, nil, err
+	}
+	dependencyInfo, err := meta.NewPackageDependencyInfoFromNuspec(nuspec)
+	if err != nil {
+		return nil, nil, err
+	}
+	return dependencyInfo, nil, nil
+}
+
+// CopyNupkgToStream copies a specific package version to the provided writer.
+func (l *LocalFolderResource) CopyNupkgToStream(
+	id string,
+	opt *CopyNupkgOptions,
+	options ...RequestOptionFunc,
+) (*http.Response, error) {
+	if opt == nil || opt.Writer == nil {
+		return nil, fmt.Errorf("writer is required")
+	}
+	pkg, err := l.findPackage(id, opt.Version)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(pkg.path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	_, err = io.Copy(opt.Writer, file)
+	return nil, err
+}
+
+// DownloadNupkg copies a specific package version to w. Offset, Progress and SHA512 are supported,
+// VerifyHash is ignored as the folder has no registration to read the hash from.
+func (l *LocalFolderResource) DownloadNupkg(
+	id string,
+	w io.Writer,
+	opt *DownloadOptions,
+	options ...RequestOptionFunc,
+) (*http.Response, error) {
+	if opt == nil || strings.TrimSpace(opt.Version) == "" {
+		return nil, fmt.Errorf("version is required")
+	}
+	if opt.SHA512 != "" && opt.Offset > 0 {
+		return nil, fmt.Errorf("hash verification of a resumed download requires DownloadNupkgToFile")
+	}
+	pkg, err := l.findPackage(id, opt.Version)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(pkg.path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if _, err = file.Seek(opt.Offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+	reader := &downloadReader{body: file, transferred: opt.Offset, total: info.Size(), progress: opt.Progress}
+	if opt.SHA512 != "" {
+		reader.hash, reader.expectedHash = sha512.New(), opt.SHA512
+	}
+	_, err = io.Copy(w, reader)
+	return nil, err
+}
+
+// OpenPackage returns a reader over a specific package version, the caller must Close it.
+func (l *LocalFolderResource) OpenPackage(id, version string) (*PackageArchiveReader, error) {
+	pkg, err := l.findPackage(id, version)
+	if err != nil {
+		return nil, err
+	}
+	return OpenPackageArchiveReader(pkg.path)
+}
+
+// GetNuspec returns the nuspec of a specific package version.
+func (l *LocalFolderResource) GetNuspec(id, version string) (*meta.Nuspec, error) {
+	reader, err := l.OpenPackage(id, version)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return reader.Nuspec()
+}
+
+// Search returns the packages whose id starts with the search term, ordered by id.
+// Only SearchTerm, IncludePrerelease, Skip and Take are honored.
+func (l *LocalFolderResource) Search(
+	opt *SearchOptions,
+	options ...RequestOptionFunc,
+) ([]*PackageSearchMetadata, *http.Response, error) {
+	if opt == nil {
+		opt = &SearchOptions{}
+	}
+	packages, err := l.allPackages()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	byID := make(map[string][]*localPackage)
+	seen := make(map[string]bool)
+	ids := make([]string, 0)
+	for _, pkg := range packages {
+		if !opt.IncludePrerelease && pkg.version.Semver.Prerelease() != "" {
+			continue
+		}
+		if !strings.HasPrefix(strings.ToLower(pkg.id), strings.ToLower(opt.SearchTerm)) {
+			continue
+		}
+		key := strings.ToLower(pkg.id)
+		// a version may be in both layouts
+		if identity := key + " " + versionKey(pkg.version); seen[identity] {
+			continue
+		} else {
+			seen[identity] = true
+		}
+		if _, ok := byID[key]; !ok {
+			ids = append(ids, key)
+		}
+		byID[key] = append(byID[key], pkg)
+	}
+	sort.Strings(ids)
+
+	if opt.Skip >= len(ids) {
+		return []*PackageSearchMetadata{}, nil, nil
+	}
+	ids = ids[opt.Skip:]
+	if opt.Take > 0 && opt.Take < len(ids) {
+		ids = ids[:opt.Take]
+	}
+
+	results := make([]*PackageSearchMetadata, 0, len(ids))
+	for _, key := range ids {
+		versions := byID[key]
+		sortLocalPackages(versions)
+		latest := versions[len(versions)-1]
+		reader, err := OpenPackageArchiveReader(latest.path)
+		if err != nil {
+			return nil, nil, err
+		}
+		nuspec, err := reader.Nuspec()
+		_ = reader.Close()
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid package %s: %w", latest.path, err)
+		}
+		results = append(results, newLocalSearchMetadata(nuspec, versions))
+	}
+	return results, nil, nil
+}
+
+func newLocalSearchMetadata(nuspec *meta.Nuspec, versions []*localPackage) *PackageSearchMetadata {
+	info := nuspec.Metadata.PackageInfo
+	result := &PackageSearchMetadata{
+		SearchMetadata: &SearchMetadata{
+			PackageId:                info.ID,
+			Version:                  info.Version,
+			Description:              info.Description,
+			IconURL:                  info.IconURL,
+			Language:                 info.Language,
+			LicenseURL:               info.LicenseURL,
+			ProjectURL:               info.ProjectURL,
+			RequireLicenseAcceptance: info.RequireLicenseAcceptance,
+			Summary:                  info.Summary,
+			Tags:                     nuspec.GetTags(),
+			Title:                    info.Title,
+			IsListed:                 true,
+		},
+		Authors: nuspec.GetAuthors(),
+		Owners:  nuspec.GetOwners(),
+	}
+	if info.License != nil && info.License.Type == "expression" {
+		result.LicenseExpression = info.License.Value
+	}
+	for _, pkg := range versions {
+		result.Versions = append(result.Versions, &VersionInfo{Url: pkg.path, Version: pkg.version.OriginalVersion})
+	}
+	return result
+}
+
+// findPackage returns the package of a specific version.
+func (l *LocalFolderResource) findPackage(id, version string) (*localPackage, error) {
+	v, err := nugetVersion.Parse(version)
+	if err != nil {
+		return nil, err
+	}
+	packages, err := l.findPackages(id)
+	if err != nil {
+		return nil, err
+	}
+	key := versionKey(v)
+	for _, pkg := range packages {
+		if versionKey(pkg.version) == key {
+			return pkg, nil
+		}
+	}
+	return nil, fmt.Errorf("%s %s: %w", id, version, ErrNotFound)
+}
+
+// findPackages returns the packages of an id in both layouts, sorted by version.
+// A version found in the hierarchical layout wins over the flat one.
+func (l *LocalFolderResource) findPackages(id string) ([]*localPackage, error) {
+	if _, err := parseID(id); err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(l.root)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	packages := make([]*localPackage, 0)
+	add := func(pkg *localPackage) {
+		if key := versionKey(pkg.version); !seen[key] {
+			seen[key] = true
+			packages = append(packages, pkg)
+		}
+	}
+	for _, entry := range entries {
+		if entry.IsDir() && strings.EqualFold(entry.Name(), id) {
+			hierarchical, err := l.readIDFolder(entry.Name())
+			if err != nil {
+				return nil, err
+			}
+			for _, pkg := range hierarchical {
+				add(pkg)
+			}
+		}
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !isPackageFile(entry.Name()) {
+			continue
+		}
+		name := strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))
+		if len(name) <= len(id)+1 || !strings.EqualFold(name[:len(id)+1], id+".") {
+			continue
+		}
+		// Ex: Foo.Bar.1.0.0.nupkg is not a version of Foo, its remainder is not a version
+		if v, err := nugetVersion.Parse(name[len(id)+1:]); err == nil {
+			add(&localPackage{id: id, version: v, path: filepath.Join(l.root, entry.Name())})
+		}
+	}
+	sortLocalPackages(packages)
+	return packages, nil
+}
+
+// readIDFolder reads the {version}/{id}.{version}.nupkg packages of an id folder.
+func (l *LocalFolderResource) readIDFolder(id string) ([]*localPackage, error) {
+	versionDirs, err := os.ReadDir(filepath.Join(l.root, id))
+	if err != nil {
+		return nil, err
+	}
+	packages := make([]*localPackage, 0, len(versionDirs))
+	for _, versionDir := range versionDirs {
+		if !versionDir.IsDir() {
+			continue
+		}
+		v, err := nugetVersion.Parse(versionDir.Name())
+		if err != nil {
+			continue
+		}
+		files, err := os.ReadDir(filepath.Join(l.root, id, versionDir.Name()))
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			if !file.IsDir() && isPackageFile(file.Name()) {
+				path := filepath.Join(l.root, id, versionDir.Name(), file.Name())
+				packages = append(packages, &localPackage{id: id, version: v, path: path})
+				break
+			}
+		}
+	}
+	return packages, nil
+}
+
+// allPackages returns every package of the folder, ids are read from the nuspec of flat packages
+// since the file name alone can't tell where the id ends.
+func (l *LocalFolderResource) allPackages() ([]*localPackage, error) {
+	entries, err := os.ReadDir(l.root)
+	if err != nil {
+		return nil, err
+	}
+	packages := make([]*localPackage, 0)
+	for _, entry := range entries {
+		switch {
+		case entry.IsDir():
+			hierarchical, err := l.readIDFolder(entry.Name())
+			if err != nil {
+				return nil, err
+			}
+			packages = append(packages, hierarchical...)
+		case isPackageFile(entry.Name()):
+			path := filepath.Join(l.root, entry.Name())
+			reader, err := OpenPackageArchiveReader(path)
+			if err != nil {
+				return nil, err
+			}
+			nuspec, err := reader.Nuspec()
+			_ = reader.Close()
+			if err != nil {
+				return nil, fmt.Errorf("invalid package %s: %w", path, err)
+			}
+			v, err := nuspec.GetVersion()
+			if err != nil {
+				return nil, fmt.Errorf("invalid package %s: %w", path, err)
+			}
+			packages = append(packages, &localPackage{id: nuspec.GetId(), version: v, path: path})
+		}
+	}
+	return packages, nil
+}
+
+// isPackageFile true for .nupkg files, symbol packages excluded
+func isPackageFile(name string) bool {
+	name = strings.ToLower(name)
+	return strings.HasSuffix(name, consts.PackageExtension) && !strings.HasSuffix(name, consts.SymbolsExtension)
+}
+
+func sortLocalPackages(packages []*localPackage) {
+	sort.SliceStable(packages, func(i, j int) bool {
+		return compareVersion(packages[i].version, packages[j].version) < 0
+	})
+}
@@ -0,0 +1,259 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package nuget
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha512"
+	"encoding/base64"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/huhouhua/go-nuget/framework"
+	"github.com/huhouhua/go-nuget/meta"
+
+	nugetVersion "github.com/huhouhua/go-nuget/version"
+
+	"github.com/stretchr/testify/require"
+)
+
+// writeTestPackage writes a .nupkg holding a nuspec of the id and version, and the given dependencies.
+func writeTestPackage(t *testing.T, path, id, version string, dependencies ...*meta.Dependency) {
+	t.Helper()
+	data := buildTestPackage(t, id, version, dependencies, "lib/net8.0/"+id+".dll")
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, data, 0644))
+}
+
+// buildTestPackage returns a .nupkg holding a nuspec of the id and version, and the given files.
+func buildTestPackage(t *testing.T, id, version string, dependencies []*meta.Dependency, files ...string) []byte {
+	t.Helper()
+	metadata := &meta.Metadata{
+		PackageInfo: meta.PackageInfo{
+			ID:          id,
+			Version:     version,
+			Authors:     "kevin",
+			Description: id + " description",
+			Tags:        "test local",
+		},
+	}
+	if len(dependencies) > 0 {
+		metadata.Dependencies = &meta.Dependencies{Dependency: dependencies}
+	}
+	nuspec, err := meta.NewNuspec(metadata).ToBytes()
+	require.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+	w := zip.NewWriter(buf)
+	f, err := w.Create(id + ".nuspec")
+	require.NoError(t, err)
+	_, err = f.Write(nuspec)
+	require.NoError(t, err)
+	for _, name := range files {
+		f, err = w.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(name))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func setupLocalFolder(t *testing.T) *LocalFolderResource {
+	root := t.TempDir()
+	// flat layout
+	writeTestPackage(t, filepath.Join(root, "Contoso.Lib.1.0.0.nupkg"), "Contoso.Lib", "1.0.0")
+	writeTestPackage(t, filepath.Join(root, "Contoso.Lib.1.1.0-beta.nupkg"), "Contoso.Lib", "1.1.0-beta")
+	writeTestPackage(t, filepath.Join(root, "Contoso.Lib.Extensions.1.0.0.nupkg"), "Contoso.Lib.Extensions", "1.0.0")
+	writeTestPackage(t, filepath.Join(root, "Contoso.Lib.1.0.0.symbols.nupkg"), "Contoso.Lib", "1.0.0")
+	// hierarchical layout
+	writeTestPackage(t, filepath.Join(root, "contoso.core", "2.0.0", "contoso.core.2.0.0.nupkg"), "Contoso.Core", "2.0.0")
+	writeTestPackage(t, filepath.Join(root, "contoso.core", "2.1.0", "contoso.core.2.1.0.nupkg"), "Contoso.Core", "2.1.0")
+	// wins over the flat Contoso.Lib.1.0.0.nupkg
+	writeTestPackage(t, filepath.Join(root, "contoso.lib", "1.0.0", "contoso.lib.1.0.0.nupkg"), "Contoso.Lib", "1.0.0",
+		&meta.Dependency{Id: "Contoso.Core", VersionRangeRaw: "[2.0.0, )"})
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "contoso.core", "not-a-version"), 0755))
+
+	local, err := NewLocalFolderResource(root)
+	require.NoError(t, err)
+	require.Equal(t, root, local.Root())
+	return local
+}
+
+func TestLocalFolderResource_ListAllVersions(t *testing.T) {
+	local := setupLocalFolder(t)
+
+	tests := []struct {
+		id   string
+		want []string
+	}{
+		{id: "Contoso.Lib", want: []string{"1.0.0", "1.1.0-beta"}},
+		{id: "contoso.core", want: []string{"2.0.0", "2.1.0"}},
+		{id: "Contoso.Lib.Extensions", want: []string{"1.0.0"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			versions, resp, err := local.ListAllVersions(tt.id)
+			require.NoError(t, err)
+			require.Nil(t, resp)
+			actual := make([]string, 0, len(versions))
+			for _, v := range versions {
+				actual = append(actual, v.OriginalVersion)
+			}
+			require.Equal(t, tt.want, actual)
+		})
+	}
+
+	_, _, err := local.ListAllVersions("missing")
+	require.ErrorIs(t, err, ErrNotFound)
+	_, _, err = local.ListAllVersions("")
+	require.Equal(t, errors.New("id is empty"), err)
+}
+
+func TestLocalFolderResource_GetDependencyInfo(t *testing.T) {
+	local := setupLocalFolder(t)
+
+	info, resp, err := local.GetDependencyInfo("Contoso.Lib", "1.0")
+	require.NoError(t, err)
+	require.Nil(t, resp)
+	require.Equal(t, "Contoso.Lib", info.PackageIdentity.Id)
+	require.Len(t, info.DependencyGroups, 1)
+	require.Equal(t, "Contoso.Core", info.DependencyGroups[0].Packages[0].Id)
+
+	_, _, err = local.GetDependencyInfo("Contoso.Lib", "9.0.0")
+	require.ErrorIs(t, err, ErrNotFound)
+	_, _, err = local.GetDependencyInfo("Contoso.Lib", "")
+	require.Error(t, err)
+}
+
+func TestLocalFolderResource_CopyNupkgToStream(t *testing.T) {
+	local := setupLocalFolder(t)
+
+	buf := &bytes.Buffer{}
+	resp, err := local.CopyNupkgToStream("contoso.core", &CopyNupkgOptions{Version: "2.1.0", Writer: buf})
+	require.NoError(t, err)
+	require.Nil(t, resp)
+
+	reader, err := NewPackageArchiveReader(buf)
+	require.NoError(t, err)
+	nuspec, err := reader.Nuspec()
+	require.NoError(t, err)
+	require.Equal(t, "2.1.0", nuspec.Metadata.Version)
+
+	_, err = local.CopyNupkgToStream("contoso.core", nil)
+	require.Equal(t, errors.New("writer is required"), err)
+}
+
+func TestLocalFolderResource_DownloadNupkg(t *testing.T) {
+	local := setupLocalFolder(t)
+	data, err := os.ReadFile(filepath.Join(local.Root(), "contoso.core", "2.1.0", "contoso.core.2.1.0.nupkg"))
+	require.NoError(t, err)
+	hash := sha512.Sum512(data)
+
+	buf := &bytes.Buffer{}
+	var transferred, total int64
+	_, err = local.DownloadNupkg("contoso.core", buf, &DownloadOptions{
+		Version:  "2.1.0",
+		SHA512:   base64.StdEncoding.EncodeToString(hash[:]),
+		Progress: func(n, size int64) { transferred, total = n, size },
+	})
+	require.NoError(t, err)
+	require.Equal(t, data, buf.Bytes())
+	require.Equal(t, int64(len(data)), transferred)
+	require.Equal(t, int64(len(data)), total)
+
+	buf.Reset()
+	_, err = local.DownloadNupkg("contoso.core", buf, &DownloadOptions{Version: "2.1.0", Offset: 10})
+	require.NoError(t, err)
+	require.Equal(t, data[10:], buf.Bytes())
+
+	_, err = local.DownloadNupkg("contoso.core", io.Discard, &DownloadOptions{Version: "2.1.0", SHA512: "aGFzaA=="})
+	require.ErrorIs(t, err, ErrPackageHashMismatch)
+	_, err = local.DownloadNupkg("contoso.core", io.Discard, nil)
+	require.Equal(t, errors.New("version is required"), err)
+}
+
+func TestLocalFolderResource_OpenPackage(t *testing.T) {
+	local := setupLocalFolder(t)
+
+	reader, err := local.OpenPackage("Contoso.Lib.Extensions", "1.0.0")
+	require.NoError(t, err)
+	defer reader.Close()
+	require.Len(t, reader.GetFilesFromDir("lib"), 1)
+
+	nuspec, err := local.GetNuspec("Contoso.Lib", "1.1.0-beta")
+	require.NoError(t, err)
+	require.Equal(t, "Contoso.Lib", nuspec.GetId())
+}
+
+func TestLocalFolderResource_Search(t *testing.T) {
+	local := setupLocalFolder(t)
+
+	results, resp, err := local.Search(&SearchOptions{SearchTerm: "contoso.lib", IncludePrerelease: true})
+	require.NoError(t, err)
+	require.Nil(t, resp)
+	require.Len(t, results, 2)
+	require.Equal(t, "Contoso.Lib", results[0].PackageId)
+	require.Equal(t, "1.1.0-beta", results[0].Version)
+	require.Equal(t, "Contoso.Lib description", results[0].Description)
+	require.Equal(t, []string{"kevin"}, results[0].Authors)
+	require.Equal(t, []string{"test", "local"}, results[0].Tags)
+	require.Len(t, results[0].Versions, 2)
+	require.Equal(t, "Contoso.Lib.Extensions", results[1].PackageId)
+
+	tests := []struct {
+		name string
+		opt  *SearchOptions
+		want []string
+	}{
+		{name: "all", opt: nil, want: []string{"Contoso.Core", "Contoso.Lib", "Contoso.Lib.Extensions"}},
+		{name: "prefix", opt: &SearchOptions{SearchTerm: "Contoso.C"}, want: []string{"Contoso.Core"}},
+		{name: "skip take", opt: &SearchOptions{Skip: 1, Take: 1}, want: []string{"Contoso.Lib"}},
+		{name: "skip all", opt: &SearchOptions{Skip: 5}, want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, _, err := local.Search(tt.opt)
+			require.NoError(t, err)
+			ids := make([]string, 0, len(results))
+			for _, result := range results {
+				ids = append(ids, result.PackageId)
+			}
+			require.Equal(t, tt.want, ids)
+		})
+	}
+
+	// without prerelease the latest stable version is described
+	results, _, err = local.Search(&SearchOptions{SearchTerm: "contoso.lib"})
+	require.NoError(t, err)
+	require.Equal(t, "1.0.0", results[0].Version)
+	require.Len(t, results[0].Versions, 1)
+}
+
+func TestLocalFolderResource_ResolveGraph(t *testing.T) {
+	local := setupLocalFolder(t)
+
+	versionRange, err := nugetVersion.ParseRange("1.0.0")
+	require.NoError(t, err)
+	dependency := &meta.Dependency{Id: "Contoso.Lib", VersionRange: versionRange}
+	graph, err := NewDependencyGraphResolver(local, framework.Net80).Resolve([]*meta.Dependency{dependency})
+	require.NoError(t, err)
+	require.NotNil(t, graph.Find("Contoso.Core"))
+	require.Equal(t, "2.0.0", graph.Find("Contoso.Core").Version.OriginalVersion)
+}
+
+func TestNewLocalFolderResource(t *testing.T) {
+	_, err := NewLocalFolderResource(filepath.Join(t.TempDir(), "missing"))
+	require.Error(t, err)
+
+	file := filepath.Join(t.TempDir(), "file.nupkg")
+	require.NoError(t, os.WriteFile(file, []byte{}, 0644))
+	_, err = NewLocalFolderResource(file)
+	require.Equal(t, errors.New(file+" is not a directory"), err)
+}
@@ -11,7 +11,7 @@ import (
 	"strings"
 	"time"
 
-	"github.com/huhouhua/go-nuget/internal/meta"
+	"github.com/huhouhua/go-nuget/meta"
 
 	nugetVersion "github.com/huhouhua/go-nuget/version"
 )
@@ -30,11 +30,23 @@ type PackageSearchMetadataRegistration struct {
 
 	CatalogURL string `json:"@id"`
 
+	// PackageHash The base64 hash of the nupkg, published by the feeds that include it in the registration.
+	PackageHash string `json:"packageHash"`
+
+	// PackageHashAlgorithm The algorithm of PackageHash, Ex: SHA512
+	PackageHashAlgorithm string `json:"packageHashAlgorithm"`
+
+	// PackageSize The size of the nupkg in bytes.
+	PackageSize int64 `json:"packageSize"`
+
 	ReadmeFileURL *url.URL `json:"-"`
 
 	ReportAbuseURL *url.URL `json:"-"`
 
 	PackageDetailsURL *url.URL `json:"-"`
+
+	// OwnerDetailsURLs The profile URL of each of the owners, nil when the source has no owner details template.
+	OwnerDetailsURLs map[string]*url.URL `json:"-"`
 }
 
 // SearchMetadata Package metadata only containing select fields relevant to search results processing and presenting.
@@ -111,9 +123,14 @@ func (p *SearchMetadata) Identity() (*meta.PackageIdentity, error) {
 	return p.identity, nil
 }
 
+// OwnersList Returns the owners of the package, Owners split on commas.
 func (p *PackageSearchMetadataRegistration) OwnersList() []string {
 	if p.qwnersList == nil && p.Owners != "" {
-		p.qwnersList = strings.Split(p.Owners, ",")
+		for _, owner := range strings.Split(p.Owners, ",") {
+			if owner = strings.TrimSpace(owner); owner != "" {
+				p.qwnersList = append(p.qwnersList, owner)
+			}
+		}
 	}
 	return p.qwnersList
 }
@@ -258,10 +275,12 @@ func (p *PackageMetadataResource) configureMetadataURL(catalogEntry *PackageSear
 	reportAbuseURL := p.client.getResourceURL(ReportAbuseURLTemplate)
 	detailURL := p.client.getResourceURL(PackageDetailsURLTemplate)
 	readmeURL := p.client.getResourceURL(ReadmeURLTemplate)
+	ownerURL := p.client.getResourceURL(OwnerDetailsURLTemplate)
 	return ApplyMetadataRegistration(catalogEntry,
 		WithReportAbuseURL(reportAbuseURL),
 		WithPackageDetailsURL(detailURL),
-		WithReadmeFileURL(readmeURL))
+		WithReadmeFileURL(readmeURL),
+		WithOwnerDetailsURLs(ownerURL))
 }
 
 // MetadataRegistrationFunc is a function that modifies the PackageSearchMetadataRegistration.
@@ -339,3 +358,28 @@ func WithReadmeFileURL(urlTemplate *url.URL) MetadataRegistrationFunc {
 		return nil
 	}
 }
+
+// WithOwnerDetailsURLs sets the OwnerDetailsURLs field of the PackageSearchMetadataRegistration.
+func WithOwnerDetailsURLs(urlTemplate *url.URL) MetadataRegistrationFunc {
+	return func(page *PackageSearchMetadataRegistration) error {
+		if urlTemplate == nil {
+			return nil
+		}
+		for _, owner := range page.OwnersList() {
+			u, err := ownerDetailsURL(urlTemplate, owner)
+			if err != nil {
+				return err
+			}
+			if page.OwnerDetailsURLs == nil {
+				page.OwnerDetailsURLs = make(map[string]*url.URL)
+			}
+			page.OwnerDetailsURLs[owner] = u
+		}
+		return nil
+	}
+}
+
+// ownerDetailsURL returns the profile URL of owner from the owner details template.
+func ownerDetailsURL(urlTemplate *url.URL, owner string) (*url.URL, error) {
+	return parseAndReplaceURL(urlTemplate, map[string]string{"{owner}": url.PathEscape(owner)})
+}
@@ -5,6 +5,7 @@
 package meta
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/huhouhua/go-nuget/version"
@@ -22,13 +23,28 @@ type Dependency struct {
 	Exclude         []string              `xml:"-"`
 }
 
+// NewDependency new a Dependency with the given id and version range, ex: [1.0.0, 2.0.0)
+func NewDependency(id, versionRange string) (*Dependency, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, fmt.Errorf("id is empty")
+	}
+	d := &Dependency{
+		Id:         id,
+		VersionRaw: versionRange,
+	}
+	if err := d.Parse(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
 // Parse parses the dependency version and splits the include/exclude strings into slices.
 func (d *Dependency) Parse() error {
 	if d.ExcludeRaw != "" {
 		d.Exclude = strings.Split(d.ExcludeRaw, ",")
 	}
 	if d.IncludeRaw != "" {
-		d.Exclude = strings.Split(d.IncludeRaw, ",")
+		d.Include = strings.Split(d.IncludeRaw, ",")
 	}
 	if d.VersionRaw != "" {
 		return d.parseRange(d.VersionRaw)
@@ -2,4 +2,5 @@
 // Use of this source code is governed by a MIT style
 // license that can be found in the LICENSE file.
 
+// this package provides the nuspec model, package identities and dependency groups
 package meta
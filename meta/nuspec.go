@@ -9,8 +9,14 @@ import (
 	"encoding/xml"
 	"io"
 	"os"
+	"strings"
+
+	"github.com/huhouhua/go-nuget/version"
 )
 
+// NuspecNamespace Default xml namespace of the nuspec created by NewNuspec
+const NuspecNamespace = "http://schemas.microsoft.com/packaging/2013/05/nuspec.xsd"
+
 // Nuspec Represents a .nuspec XML file found in the root of the .nupck files
 type Nuspec struct {
 	XMLName  xml.Name  `xml:"package"`
@@ -18,6 +24,103 @@ type Nuspec struct {
 	Metadata *Metadata `xml:"metadata"`
 }
 
+// NewNuspec creates a nuspec with the given metadata
+func NewNuspec(metadata *Metadata) *Nuspec {
+	if metadata == nil {
+		metadata = &Metadata{}
+	}
+	return &Nuspec{
+		Xmlns:    NuspecNamespace,
+		Metadata: metadata,
+	}
+}
+
+// GetId Package id
+func (nsf *Nuspec) GetId() string {
+	return nsf.metadata().ID
+}
+
+// GetVersion Package version
+func (nsf *Nuspec) GetVersion() (*version.Version, error) {
+	return version.Parse(nsf.metadata().Version)
+}
+
+// GetIdentity Package id and version
+func (nsf *Nuspec) GetIdentity() (*PackageIdentity, error) {
+	return NewPackageIdentity(nsf.metadata().ID, nsf.metadata().Version)
+}
+
+// GetAuthors Package authors
+func (nsf *Nuspec) GetAuthors() []string {
+	return splitList(nsf.metadata().Authors, ",")
+}
+
+// GetOwners Package owners
+func (nsf *Nuspec) GetOwners() []string {
+	return splitList(nsf.metadata().Owners, ",")
+}
+
+// GetTags Package tags, separated by spaces in the nuspec
+func (nsf *Nuspec) GetTags() []string {
+	return splitList(nsf.metadata().Tags, " ")
+}
+
+// GetPackageTypes Package types, empty for a regular dependency package
+func (nsf *Nuspec) GetPackageTypes() []*PackageType {
+	if nsf.metadata().PackageTypes == nil {
+		return make([]*PackageType, 0)
+	}
+	return nsf.metadata().PackageTypes.PackageTypes
+}
+
+// GetMinClientVersion Minimum NuGet client version required to consume the package
+func (nsf *Nuspec) GetMinClientVersion() (*version.Version, error) {
+	if strings.TrimSpace(nsf.metadata().MinClientVersion) == "" {
+		return nil, nil
+	}
+	return version.Parse(nsf.metadata().MinClientVersion)
+}
+
+// IsDevelopmentDependency True if the package is a development dependency
+func (nsf *Nuspec) IsDevelopmentDependency() bool {
+	return nsf.metadata().DevelopmentDependency
+}
+
+// GetDependencyGroups Package dependencies grouped by target framework
+func (nsf *Nuspec) GetDependencyGroups() ([]*PackageDependencyGroup, error) {
+	info, err := NewPackageDependencyInfoFromNuspec(nsf)
+	if err != nil {
+		return nil, err
+	}
+	return info.DependencyGroups, nil
+}
+
+// GetFrameworkReferenceGroups Framework assembly references grouped by target framework
+func (nsf *Nuspec) GetFrameworkReferenceGroups() ([]*FrameworkSpecificGroup, error) {
+	info := NewPackageDependencyInfo(nil, nil, nil)
+	if err := ApplyPackageDependency(info, WithFrameworkReferenceGroups(nsf.metadata().FrameworkAssemblies)); err != nil {
+		return nil, err
+	}
+	return info.FrameworkReferenceGroups, nil
+}
+
+func (nsf *Nuspec) metadata() *Metadata {
+	if nsf.Metadata == nil {
+		return &Metadata{}
+	}
+	return nsf.Metadata
+}
+
+func splitList(s, sep string) []string {
+	items := make([]string, 0)
+	for _, item := range strings.Split(s, sep) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 // ToBytes exports the nuspec to bytes in XML format
 func (nsf *Nuspec) ToBytes() ([]byte, error) {
 	var b bytes.Buffer
@@ -67,6 +170,7 @@ func FromFile(fn string) (*Nuspec, error) {
 	if err != nil {
 		return nil, err
 	}
+	defer xmlFile.Close()
 	return FromReader(xmlFile)
 }
 
@@ -11,12 +11,44 @@ import (
 	nugetVersion "github.com/huhouhua/go-nuget/version"
 )
 
+// PackageDependencyInfo Package identity with its dependency and framework reference groups.
 type PackageDependencyInfo struct {
 	PackageIdentity          *PackageIdentity
 	DependencyGroups         []*PackageDependencyGroup
 	FrameworkReferenceGroups []*FrameworkSpecificGroup
 }
 
+// NewPackageDependencyInfo new a PackageDependencyInfo, nil groups are replaced by empty ones
+func NewPackageDependencyInfo(
+	identity *PackageIdentity,
+	dependencyGroups []*PackageDependencyGroup,
+	frameworkReferenceGroups []*FrameworkSpecificGroup,
+) *PackageDependencyInfo {
+	if dependencyGroups == nil {
+		dependencyGroups = make([]*PackageDependencyGroup, 0)
+	}
+	if frameworkReferenceGroups == nil {
+		frameworkReferenceGroups = make([]*FrameworkSpecificGroup, 0)
+	}
+	return &PackageDependencyInfo{
+		PackageIdentity:          identity,
+		DependencyGroups:         dependencyGroups,
+		FrameworkReferenceGroups: frameworkReferenceGroups,
+	}
+}
+
+// NewPackageDependencyInfoFromNuspec reads the identity, dependency groups and framework references of a nuspec.
+func NewPackageDependencyInfoFromNuspec(nuspec *Nuspec) (*PackageDependencyInfo, error) {
+	if nuspec == nil || nuspec.Metadata == nil {
+		return nil, fmt.Errorf("nuspec metadata is required")
+	}
+	info := NewPackageDependencyInfo(nil, nil, nil)
+	if err := ConfigureDependencyInfo(info, *nuspec); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
 // PackageDependencyGroup  Package dependencies grouped to a target framework.
 type PackageDependencyGroup struct {
 	// TargetFramework Dependency group target framework
@@ -39,11 +71,13 @@ func NewPackageDependencyGroup(targetFramework string, packages ...*Dependency)
 	}, nil
 }
 
+// PackageIdentity Represents the core identity of a package, id and version.
 type PackageIdentity struct {
 	Id      string                `json:"id"`
 	Version *nugetVersion.Version `json:"version,omitempty"`
 }
 
+// NewPackageIdentity new a PackageIdentity, the version must be a valid NuGet version
 func NewPackageIdentity(id, version string) (*PackageIdentity, error) {
 	nv, err := nugetVersion.Parse(version)
 	if err != nil {
@@ -60,6 +94,26 @@ func (p *PackageIdentity) HasVersion() bool {
 	return p.Version != nil
 }
 
+// Equals True if the ids are equal ignoring case and the versions are equal
+func (p *PackageIdentity) Equals(other *PackageIdentity) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+	if !strings.EqualFold(p.Id, other.Id) || p.HasVersion() != other.HasVersion() {
+		return false
+	}
+	return !p.HasVersion() ||
+		(p.Version.Semver.Equal(other.Version.Semver) && p.Version.Revision == other.Version.Revision)
+}
+
+// String Returns the id and the original version, ex: Newtonsoft.Json 13.0.1
+func (p *PackageIdentity) String() string {
+	if !p.HasVersion() {
+		return p.Id
+	}
+	return fmt.Sprintf("%s %s", p.Id, p.Version.OriginalVersion)
+}
+
 // FrameworkSpecificGroup Framework specific group
 type FrameworkSpecificGroup struct {
 	Items           []string
@@ -557,14 +557,14 @@ func TestConfigureDependencyInfo(t *testing.T) {
 						VersionRaw:   "5.0.0",
 						VersionRange: versionRange500,
 						IncludeRaw:   "Build,Analyzers",
-						Exclude:      []string{"Build", "Analyzers"},
+						Include:      []string{"Build", "Analyzers"},
 						//Version:    &NuGetVersion{semver.New(5, 0, 0, "", "")},
 					},
 					{
 						Id:              "Microsoft.Extensions.test",
 						ExcludeRaw:      "",
 						IncludeRaw:      "Build,Analyzers",
-						Exclude:         []string{"Build", "Analyzers"},
+						Include:         []string{"Build", "Analyzers"},
 						VersionRangeRaw: "",
 					},
 				},
@@ -596,3 +596,66 @@ func TestConfigureDependencyInfo(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, want, input)
 }
+
+func TestNewPackageDependencyInfo(t *testing.T) {
+	info := NewPackageDependencyInfo(nil, nil, nil)
+	require.NotNil(t, info.DependencyGroups)
+	require.NotNil(t, info.FrameworkReferenceGroups)
+
+	_, err := NewPackageDependencyInfoFromNuspec(nil)
+	require.Equal(t, errors.New("nuspec metadata is required"), err)
+
+	info, err = NewPackageDependencyInfoFromNuspec(NewNuspec(&Metadata{
+		PackageInfo: PackageInfo{ID: "Fake.Package", Version: "1.0.0"},
+		Dependencies: &Dependencies{
+			Groups: []*DependenciesGroup{
+				{TargetFramework: "net8.0", Dependencies: []*Dependency{{Id: "A", VersionRaw: "1.0.0"}}},
+			},
+		},
+	}))
+	require.NoError(t, err)
+	require.Equal(t, "Fake.Package 1.0.0", info.PackageIdentity.String())
+	require.Len(t, info.DependencyGroups, 1)
+	require.NotNil(t, info.DependencyGroups[0].Packages[0].VersionRange)
+}
+
+func TestPackageIdentityEquals(t *testing.T) {
+	a, err := NewPackageIdentity("Newtonsoft.Json", "13.0.1")
+	require.NoError(t, err)
+	b, err := NewPackageIdentity("newtonsoft.json", "13.0.1")
+	require.NoError(t, err)
+	c, err := NewPackageIdentity("Newtonsoft.Json", "13.0.2")
+	require.NoError(t, err)
+
+	require.True(t, a.Equals(b))
+	require.False(t, a.Equals(c))
+	require.False(t, a.Equals(nil))
+	require.False(t, a.Equals(&PackageIdentity{Id: "Newtonsoft.Json"}))
+	require.True(t, (&PackageIdentity{Id: "A"}).Equals(&PackageIdentity{Id: "a"}))
+	require.Equal(t, "A", (&PackageIdentity{Id: "A"}).String())
+}
+
+func TestNewDependency(t *testing.T) {
+	dependency, err := NewDependency("Newtonsoft.Json", "[13.0.1, 14.0.0)")
+	require.NoError(t, err)
+	require.Equal(t, "Newtonsoft.Json", dependency.Id)
+	require.True(t, dependency.VersionRange.HasLowerBound())
+
+	_, err = NewDependency("", "1.0.0")
+	require.Equal(t, errors.New("id is empty"), err)
+
+	_, err = NewDependency("Newtonsoft.Json", "[1.0.0")
+	require.Error(t, err)
+}
+
+func TestDependency_Parse(t *testing.T) {
+	dependency := &Dependency{
+		Id:         "Newtonsoft.Json",
+		VersionRaw: "13.0.1",
+		IncludeRaw: "Compile,Runtime",
+		ExcludeRaw: "Build,Analyzers",
+	}
+	require.NoError(t, dependency.Parse())
+	require.Equal(t, []string{"Compile", "Runtime"}, dependency.Include)
+	require.Equal(t, []string{"Build", "Analyzers"}, dependency.Exclude)
+}
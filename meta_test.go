@@ -15,7 +15,7 @@ import (
 	"testing"
 	"time"
 
-	meta1 "github.com/huhouhua/go-nuget/internal/meta"
+	meta1 "github.com/huhouhua/go-nuget/meta"
 
 	nugetVersion "github.com/huhouhua/go-nuget/version"
 
@@ -1012,3 +1012,21 @@ func TestAddMetadataToPackages(t *testing.T) {
 		})
 	}
 }
+
+func TestWithOwnerDetailsURLs(t *testing.T) {
+	metadata := &PackageSearchMetadataRegistration{
+		SearchMetadata: &SearchMetadata{PackageId: "TestPackage", Version: "1.0.0"},
+		Owners:         "kevin, build service,",
+	}
+	err := WithOwnerDetailsURLs(createUrl(t, "https://example.com/profiles/{owner}?_src=template"))(metadata)
+	require.NoError(t, err)
+	require.Equal(t, []string{"kevin", "build service"}, metadata.OwnersList())
+	require.Equal(t, map[string]*url.URL{
+		"kevin":         createUrl(t, "https://example.com/profiles/kevin?_src=template"),
+		"build service": createUrl(t, "https://example.com/profiles/build%20service?_src=template"),
+	}, metadata.OwnerDetailsURLs)
+
+	metadata = &PackageSearchMetadataRegistration{SearchMetadata: &SearchMetadata{}, Owners: "kevin"}
+	require.NoError(t, WithOwnerDetailsURLs(nil)(metadata))
+	require.Nil(t, metadata.OwnerDetailsURLs)
+}
@@ -0,0 +1,468 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package nuget
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/huhouhua/go-nuget/internal/consts"
+
+	nugetVersion "github.com/huhouhua/go-nuget/version"
+)
+
+const (
+	// mirrorSearchPageSize how many package ids each search request returns when listing the source.
+	mirrorSearchPageSize = 1000
+	// defaultMirrorPushTimeout the push timeout of a package and its symbols when MirrorOptions.PushTimeout is zero.
+	defaultMirrorPushTimeout = 5 * time.Minute
+)
+
+// NuGetSymbolPackageURLTemplate Where nuget.org serves the symbol packages.
+const NuGetSymbolPackageURLTemplate = "https://globalcdn.nuget.org/symbol-packages/{id}.{version}.snupkg"
+
+// MirrorStatus The outcome of mirroring a package version.
+type MirrorStatus string
+
+const (
+	// MirrorStatusMirrored The package was pushed to the destination.
+	MirrorStatusMirrored MirrorStatus = "mirrored"
+
+	// MirrorStatusSkipped The destination already has the package.
+	MirrorStatusSkipped MirrorStatus = "skipped"
+
+	// MirrorStatusResumed The package was mirrored by a previous run recorded in the resume file.
+	MirrorStatusResumed MirrorStatus = "resumed"
+
+	// MirrorStatusFailed The package could not be mirrored, see MirrorResult.Err.
+	MirrorStatusFailed MirrorStatus = "failed"
+)
+
+// MirrorOptions What is mirrored and how.
+type MirrorOptions struct {
+	// PackageIds The packages to mirror. All the packages the source search returns when empty.
+	PackageIds []string
+
+	// IdPattern Only mirror the ids matching this case-insensitive path.Match pattern, Ex: Microsoft.Extensions.*
+	IdPattern string
+
+	// VersionRange Only mirror the versions in this range, all versions when nil.
+	VersionRange *nugetVersion.VersionRange
+
+	// SymbolPackageURLTemplate Where the source serves symbol packages, with {id} and {version} placeholders
+	// replaced by the lowercase id and normalized version, Ex: NuGetSymbolPackageURLTemplate.
+	// Symbols are not mirrored when empty.
+	SymbolPackageURLTemplate string
+
+	// SymbolSource Where symbol packages are pushed, the SymbolPackagePublish resource of the destination when empty.
+	SymbolSource string
+
+	// Parallelism How many package versions are mirrored at once, 1 when zero.
+	Parallelism int
+
+	// ResumeFile Records the mirrored package versions, they are not checked against the destination again
+	// by the next run. Nothing is recorded when empty.
+	ResumeFile string
+
+	// WorkDir Where packages are downloaded before they are pushed, a temporary directory when empty.
+	WorkDir string
+
+	// PushTimeout The timeout of the push of a package and its symbols, 5 minutes when zero.
+	PushTimeout time.Duration
+
+	// OnResult Called with the result of each package version, from the mirroring goroutines.
+	OnResult func(result *MirrorResult)
+}
+
+// MirrorResult The outcome of mirroring a package version. Version is empty when the versions of
+// the package could not be listed.
+type MirrorResult struct {
+	Id      string
+	Version string
+	Status  MirrorStatus
+
+	// Symbols True when the symbol package was mirrored with the package.
+	Symbols bool
+
+	Err error
+}
+
+// MirrorReport The outcome of a mirror run.
+type MirrorReport struct {
+	Started  time.Time
+	Finished time.Time
+	Results  []*MirrorResult
+}
+
+// Count Returns how many package versions have status.
+func (r *MirrorReport) Count(status MirrorStatus) int {
+	n := 0
+	for _, result := range r.Results {
+		if result.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+// Err Returns the failures of the run joined, nil when every package version was mirrored or skipped.
+func (r *MirrorReport) Err() error {
+	var errs []error
+	for _, result := range r.Results {
+		if result.Status == MirrorStatusFailed {
+			errs = append(errs, fmt.Errorf("%s %s: %w", result.Id, result.Version, result.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// String Returns a summary of the run.
+func (r *MirrorReport) String() string {
+	return fmt.Sprintf("%d mirrored, %d skipped, %d resumed, %d failed in %s",
+		r.Count(MirrorStatusMirrored), r.Count(MirrorStatusSkipped), r.Count(MirrorStatusResumed),
+		r.Count(MirrorStatusFailed), r.Finished.Sub(r.Started).Round(time.Millisecond))
+}
+
+type mirrorJob struct {
+	id      string
+	version *nugetVersion.Version
+}
+
+// mirror the state of a Mirror run.
+type mirror struct {
+	source      *Client
+	destination *Client
+	opt         MirrorOptions
+	workDir     string
+
+	mu       sync.Mutex
+	report   *MirrorReport
+	resumed  map[string]bool
+	resumeTo *os.File
+}
+
+// Mirror copies the package versions of source selected by opt, with their symbols,
+// to destination. Versions the destination already has are skipped. Failures of single package versions
+// are recorded in the report, the error is only set when the run could not start.
+func Mirror(source, destination *Client, opt *MirrorOptions, options ...RequestOptionFunc) (*MirrorReport, error) {
+	m := &mirror{source: source, destination: destination, report: &MirrorReport{Started: time.Now()}}
+	if opt != nil {
+		m.opt = *opt
+	}
+	if m.opt.Parallelism <= 0 {
+		m.opt.Parallelism = 1
+	}
+	if m.opt.PushTimeout <= 0 {
+		m.opt.PushTimeout = defaultMirrorPushTimeout
+	}
+	if m.opt.IdPattern != "" {
+		if _, err := path.Match(m.opt.IdPattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid id pattern %q: %w", m.opt.IdPattern, err)
+		}
+	}
+	if m.opt.SymbolPackageURLTemplate != "" && m.opt.SymbolSource == "" {
+		symbolURL := destination.getResourceURL(SymbolPackagePublish)
+		if symbolURL == nil {
+			return nil, fmt.Errorf("the destination does not support symbol packages")
+		}
+		m.opt.SymbolSource = symbolURL.String()
+	}
+	if err := m.openResumeFile(); err != nil {
+		return nil, err
+	}
+	defer m.closeResumeFile()
+
+	m.workDir = m.opt.WorkDir
+	if m.workDir == "" {
+		dir, err := os.MkdirTemp("", "nuget-mirror")
+		if err != nil {
+			return nil, err
+		}
+		defer os.RemoveAll(dir)
+		m.workDir = dir
+	}
+
+	ids := m.opt.PackageIds
+	if len(ids) == 0 {
+		var err error
+		if ids, err = m.listSourceIds(options); err != nil {
+			return nil, err
+		}
+	}
+
+	jobs := make(chan *mirrorJob)
+	var wg sync.WaitGroup
+	for range m.opt.Parallelism {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				m.record(m.mirrorVersion(job, options))
+			}
+		}()
+	}
+	for _, id := range ids {
+		if !m.matchId(id) {
+			continue
+		}
+		versions, err := m.pendingVersions(id, options)
+		if err != nil {
+			m.record(&MirrorResult{Id: id, Status: MirrorStatusFailed, Err: err})
+			continue
+		}
+		for _, version := range versions {
+			jobs <- &mirrorJob{id: id, version: version}
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	m.report.Finished = time.Now()
+	return m.report, nil
+}
+
+func (m *mirror) matchId(id string) bool {
+	if m.opt.IdPattern == "" {
+		return true
+	}
+	ok, _ := path.Match(strings.ToLower(m.opt.IdPattern), strings.ToLower(id))
+	return ok
+}
+
+// listSourceIds lists the ids of the source through its search, narrowed by the literal prefix of the id pattern.
+func (m *mirror) listSourceIds(options []RequestOptionFunc) ([]string, error) {
+	term := m.opt.IdPattern
+	if i := strings.IndexAny(term, "*?[\\"); i >= 0 {
+		term = term[:i]
+	}
+	var ids []string
+	for skip := 0; ; skip += mirrorSearchPageSize {
+		result, _, err := m.source.SearchResource.search(&SearchOptions{
+			SearchTerm:        term,
+			IncludePrerelease: true,
+			Skip:              skip,
+			Take:              mirrorSearchPageSize,
+		}, options)
+		if err != nil {
+			return nil, fmt.Errorf("list source packages: %w", err)
+		}
+		for _, pkg := range result.Data {
+			ids = append(ids, pkg.PackageId)
+		}
+		if len(result.Data) < mirrorSearchPageSize || uint64(skip+len(result.Data)) >= result.TotalHits {
+			return ids, nil
+		}
+	}
+}
+
+// pendingVersions returns the versions of id to mirror: in the version range and missing from the destination.
+// The versions already mirrored according to the resume file are recorded as resumed.
+func (m *mirror) pendingVersions(id string, options []RequestOptionFunc) ([]*nugetVersion.Version, error) {
+	versions, _, err := m.source.FindPackageResource.ListAllVersions(id, options...)
+	if err != nil {
+		return nil, fmt.Errorf("list source versions: %w", err)
+	}
+	var existing map[string]bool
+	var pending []*nugetVersion.Version
+	for _, version := range versions {
+		if m.opt.VersionRange != nil && !m.opt.VersionRange.Satisfies(version) {
+			continue
+		}
+		normalized := version.ToNormalizedString()
+		if m.isResumed(id, normalized) {
+			m.record(&MirrorResult{Id: id, Version: normalized, Status: MirrorStatusResumed})
+			continue
+		}
+		if existing == nil {
+			if existing, err = m.destinationVersions(id, options); err != nil {
+				return nil, err
+			}
+		}
+		if existing[strings.ToLower(normalized)] {
+			m.record(&MirrorResult{Id: id, Version: normalized, Status: MirrorStatusSkipped})
+			continue
+		}
+		pending = append(pending, version)
+	}
+	return pending, nil
+}
+
+// destinationVersions returns the lowercase normalized versions of id the destination has.
+func (m *mirror) destinationVersions(id string, options []RequestOptionFunc) (map[string]bool, error) {
+	versions, _, err := m.destination.FindPackageResource.ListAllVersions(id, options...)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, fmt.Errorf("list destination versions: %w", err)
+	}
+	existing := make(map[string]bool, len(versions))
+	for _, version := range versions {
+		existing[strings.ToLower(version.ToNormalizedString())] = true
+	}
+	return existing, nil
+}
+
+// mirrorVersion downloads a package version and its symbols, then pushes them to the destination.
+func (m *mirror) mirrorVersion(job *mirrorJob, options []RequestOptionFunc) *MirrorResult {
+	version := job.version.ToNormalizedString()
+	result := &MirrorResult{Id: job.id, Version: version, Status: MirrorStatusFailed}
+	dir, err := os.MkdirTemp(m.workDir, "package")
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	defer os.RemoveAll(dir)
+
+	fileName := strings.ToLower(fmt.Sprintf("%s.%s", job.id, version))
+	nupkgPath := filepath.Join(dir, fileName+consts.PackageExtension)
+	if _, err = m.source.FindPackageResource.DownloadNupkgToFile(job.id, nupkgPath, &DownloadOptions{
+		Version: version,
+	}, options...); err != nil {
+		result.Err = fmt.Errorf("download package: %w", err)
+		return result
+	}
+	if m.opt.SymbolPackageURLTemplate != "" {
+		snupkgPath := filepath.Join(dir, fileName+consts.SnupkgExtension)
+		if result.Symbols, err = m.downloadSymbols(job.id, version, snupkgPath, options); err != nil {
+			result.Err = fmt.Errorf("download symbols: %w", err)
+			return result
+		}
+	}
+
+	resp, err := m.destination.UpdateResource.Push(nupkgPath, &PushPackageOptions{
+		SymbolSource:      m.opt.SymbolSource,
+		TimeoutInDuration: m.opt.PushTimeout,
+		IsSnupkg:          true,
+	}, options...)
+	if errors.Is(err, ErrPackageExists) {
+		// pushed by someone else since the destination was listed
+		result.Status, result.Symbols = MirrorStatusSkipped, false
+		return result
+	}
+	if err != nil {
+		if resp != nil {
+			err = fmt.Errorf("push package: %s: %w", resp.Status, err)
+		} else {
+			err = fmt.Errorf("push package: %w", err)
+		}
+		result.Err = err
+		return result
+	}
+	result.Status = MirrorStatusMirrored
+	if err = m.markResumed(job.id, version); err != nil {
+		result.Err = fmt.Errorf("record mirrored package: %w", err)
+	}
+	return result
+}
+
+// downloadSymbols downloads the symbol package of a package version to path.
+// Returns false when the source has none.
+func (m *mirror) downloadSymbols(id, version, path string, options []RequestOptionFunc) (bool, error) {
+	rawURL := strings.NewReplacer(
+		"{id}", url.PathEscape(strings.ToLower(id)),
+		"{version}", url.PathEscape(strings.ToLower(version)),
+	).Replace(m.opt.SymbolPackageURLTemplate)
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false, err
+	}
+	req, err := m.source.NewRequest(http.MethodGet, u.Path, u, nil, options)
+	if err != nil {
+		return false, err
+	}
+	resp, err := m.source.DoStream(req)
+	if errors.Is(err, ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	file, err := os.Create(path)
+	if err != nil {
+		return false, err
+	}
+	_, err = io.Copy(file, resp.Body)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return true, err
+}
+
+func (m *mirror) record(result *MirrorResult) {
+	m.mu.Lock()
+	m.report.Results = append(m.report.Results, result)
+	m.mu.Unlock()
+	if m.opt.OnResult != nil {
+		m.opt.OnResult(result)
+	}
+}
+
+// resumeKey identifies a package version in the resume file.
+func resumeKey(id, version string) string {
+	return strings.ToLower(id + "/" + version)
+}
+
+// openResumeFile reads the package versions mirrored by the previous runs and opens the file to append to it.
+func (m *mirror) openResumeFile() error {
+	m.resumed = make(map[string]bool)
+	if m.opt.ResumeFile == "" {
+		return nil
+	}
+	file, err := os.Open(m.opt.ResumeFile)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if err == nil {
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			if line := strings.TrimSpace(scanner.Text()); line != "" {
+				m.resumed[strings.ToLower(line)] = true
+			}
+		}
+		_ = file.Close()
+		if err = scanner.Err(); err != nil {
+			return err
+		}
+	}
+	if err = os.MkdirAll(filepath.Dir(m.opt.ResumeFile), 0o755); err != nil {
+		return err
+	}
+	m.resumeTo, err = os.OpenFile(m.opt.ResumeFile, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	return err
+}
+
+func (m *mirror) closeResumeFile() {
+	if m.resumeTo != nil {
+		_ = m.resumeTo.Close()
+	}
+}
+
+func (m *mirror) isResumed(id, version string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.resumed[resumeKey(id, version)]
+}
+
+func (m *mirror) markResumed(id, version string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := resumeKey(id, version)
+	m.resumed[key] = true
+	if m.resumeTo == nil {
+		return nil
+	}
+	_, err := m.resumeTo.WriteString(key + "\n")
+	return err
+}
@@ -0,0 +1,190 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package nuget
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	nugetVersion "github.com/huhouhua/go-nuget/version"
+)
+
+// testMirrorFeed a feed serving the flat container and accepting pushes, Ex: {"newtonsoft.json": {"13.0.1"}}.
+type testMirrorFeed struct {
+	client  *Client
+	mu      sync.Mutex
+	pushed  []string
+	symbols []string
+	queries []string
+}
+
+func newTestMirrorFeed(t *testing.T, versions map[string][]string) *testMirrorFeed {
+	mux, client := setup(t, index_V3)
+	feed := &testMirrorFeed{client: client}
+	base := client.getResourceURL(PackageBaseAddress).Path
+
+	mux.HandleFunc(base+"/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, base+"/"), "/")
+		id := parts[0]
+		if _, ok := versions[id]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if len(parts) == 2 && parts[1] == "index.json" {
+			fmt.Fprintf(w, `{"versions": ["%s"]}`, strings.Join(versions[id], `", "`))
+			return
+		}
+		fmt.Fprintf(w, "nupkg %s %s", id, parts[1])
+	})
+	mux.HandleFunc(client.getResourceURL(SearchQueryService).Path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		query := r.URL.Query().Get("q")
+		feed.queries = append(feed.queries, query)
+		var data []string
+		for id := range versions {
+			if strings.HasPrefix(id, strings.ToLower(query)) {
+				data = append(data, fmt.Sprintf(`{"id": %q, "version": %q}`, id, versions[id][0]))
+			}
+		}
+		fmt.Fprintf(w, `{"totalHits": %d, "data": [%s]}`, len(data), strings.Join(data, ","))
+	})
+	mux.HandleFunc("/symbol-packages/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		name := strings.TrimPrefix(r.URL.Path, "/symbol-packages/")
+		if !strings.HasPrefix(name, "newtonsoft.json.") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprintf(w, "snupkg %s", name)
+	})
+	upload := func(target *[]string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, http.MethodPut)
+			file, _, err := r.FormFile("package")
+			require.NoError(t, err)
+			content, err := io.ReadAll(file)
+			require.NoError(t, err)
+			if strings.Contains(string(content), "conflict") {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+			feed.mu.Lock()
+			*target = append(*target, string(content))
+			feed.mu.Unlock()
+		}
+	}
+	for _, p := range []string{client.getResourceURL(PackagePublish).Path, "/api/v2/package/"} {
+		mux.HandleFunc(p, upload(&feed.pushed))
+	}
+	for _, p := range []string{client.getResourceURL(SymbolPackagePublish).Path, "/api/v2/symbolpackage/"} {
+		mux.HandleFunc(p, upload(&feed.symbols))
+	}
+	return feed
+}
+
+func TestMirror(t *testing.T) {
+	source := newTestMirrorFeed(t, map[string][]string{
+		"newtonsoft.json": {"12.0.3", "13.0.1", "13.0.2-beta1"},
+		"serilog":         {"3.1.1", "4.0.0"},
+		"xunit":           {"2.9.0"},
+		"conflict":        {"1.0.0"},
+	})
+	destination := newTestMirrorFeed(t, map[string][]string{
+		"serilog": {"3.1.1"},
+	})
+	resumeFile := filepath.Join(t.TempDir(), "mirror", "resume.txt")
+	symbolsURL := fmt.Sprintf("%s://%s/symbol-packages", source.client.baseURL.Scheme, source.client.baseURL.Host)
+
+	var mu sync.Mutex
+	var notified int
+	report, err := Mirror(source.client, destination.client, &MirrorOptions{
+		PackageIds:               []string{"Newtonsoft.Json", "Serilog", "xunit", "conflict"},
+		IdPattern:                "[ncs]*",
+		SymbolPackageURLTemplate: symbolsURL + "/{id}.{version}.snupkg",
+		Parallelism:              3,
+		ResumeFile:               resumeFile,
+		OnResult: func(*MirrorResult) {
+			mu.Lock()
+			notified++
+			mu.Unlock()
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, report.Err())
+	require.Len(t, report.Results, 6)
+	require.Equal(t, 6, notified)
+	require.Equal(t, 4, report.Count(MirrorStatusMirrored))
+	require.Equal(t, 2, report.Count(MirrorStatusSkipped))
+	require.Contains(t, report.String(), "4 mirrored, 2 skipped, 0 resumed, 0 failed")
+
+	sort.Strings(destination.pushed)
+	require.Equal(t, []string{
+		"nupkg newtonsoft.json 12.0.3",
+		"nupkg newtonsoft.json 13.0.1",
+		"nupkg newtonsoft.json 13.0.2-beta1",
+		"nupkg serilog 4.0.0",
+	}, destination.pushed)
+	require.Len(t, destination.symbols, 3)
+	for _, result := range report.Results {
+		require.Equal(t, result.Id == "Newtonsoft.Json", result.Symbols, "%s %s", result.Id, result.Version)
+	}
+
+	// The second run resumes from the recorded versions, without downloading them again.
+	resume, err := os.ReadFile(resumeFile)
+	require.NoError(t, err)
+	require.Len(t, strings.Fields(string(resume)), 4)
+	versionRange, err := nugetVersion.ParseRange("[13.0.0, )")
+	require.NoError(t, err)
+	report, err = Mirror(source.client, destination.client, &MirrorOptions{
+		PackageIds:   []string{"Newtonsoft.Json"},
+		VersionRange: versionRange,
+		ResumeFile:   resumeFile,
+	})
+	require.NoError(t, err)
+	require.Len(t, report.Results, 2)
+	require.Equal(t, 2, report.Count(MirrorStatusResumed))
+	require.Len(t, destination.pushed, 4)
+}
+
+func TestMirror_Failures(t *testing.T) {
+	source := newTestMirrorFeed(t, map[string][]string{"xunit": {"2.9.0"}})
+	destination := newTestMirrorFeed(t, nil)
+
+	report, err := Mirror(source.client, destination.client, &MirrorOptions{PackageIds: []string{"missing", "xunit"}})
+	require.NoError(t, err)
+	require.Equal(t, 1, report.Count(MirrorStatusFailed))
+	require.Equal(t, 1, report.Count(MirrorStatusMirrored))
+	require.ErrorIs(t, report.Err(), ErrNotFound)
+	require.ErrorContains(t, report.Err(), "missing : list source versions")
+
+	_, err = Mirror(source.client, destination.client, &MirrorOptions{IdPattern: "["})
+	require.ErrorContains(t, err, "invalid id pattern")
+}
+
+func TestMirror_ListSourceIds(t *testing.T) {
+	source := newTestMirrorFeed(t, map[string][]string{
+		"serilog":               {"3.1.1"},
+		"serilog.sinks.console": {"6.0.0"},
+		"xunit":                 {"2.9.0"},
+	})
+	destination := newTestMirrorFeed(t, nil)
+
+	report, err := Mirror(source.client, destination.client, &MirrorOptions{IdPattern: "Serilog.*"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"Serilog."}, source.queries)
+	require.Len(t, report.Results, 1)
+	require.Equal(t, "serilog.sinks.console", report.Results[0].Id)
+	require.Equal(t, MirrorStatusMirrored, report.Results[0].Status)
+}
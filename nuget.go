@@ -12,6 +12,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"math"
 	"math/rand"
 	"mime/multipart"
@@ -72,12 +73,22 @@ type Client struct {
 	// apiKey used to make authenticated API calls.
 	apiKey string
 
+	// username and password used for basic authentication against private feeds.
+	username string
+	password string
+
 	// serviceURLs is used to store the service Resource of the NuGet API.
 	serviceURLs map[ServiceType]*url.URL
 
 	// Default request options applied to every request.
 	defaultRequestOptions []RequestOptionFunc
 
+	// cache stores the GET responses of the V3 resources, nil when caching is disabled.
+	cache HTTPCache
+
+	// cacheTTLs how long the cached responses of each resource are fresh.
+	cacheTTLs map[ServiceType]time.Duration
+
 	// User agent used when communicating with the NuGet API.
 	UserAgent string
 
@@ -92,6 +103,14 @@ type Client struct {
 	UpdateResource *PackageUpdateResource
 
 	IndexResource *ServiceResource
+
+	RepositorySignaturesResource *RepositorySignaturesResource
+
+	VulnerabilityInfoResource *VulnerabilityInfoResource
+
+	AutocompleteResource *AutocompleteResource
+
+	CatalogResource *CatalogResource
 }
 
 // RateLimiter describes the interface that all (custom) rate limiters must implement.
@@ -120,7 +139,7 @@ func NewOAuthClient(apiKey string, options ...ClientOptionFunc) (*Client, error)
 }
 
 func newClient(options ...ClientOptionFunc) (*Client, error) {
-	c := &Client{UserAgent: userAgent}
+	c := &Client{UserAgent: userAgent, cacheTTLs: maps.Clone(defaultCacheTTLs)}
 
 	// Configure the HTTP client.
 	c.client = &retryablehttp.Client{
@@ -159,6 +178,10 @@ func newClient(options ...ClientOptionFunc) (*Client, error) {
 	c.SearchResource = &PackageSearchResource{client: c}
 	c.UpdateResource = &PackageUpdateResource{client: c}
 	c.IndexResource = &ServiceResource{client: c}
+	c.RepositorySignaturesResource = &RepositorySignaturesResource{client: c}
+	c.VulnerabilityInfoResource = &VulnerabilityInfoResource{client: c}
+	c.AutocompleteResource = &AutocompleteResource{client: c}
+	c.CatalogResource = &CatalogResource{client: c}
 
 	c.serviceURLs = make(map[ServiceType]*url.URL)
 	err := c.loadResource()
@@ -444,6 +467,67 @@ func (c *Client) UploadRequest(
 // interface, the raw response body will be written to v, without attempting to
 // first decode it.
 func (c *Client) Do(req *retryablehttp.Request, v interface{}, decoder DecoderType) (*http.Response, error) {
+	// Serve the response from the http cache when it is fresh, or when the request is offline only.
+	policy, err := c.cachePolicy(req, decoder)
+	if err != nil {
+		return nil, err
+	}
+	var stale *HTTPCacheEntry
+	if policy != nil {
+		entry, fresh, err := policy.lookup()
+		if err != nil {
+			return nil, err
+		}
+		if fresh {
+			resp := entry.response(req.Request)
+			return resp, decodeResponse(resp, v, decoder)
+		}
+		if stale = entry; stale != nil {
+			policy.revalidate(req, stale)
+		}
+	}
+
+	resp, err := c.send(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+	defer io.Copy(io.Discard, resp.Body)
+
+	if policy != nil {
+		if resp, err = policy.store(resp, stale); err != nil {
+			return resp, err
+		}
+	}
+
+	if err = CheckResponse(resp); err != nil {
+		// Even though there was an error, we still return the response
+		// in case the caller wants to inspect it further.
+		return resp, err
+	}
+
+	return resp, decodeResponse(resp, v, decoder)
+}
+
+// DoStream sends an API request and returns the API response with its body left open,
+// the caller must close it. An API error is returned with the body already closed.
+// Use it for large downloads which must not be read into memory.
+func (c *Client) DoStream(req *retryablehttp.Request) (*http.Response, error) {
+	resp, err := c.send(req)
+	if err != nil {
+		return nil, err
+	}
+	if err = CheckResponse(resp); err != nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+		return resp, err
+	}
+	return resp, nil
+}
+
+// send waits for the rate limiter, sets the authentication headers and sends the request.
+func (c *Client) send(req *retryablehttp.Request) (*http.Response, error) {
 	// Wait will block until the limiter can obtain a new token.
 	if err := c.limiter.Wait(req.Context()); err != nil {
 		return nil, err
@@ -455,6 +539,10 @@ func (c *Client) Do(req *retryablehttp.Request, v interface{}, decoder DecoderTy
 		req.Header.Set("X-NuGet-ApiKey", c.apiKey)
 	}
 
+	if values := req.Header.Values("Authorization"); len(values) == 0 && c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
 	if values := req.Header.Values("X-NuGet-Client-Version"); len(values) == 0 {
 		req.Header.Set("X-NuGet-Client-Version", "4.1.0")
 	}
@@ -464,29 +552,24 @@ func (c *Client) Do(req *retryablehttp.Request, v interface{}, decoder DecoderTy
 		return nil, err
 	}
 
-	defer resp.Body.Close()
-	defer io.Copy(io.Discard, resp.Body)
-
 	// If not yet configured, try to configure the rate limiter
 	// using the response headers we just received. Fail silently
 	// so the limiter will remain disabled in case of an error.
 	c.configureLimiterOnce.Do(func() { c.configureLimiter(req.Context(), resp.Header) })
 
-	if err = CheckResponse(resp); err != nil {
-		// Even though there was an error, we still return the response
-		// in case the caller wants to inspect it further.
-		return resp, err
-	}
+	return resp, nil
+}
 
-	if v != nil {
-		if w, ok := v.(io.Writer); ok {
-			_, err = io.Copy(w, resp.Body)
-		} else {
-			err = decoder.invoke(resp.Body, v)
-		}
+// decodeResponse decodes the response body into v, or copies it when v is an io.Writer.
+func decodeResponse(resp *http.Response, v interface{}, decoder DecoderType) error {
+	if v == nil {
+		return nil
 	}
-
-	return resp, err
+	if w, ok := v.(io.Writer); ok {
+		_, err := io.Copy(w, resp.Body)
+		return err
+	}
+	return decoder.invoke(resp.Body, v)
 }
 
 // loadResource loads the service index resource.
@@ -583,7 +666,7 @@ func (e *ErrorResponse) Error() string {
 // CheckResponse checks the API response for errors, and returns them if present.
 func CheckResponse(r *http.Response) error {
 	switch r.StatusCode {
-	case 200, 201, 202, 204, 304:
+	case 200, 201, 202, 204, 206, 304:
 		return nil
 	case 404:
 		return ErrNotFound
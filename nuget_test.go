@@ -88,6 +88,16 @@ func mustWriteHTTPResponse(t *testing.T, w io.Writer, fixturePath string) {
 	}
 }
 
+// withTestServer sends a request to the test server of client, for the URLs read from the fixtures.
+func withTestServer(client *Client) RequestOptionFunc {
+	return func(request *retryablehttp.Request) error {
+		request.URL.Scheme = client.baseURL.Scheme
+		request.URL.Host = client.baseURL.Host
+		request.Host = client.baseURL.Host
+		return nil
+	}
+}
+
 func createFile(t *testing.T, path, data string) {
 	err := os.MkdirAll(filepath.Dir(path), 0755)
 	require.NoError(t, err)
@@ -633,6 +643,7 @@ func TestServiceUrls(t *testing.T) {
 						baseUrl.Scheme,
 						baseUrl.Host,
 					),
+					Catalog: fmt.Sprintf("%s://%s/v3/catalog0/index.json", baseUrl.Scheme, baseUrl.Host),
 				}
 			},
 			want: true,
@@ -659,6 +670,7 @@ func TestServiceUrls(t *testing.T) {
 					SymbolPackagePublish:      fmt.Sprintf("%s://%s/api/v2/symbol", sourceURL.Scheme, sourceURL.Host),
 					VulnerabilityInfo:         "",
 					OwnerDetailsURLTemplate:   "",
+					Catalog:                   "",
 				}
 			},
 			want: true,
@@ -0,0 +1,80 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package nuget
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ownerSearchPageSize how many packages each search request of ListOwnedPackages returns.
+const ownerSearchPageSize = 100
+
+// OwnerDetailsURL Returns the profile URL of owner on the source.
+func (p *PackageSearchResource) OwnerDetailsURL(owner string) (*url.URL, error) {
+	if strings.TrimSpace(owner) == "" {
+		return nil, fmt.Errorf("owner is empty")
+	}
+	urlTemplate := p.client.getResourceURL(OwnerDetailsURLTemplate)
+	if urlTemplate == nil {
+		return nil, fmt.Errorf("the source does not support owner details")
+	}
+	return ownerDetailsURL(urlTemplate, owner)
+}
+
+// ListOwnedPackages lists all the packages owned by owner with their latest version,
+// searching with the owner: filter page by page. Results the source returns for other owners are dropped.
+func (p *PackageSearchResource) ListOwnedPackages(
+	owner string,
+	includePrerelease bool,
+	options ...RequestOptionFunc,
+) ([]*PackageSearchMetadata, *http.Response, error) {
+	if strings.TrimSpace(owner) == "" {
+		return nil, nil, fmt.Errorf("owner is empty")
+	}
+	var (
+		packages []*PackageSearchMetadata
+		resp     *http.Response
+		seen     = make(map[string]bool)
+	)
+	for skip := 0; ; skip += ownerSearchPageSize {
+		var (
+			result *V3SearchResult
+			err    error
+		)
+		result, resp, err = p.search(&SearchOptions{
+			SearchTerm:        "owner:" + owner,
+			IncludePrerelease: includePrerelease,
+			Skip:              skip,
+			Take:              ownerSearchPageSize,
+		}, options)
+		if err != nil {
+			return nil, resp, err
+		}
+		for _, pkg := range result.Data {
+			id := strings.ToLower(pkg.PackageId)
+			if seen[id] || !isOwnedBy(pkg, owner) {
+				continue
+			}
+			seen[id] = true
+			packages = append(packages, pkg)
+		}
+		if len(result.Data) < ownerSearchPageSize || uint64(skip+len(result.Data)) >= result.TotalHits {
+			break
+		}
+	}
+	return packages, resp, nil
+}
+
+func isOwnedBy(pkg *PackageSearchMetadata, owner string) bool {
+	for _, o := range pkg.Owners {
+		if strings.EqualFold(strings.TrimSpace(o), owner) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package nuget
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageSearchResource_OwnerDetailsURL(t *testing.T) {
+	_, client := setup(t, index_V3)
+
+	u, err := client.SearchResource.OwnerDetailsURL("build service")
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		fmt.Sprintf("%s://%s/profiles/build%%20service?_src=template", client.baseURL.Scheme, client.baseURL.Host),
+		u.String(),
+	)
+
+	_, err = client.SearchResource.OwnerDetailsURL(" ")
+	require.EqualError(t, err, "owner is empty")
+
+	delete(client.serviceURLs, OwnerDetailsURLTemplate)
+	_, err = client.SearchResource.OwnerDetailsURL("kevin")
+	require.EqualError(t, err, "the source does not support owner details")
+}
+
+func TestPackageSearchResource_ListOwnedPackages(t *testing.T) {
+	mux, client := setup(t, index_V3)
+
+	requests := 0
+	baseURL := client.getResourceURL(SearchQueryService)
+	mux.HandleFunc(baseURL.Path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		requests++
+		query := r.URL.Query()
+		require.Equal(t, "owner:ci-bot", query.Get("q"))
+		require.Equal(t, "true", query.Get("prerelease"))
+		require.Equal(t, strconv.Itoa(ownerSearchPageSize), query.Get("take"))
+
+		// The first page is full, the second holds the last package and one of another owner.
+		if query.Get("skip") == "" {
+			mustWriteHTTPResponse(t, w, "testdata/owner_search_page0.json")
+			return
+		}
+		require.Equal(t, strconv.Itoa(ownerSearchPageSize), query.Get("skip"))
+		mustWriteHTTPResponse(t, w, "testdata/owner_search_page1.json")
+	})
+
+	packages, resp, err := client.SearchResource.ListOwnedPackages("ci-bot", true)
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.Equal(t, 2, requests)
+	require.Len(t, packages, ownerSearchPageSize+1)
+	require.Equal(t, "Pkg0", packages[0].PackageId)
+	require.Equal(t, "Last", packages[ownerSearchPageSize].PackageId)
+	require.Equal(t, "2.0.0-beta", packages[ownerSearchPageSize].Version)
+
+	_, _, err = client.SearchResource.ListOwnedPackages("", false)
+	require.EqualError(t, err, "owner is empty")
+}
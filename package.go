@@ -9,7 +9,7 @@ import (
 	"fmt"
 	"net/http"
 
-	"github.com/huhouhua/go-nuget/internal/meta"
+	"github.com/huhouhua/go-nuget/meta"
 
 	nugetVersion "github.com/huhouhua/go-nuget/version"
 )
@@ -75,11 +75,8 @@ func (f *FindPackageResource) GetDependencyInfo(
 	if err != nil {
 		return nil, resp, err
 	}
-	dependencyInfo := &meta.PackageDependencyInfo{
-		DependencyGroups:         make([]*meta.PackageDependencyGroup, 0),
-		FrameworkReferenceGroups: make([]*meta.FrameworkSpecificGroup, 0),
-	}
-	if err = meta.ConfigureDependencyInfo(dependencyInfo, nuspec); err != nil {
+	dependencyInfo, err := meta.NewPackageDependencyInfoFromNuspec(&nuspec)
+	if err != nil {
 		return nil, resp, err
 	}
 	return dependencyInfo, resp, nil
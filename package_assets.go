@@ -0,0 +1,377 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package nuget
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/huhouhua/go-nuget/framework"
+	"github.com/huhouhua/go-nuget/rid"
+)
+
+// AssetType Kind of package asset, named after the package folder it comes from
+type AssetType string
+
+const (
+	AssetTypeLib             AssetType = "lib"
+	AssetTypeRef             AssetType = "ref"
+	AssetTypeRuntimeLib      AssetType = "runtimes/lib"
+	AssetTypeNative          AssetType = "runtimes/native"
+	AssetTypeBuild           AssetType = "build"
+	AssetTypeBuildTransitive AssetType = "buildTransitive"
+	AssetTypeAnalyzers       AssetType = "analyzers"
+	AssetTypeContentFiles    AssetType = "contentFiles"
+)
+
+// emptyFolderMarker Placeholder file marking a folder as supported with no assets
+const emptyFolderMarker = "_._"
+
+// analyzerLanguages Language folders allowed under analyzers/dotnet
+var analyzerLanguages = []string{"cs", "vb", "fs"}
+
+// PackageAsset A single package entry classified by asset type
+type PackageAsset struct {
+	// Type Asset type of the entry
+	Type AssetType
+
+	// Path Entry name in the package
+	Path string
+
+	// TargetFramework Framework of the asset, Any for assets that apply to all frameworks
+	TargetFramework *framework.Framework
+
+	// RuntimeIdentifier Runtime of runtimes/{rid} assets
+	RuntimeIdentifier string
+
+	// Language Code language of contentFiles/{lang} and analyzers/dotnet/{lang} assets
+	Language string
+}
+
+// AssetGroup Assets of one type selected for a target framework and runtime
+type AssetGroup struct {
+	// Type Asset type of the items
+	Type AssetType
+
+	// TargetFramework Nearest framework of the group
+	TargetFramework *framework.Framework
+
+	// RuntimeIdentifier Runtime of the group, empty for runtime independent assets
+	RuntimeIdentifier string
+
+	// Language Code language of the items, empty when the items have no language or mix languages
+	Language string
+
+	// Items Entry names of the assets
+	Items []string
+
+	// HasEmptyFolder True if the group only contains the _._ marker
+	HasEmptyFolder bool
+}
+
+// PackageAssets The asset groups of a package that apply to a target framework and runtime
+type PackageAssets struct {
+	Framework         *framework.Framework
+	RuntimeIdentifier string
+
+	Lib             *AssetGroup
+	Ref             *AssetGroup
+	RuntimeLib      *AssetGroup
+	Native          *AssetGroup
+	Build           *AssetGroup
+	BuildTransitive *AssetGroup
+	Analyzers       *AssetGroup
+
+	// ContentFiles Nearest group for each language
+	ContentFiles []*AssetGroup
+}
+
+// CompileTimeAssemblies Assemblies used to compile against, ref/ wins over lib/.
+func (a *PackageAssets) CompileTimeAssemblies() []string {
+	if a.Ref != nil {
+		return a.Ref.Items
+	}
+	if a.Lib != nil {
+		return a.Lib.Items
+	}
+	return make([]string, 0)
+}
+
+// RuntimeAssemblies Assemblies copied to the output, runtimes/{rid}/lib wins over lib/.
+func (a *PackageAssets) RuntimeAssemblies() []string {
+	if a.RuntimeLib != nil {
+		return a.RuntimeLib.Items
+	}
+	if a.Lib != nil {
+		return a.Lib.Items
+	}
+	return make([]string, 0)
+}
+
+// GetAssets Classifies the package entries into asset types. Entries outside the asset folders are ignored.
+func (p *PackageArchiveReader) GetAssets() []*PackageAsset {
+	assets := make([]*PackageAsset, 0)
+	for _, file := range p.GetFiles() {
+		if asset := parsePackageAsset(file.Name); asset != nil {
+			assets = append(assets, asset)
+		}
+	}
+	return assets
+}
+
+// SelectAssets Returns the asset groups nearest to the target framework. Runtime specific assets are
+// selected when a runtime identifier is given, falling back through the default runtime graph.
+func (p *PackageArchiveReader) SelectAssets(fw *framework.Framework, runtimeIdentifier string) (*PackageAssets, error) {
+	return p.SelectAssetsWithRuntimeGraph(fw, runtimeIdentifier, rid.DefaultGraph())
+}
+
+// SelectAssetsWithRuntimeGraph Returns the asset groups nearest to the target framework, runtime specific
+// assets are selected from the runtime and its fallbacks in the given runtime graph.
+func (p *PackageArchiveReader) SelectAssetsWithRuntimeGraph(
+	fw *framework.Framework,
+	runtimeIdentifier string,
+	graph *rid.Graph,
+) (*PackageAssets, error) {
+	if fw == nil {
+		return nil, fmt.Errorf("framework is required")
+	}
+	if graph == nil {
+		return nil, fmt.Errorf("runtime graph is required")
+	}
+	return selectAssets(p.GetAssets(), fw, runtimeIdentifier, graph), nil
+}
+
+func selectAssets(
+	assets []*PackageAsset,
+	fw *framework.Framework,
+	runtimeIdentifier string,
+	graph *rid.Graph,
+) *PackageAssets {
+	byType := make(map[AssetType][]*PackageAsset)
+	for _, asset := range assets {
+		byType[asset.Type] = append(byType[asset.Type], asset)
+	}
+	result := &PackageAssets{
+		Framework:         fw,
+		RuntimeIdentifier: runtimeIdentifier,
+		Lib:               getNearestAssetGroup(byType[AssetTypeLib], fw),
+		Ref:               getNearestAssetGroup(byType[AssetTypeRef], fw),
+		Build:             getNearestAssetGroup(byType[AssetTypeBuild], fw),
+		BuildTransitive:   getNearestAssetGroup(byType[AssetTypeBuildTransitive], fw),
+		Analyzers:         getNearestAssetGroup(byType[AssetTypeAnalyzers], fw),
+		ContentFiles:      make([]*AssetGroup, 0),
+	}
+	if runtimeIdentifier != "" {
+		runtimes := graph.ExpandRuntime(runtimeIdentifier)
+		result.RuntimeLib = getNearestRuntimeAssetGroup(byType[AssetTypeRuntimeLib], fw, runtimes)
+		result.Native = getNearestRuntimeAssetGroup(byType[AssetTypeNative], fw, runtimes)
+	}
+
+	languages := make([]string, 0)
+	contentFiles := make(map[string][]*PackageAsset)
+	for _, asset := range byType[AssetTypeContentFiles] {
+		if _, ok := contentFiles[asset.Language]; !ok {
+			languages = append(languages, asset.Language)
+		}
+		contentFiles[asset.Language] = append(contentFiles[asset.Language], asset)
+	}
+	for _, language := range languages {
+		if group := getNearestAssetGroup(contentFiles[language], fw); group != nil {
+			result.ContentFiles = append(result.ContentFiles, group)
+		}
+	}
+	return result
+}
+
+// getNearestAssetGroup groups the assets by framework and returns the group nearest to the target framework.
+func getNearestAssetGroup(assets []*PackageAsset, fw *framework.Framework) *AssetGroup {
+	if len(assets) == 0 {
+		return nil
+	}
+	frameworks := make([]*framework.Framework, 0, len(assets))
+	for _, asset := range assets {
+		frameworks = append(frameworks, asset.TargetFramework)
+	}
+	nearest := framework.GetNearest(fw, frameworks)
+	if nearest == nil {
+		return nil
+	}
+	var group *AssetGroup
+	for _, asset := range assets {
+		if !asset.TargetFramework.Equals(nearest) {
+			continue
+		}
+		if group == nil {
+			group = &AssetGroup{
+				Type:              asset.Type,
+				TargetFramework:   nearest,
+				RuntimeIdentifier: asset.RuntimeIdentifier,
+				Language:          asset.Language,
+				Items:             make([]string, 0),
+			}
+		} else if group.Language != asset.Language {
+			// Ex: analyzers/dotnet/cs and analyzers/dotnet/vb in the same group
+			group.Language = ""
+		}
+		if path.Base(asset.Path) == emptyFolderMarker {
+			group.HasEmptyFolder = true
+			continue
+		}
+		group.Items = append(group.Items, asset.Path)
+	}
+	return group
+}
+
+// getNearestRuntimeAssetGroup returns the nearest group of the first runtime in the fallback chain that has one.
+func getNearestRuntimeAssetGroup(
+	assets []*PackageAsset,
+	fw *framework.Framework,
+	runtimes []string,
+) *AssetGroup {
+	for _, runtimeIdentifier := range runtimes {
+		filtered := make([]*PackageAsset, 0)
+		for _, asset := range assets {
+			if strings.EqualFold(asset.RuntimeIdentifier, runtimeIdentifier) {
+				filtered = append(filtered, asset)
+			}
+		}
+		if group := getNearestAssetGroup(filtered, fw); group != nil {
+			return group
+		}
+	}
+	return nil
+}
+
+// parsePackageAsset classifies a package entry, nil is returned for entries that are not assets.
+//
+//	lib/{tfm}/{any}
+//	ref/{tfm}/{any}
+//	runtimes/{rid}/lib/{tfm}/{any}
+//	runtimes/{rid}/native/{any}
+//	build/{tfm}/{any}.props|targets
+//	buildTransitive/{tfm}/{any}.props|targets
+//	analyzers/dotnet/{lang}/{any}.dll
+//	contentFiles/{lang}/{tfm}/{any}
+func parsePackageAsset(name string) *PackageAsset {
+	if strings.HasSuffix(name, "/") {
+		return nil
+	}
+	parts := strings.Split(name, "/")
+	if len(parts) < 2 {
+		return nil
+	}
+	switch strings.ToLower(parts[0]) {
+	case "lib":
+		return newFrameworkAsset(AssetTypeLib, name, parts[1:], framework.NewFramework(framework.IdentifierNet))
+	case "ref":
+		return newFrameworkAsset(AssetTypeRef, name, parts[1:], nil)
+	case "build":
+		if !isMSBuildFile(name) {
+			return nil
+		}
+		return newFrameworkAsset(AssetTypeBuild, name, parts[1:], framework.NewFramework(framework.Any))
+	case "buildtransitive":
+		if !isMSBuildFile(name) {
+			return nil
+		}
+		return newFrameworkAsset(AssetTypeBuildTransitive, name, parts[1:], framework.NewFramework(framework.Any))
+	case "analyzers":
+		return newAnalyzerAsset(name, parts[1:])
+	case "contentfiles":
+		// contentFiles/{lang}/{tfm}/{any}
+		if len(parts) < 4 {
+			return nil
+		}
+		asset := newFrameworkAsset(AssetTypeContentFiles, name, parts[2:], nil)
+		if asset != nil {
+			asset.Language = strings.ToLower(parts[1])
+		}
+		return asset
+	case "runtimes":
+		return newRuntimeAsset(name, parts[1:])
+	}
+	return nil
+}
+
+// newFrameworkAsset creates an asset from {tfm}/{any}, files directly in the root folder use the root framework.
+func newFrameworkAsset(
+	assetType AssetType,
+	name string,
+	parts []string,
+	rootFramework *framework.Framework,
+) *PackageAsset {
+	if len(parts) == 1 {
+		if rootFramework == nil {
+			return nil
+		}
+		return &PackageAsset{Type: assetType, Path: name, TargetFramework: rootFramework}
+	}
+	fw := parseAssetFramework(parts[0])
+	if fw == nil {
+		return nil
+	}
+	return &PackageAsset{Type: assetType, Path: name, TargetFramework: fw}
+}
+
+// newRuntimeAsset creates an asset from {rid}/lib/{tfm}/{any} or {rid}/native/{any}.
+func newRuntimeAsset(name string, parts []string) *PackageAsset {
+	if len(parts) < 3 {
+		return nil
+	}
+	runtimeIdentifier := parts[0]
+	var asset *PackageAsset
+	switch strings.ToLower(parts[1]) {
+	case "lib":
+		asset = newFrameworkAsset(AssetTypeRuntimeLib, name, parts[2:], nil)
+	case "native":
+		asset = &PackageAsset{Type: AssetTypeNative, Path: name, TargetFramework: framework.NewFramework(framework.Any)}
+	}
+	if asset != nil {
+		asset.RuntimeIdentifier = runtimeIdentifier
+	}
+	return asset
+}
+
+// newAnalyzerAsset creates an asset from dotnet/{lang}/{any}.dll or dotnet/{any}.dll.
+func newAnalyzerAsset(name string, parts []string) *PackageAsset {
+	if !strings.EqualFold(path.Ext(name), ".dll") {
+		return nil
+	}
+	asset := &PackageAsset{Type: AssetTypeAnalyzers, Path: name, TargetFramework: framework.NewFramework(framework.Any)}
+	if len(parts) > 2 && strings.EqualFold(parts[0], "dotnet") {
+		for _, language := range analyzerLanguages {
+			if strings.EqualFold(parts[1], language) {
+				asset.Language = language
+			}
+		}
+	}
+	return asset
+}
+
+// parseAssetFramework parses a framework folder, folder names are escaped in the package. Ex: portable-net45%2Bwin8
+func parseAssetFramework(folder string) *framework.Framework {
+	if unescaped, err := url.PathUnescape(folder); err == nil {
+		folder = unescaped
+	}
+	if strings.EqualFold(folder, "any") {
+		return framework.NewFramework(framework.Any)
+	}
+	fw, err := framework.ParseFolderFromDefault(folder)
+	if err != nil || fw.IsUnsupported() {
+		return nil
+	}
+	return fw
+}
+
+// isMSBuildFile True for .props and .targets files, and the _._ marker of an empty build folder.
+func isMSBuildFile(name string) bool {
+	if path.Base(name) == emptyFolderMarker {
+		return true
+	}
+	ext := strings.ToLower(path.Ext(name))
+	return ext == ".props" || ext == ".targets"
+}
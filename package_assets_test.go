@@ -0,0 +1,226 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package nuget
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/huhouhua/go-nuget/framework"
+	"github.com/huhouhua/go-nuget/rid"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPackageArchiveReader(t *testing.T, files ...string) *PackageArchiveReader {
+	t.Helper()
+	buf := &bytes.Buffer{}
+	w := zip.NewWriter(buf)
+	for _, name := range append([]string{"test.nuspec"}, files...) {
+		f, err := w.Create(name)
+		require.NoError(t, err)
+		if !strings.HasSuffix(name, "/") {
+			_, err = f.Write([]byte(name))
+			require.NoError(t, err)
+		}
+	}
+	require.NoError(t, w.Close())
+
+	reader, err := NewPackageArchiveReader(buf)
+	require.NoError(t, err)
+	return reader
+}
+
+func TestPackageArchiveReader_GetAssets(t *testing.T) {
+	reader := newTestPackageArchiveReader(t,
+		"lib/net45/a.dll",
+		"lib/a.dll",
+		"Ref/netstandard2.0/a.dll",
+		"runtimes/win-x64/lib/net6.0/a.dll",
+		"runtimes/win-x64/native/a.so",
+		"build/test.props",
+		"build/net6.0/test.targets",
+		"build/readme.txt",
+		"buildTransitive/net6.0/test.targets",
+		"analyzers/dotnet/cs/a.dll",
+		"analyzers/dotnet/a.xml",
+		"contentFiles/cs/any/a.cs",
+		"lib/portable-net45%2Bwin8/a.dll",
+		"lib/unknown-folder/a.dll",
+		"content/a.txt",
+		"lib/",
+	)
+	type asset struct {
+		Type      AssetType
+		Path      string
+		Framework string
+		Rid       string
+		Language  string
+	}
+	actual := make([]asset, 0)
+	for _, a := range reader.GetAssets() {
+		fw, err := a.TargetFramework.GetShortFolderName()
+		require.NoError(t, err)
+		actual = append(actual, asset{a.Type, a.Path, fw, a.RuntimeIdentifier, a.Language})
+	}
+	want := []asset{
+		{AssetTypeLib, "lib/net45/a.dll", "net45", "", ""},
+		{AssetTypeLib, "lib/a.dll", "net", "", ""},
+		{AssetTypeRef, "Ref/netstandard2.0/a.dll", "netstandard2.0", "", ""},
+		{AssetTypeRuntimeLib, "runtimes/win-x64/lib/net6.0/a.dll", "net6.0", "win-x64", ""},
+		{AssetTypeNative, "runtimes/win-x64/native/a.so", "any", "win-x64", ""},
+		{AssetTypeBuild, "build/test.props", "any", "", ""},
+		{AssetTypeBuild, "build/net6.0/test.targets", "net6.0", "", ""},
+		{AssetTypeBuildTransitive, "buildTransitive/net6.0/test.targets", "net6.0", "", ""},
+		{AssetTypeAnalyzers, "analyzers/dotnet/cs/a.dll", "any", "", "cs"},
+		{AssetTypeContentFiles, "contentFiles/cs/any/a.cs", "any", "", "cs"},
+		{AssetTypeLib, "lib/portable-net45%2Bwin8/a.dll", "portable-net45+win8", "", ""},
+	}
+	require.Equal(t, want, actual)
+}
+
+func TestPackageArchiveReader_SelectAssets(t *testing.T) {
+	reader := newTestPackageArchiveReader(t,
+		"lib/net45/a.dll",
+		"lib/net45/a.xml",
+		"lib/netstandard2.0/a.dll",
+		"lib/net6.0/a.dll",
+		"ref/net6.0/a.dll",
+		"runtimes/win-x64/lib/net6.0/a.dll",
+		"runtimes/win-x64/native/e_sqlite3.dll",
+		"runtimes/linux-x64/native/libe_sqlite3.so",
+		"build/test.props",
+		"build/net6.0/test.targets",
+		"buildTransitive/net45/_._",
+		"analyzers/dotnet/cs/a.dll",
+		"analyzers/dotnet/vb/a.dll",
+		"contentFiles/cs/net45/a.cs",
+		"contentFiles/cs/netstandard2.0/a.cs",
+		"contentFiles/any/any/a.txt",
+	)
+
+	t.Run("net48", func(t *testing.T) {
+		assets, err := reader.SelectAssets(framework.Net48, "")
+		require.NoError(t, err)
+		require.Equal(t, []string{"lib/net45/a.dll", "lib/net45/a.xml"}, assets.Lib.Items)
+		require.Nil(t, assets.Ref)
+		require.Nil(t, assets.RuntimeLib)
+		require.Nil(t, assets.Native)
+		require.Equal(t, []string{"build/test.props"}, assets.Build.Items)
+		require.True(t, assets.BuildTransitive.HasEmptyFolder)
+		require.Empty(t, assets.BuildTransitive.Items)
+		require.Equal(t, []string{"analyzers/dotnet/cs/a.dll", "analyzers/dotnet/vb/a.dll"}, assets.Analyzers.Items)
+		require.Empty(t, assets.Analyzers.Language)
+		require.Len(t, assets.ContentFiles, 2)
+		require.Equal(t, "cs", assets.ContentFiles[0].Language)
+		require.Equal(t, []string{"contentFiles/cs/net45/a.cs"}, assets.ContentFiles[0].Items)
+		require.Equal(t, "any", assets.ContentFiles[1].Language)
+		require.Equal(t, []string{"lib/net45/a.dll", "lib/net45/a.xml"}, assets.CompileTimeAssemblies())
+		require.Equal(t, []string{"lib/net45/a.dll", "lib/net45/a.xml"}, assets.RuntimeAssemblies())
+	})
+
+	t.Run("net8.0 on win-x64", func(t *testing.T) {
+		assets, err := reader.SelectAssets(framework.Net80, "win-x64")
+		require.NoError(t, err)
+		require.True(t, framework.Net60.Equals(assets.Lib.TargetFramework))
+		require.Equal(t, []string{"ref/net6.0/a.dll"}, assets.CompileTimeAssemblies())
+		require.Equal(t, []string{"runtimes/win-x64/lib/net6.0/a.dll"}, assets.RuntimeAssemblies())
+		require.Equal(t, "win-x64", assets.RuntimeLib.RuntimeIdentifier)
+		require.Equal(t, []string{"runtimes/win-x64/native/e_sqlite3.dll"}, assets.Native.Items)
+		require.Equal(t, []string{"build/net6.0/test.targets"}, assets.Build.Items)
+		require.Nil(t, assets.BuildTransitive)
+		require.Equal(t, []string{"contentFiles/cs/netstandard2.0/a.cs"}, assets.ContentFiles[0].Items)
+	})
+
+	t.Run("netstandard2.1 on linux-x64", func(t *testing.T) {
+		assets, err := reader.SelectAssets(framework.NetStandard21, "linux-x64")
+		require.NoError(t, err)
+		require.Equal(t, []string{"lib/netstandard2.0/a.dll"}, assets.RuntimeAssemblies())
+		require.Nil(t, assets.RuntimeLib)
+		require.Equal(t, []string{"runtimes/linux-x64/native/libe_sqlite3.so"}, assets.Native.Items)
+	})
+
+	t.Run("no compatible lib", func(t *testing.T) {
+		assets, err := reader.SelectAssets(framework.Net4, "")
+		require.NoError(t, err)
+		require.Nil(t, assets.Lib)
+		require.Empty(t, assets.CompileTimeAssemblies())
+		require.Empty(t, assets.RuntimeAssemblies())
+	})
+
+	_, err := reader.SelectAssets(nil, "")
+	require.Equal(t, errors.New("framework is required"), err)
+
+	_, err = reader.SelectAssetsWithRuntimeGraph(framework.Net80, "linux-x64", nil)
+	require.Equal(t, errors.New("runtime graph is required"), err)
+}
+
+func TestPackageArchiveReader_SelectAssets_AnalyzerLanguage(t *testing.T) {
+	reader := newTestPackageArchiveReader(t,
+		"analyzers/dotnet/cs/a.dll",
+		"analyzers/dotnet/cs/b.dll",
+	)
+	assets, err := reader.SelectAssets(framework.Net80, "")
+	require.NoError(t, err)
+	require.Equal(t, "cs", assets.Analyzers.Language)
+	require.Equal(t, []string{"analyzers/dotnet/cs/a.dll", "analyzers/dotnet/cs/b.dll"}, assets.Analyzers.Items)
+}
+
+func TestPackageArchiveReader_SelectAssets_RuntimeFallback(t *testing.T) {
+	reader := newTestPackageArchiveReader(t,
+		"lib/net6.0/a.dll",
+		"runtimes/unix/lib/net6.0/a.dll",
+		"runtimes/linux-x64/native/libe_sqlite3.so",
+		"runtimes/linux-musl-x64/native/libe_sqlite3.so",
+		"runtimes/win/native/e_sqlite3.dll",
+	)
+	tests := []struct {
+		runtimeIdentifier string
+		runtimeLib        []string
+		native            []string
+	}{
+		{
+			runtimeIdentifier: "linux-musl-x64",
+			runtimeLib:        []string{"runtimes/unix/lib/net6.0/a.dll"},
+			native:            []string{"runtimes/linux-musl-x64/native/libe_sqlite3.so"},
+		},
+		{
+			runtimeIdentifier: "linux-musl-arm64",
+			runtimeLib:        []string{"runtimes/unix/lib/net6.0/a.dll"},
+			native:            nil,
+		},
+		{
+			runtimeIdentifier: "linux-x64",
+			runtimeLib:        []string{"runtimes/unix/lib/net6.0/a.dll"},
+			native:            []string{"runtimes/linux-x64/native/libe_sqlite3.so"},
+		},
+		{
+			runtimeIdentifier: "win-x64",
+			runtimeLib:        []string{"lib/net6.0/a.dll"},
+			native:            []string{"runtimes/win/native/e_sqlite3.dll"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.runtimeIdentifier, func(t *testing.T) {
+			assets, err := reader.SelectAssets(framework.Net80, tt.runtimeIdentifier)
+			require.NoError(t, err)
+			require.Equal(t, tt.runtimeLib, assets.RuntimeAssemblies())
+			if tt.native == nil {
+				require.Nil(t, assets.Native)
+				return
+			}
+			require.Equal(t, tt.native, assets.Native.Items)
+		})
+	}
+
+	// a custom graph where alpine-x64 falls back to linux-musl-x64
+	graph := rid.DefaultGraph().Merge(rid.NewGraph(rid.NewDescription("alpine-x64", "linux-musl-x64")))
+	assets, err := reader.SelectAssetsWithRuntimeGraph(framework.Net80, "alpine-x64", graph)
+	require.NoError(t, err)
+	require.Equal(t, []string{"runtimes/linux-musl-x64/native/libe_sqlite3.so"}, assets.Native.Items)
+}
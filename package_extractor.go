@@ -0,0 +1,176 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package nuget
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/huhouhua/go-nuget/internal/consts"
+)
+
+// PackageSaveMode What ExtractTo writes to disk, modes combine with |.
+type PackageSaveMode int
+
+const (
+	PackageSaveModeNone PackageSaveMode = 0
+
+	// PackageSaveModeNuspec writes the nuspec as {id}.nuspec, the id in lowercase.
+	PackageSaveModeNuspec PackageSaveMode = 1 << 0
+
+	// PackageSaveModeNupkg writes the package itself as {id}.{version}.nupkg, in lowercase.
+	PackageSaveModeNupkg PackageSaveMode = 1 << 1
+
+	// PackageSaveModeFiles writes the package files, the nuspec and the OPC parts excluded.
+	PackageSaveModeFiles PackageSaveMode = 1 << 2
+
+	// PackageSaveModeDefaultV2 The packages.config layout.
+	PackageSaveModeDefaultV2 = PackageSaveModeNupkg | PackageSaveModeFiles
+
+	// PackageSaveModeDefaultV3 The PackageReference layout of the global packages folder.
+	PackageSaveModeDefaultV3 = PackageSaveModeNuspec | PackageSaveModeNupkg | PackageSaveModeFiles
+)
+
+// Has True when mode includes all the modes of flag.
+func (m PackageSaveMode) Has(flag PackageSaveMode) bool {
+	return m&flag == flag
+}
+
+// ExtractTo extracts the package to dir according to mode and returns the paths written.
+// The OPC parts ([Content_Types].xml, _rels/ and package/services/metadata/) are never extracted.
+// Entries with an absolute name or escaping dir are rejected before anything is written,
+// and the files keep the modification time of their entry.
+func (p *PackageArchiveReader) ExtractTo(dir string, mode PackageSaveMode) ([]string, error) {
+	nuspec, err := p.Nuspec()
+	if err != nil {
+		return nil, err
+	}
+	identity, err := nuspec.GetIdentity()
+	if err != nil {
+		return nil, err
+	}
+	if dir, err = filepath.Abs(dir); err != nil {
+		return nil, err
+	}
+
+	type extraction struct {
+		file   *zip.File
+		target string
+	}
+	var extractions []*extraction
+	if mode.Has(PackageSaveModeNuspec) {
+		target := filepath.Join(dir, strings.ToLower(identity.Id)+consts.NuspecExtension)
+		extractions = append(extractions, &extraction{file: p.nuspecFile, target: target})
+	}
+	if mode.Has(PackageSaveModeFiles) {
+		for _, file := range p.archive.File {
+			name, ok := extractedFileName(file.Name)
+			if !ok {
+				continue
+			}
+			target, err := safeExtractPath(dir, name)
+			if err != nil {
+				return nil, err
+			}
+			extractions = append(extractions, &extraction{file: file, target: target})
+		}
+	}
+
+	var paths []string
+	for _, e := range extractions {
+		if err = extractFile(e.file, e.target); err != nil {
+			return paths, err
+		}
+		paths = append(paths, e.target)
+	}
+	if mode.Has(PackageSaveModeNupkg) {
+		target := filepath.Join(dir, packageFileName(identity.Id, identity.Version))
+		if err = os.MkdirAll(dir, 0755); err != nil {
+			return paths, err
+		}
+		if err = writeFile(target, io.NewSectionReader(p.source, 0, p.size)); err != nil {
+			return paths, err
+		}
+		paths = append(paths, target)
+	}
+	return paths, nil
+}
+
+// extractedFileName returns the path a nupkg entry is extracted to, false for the entries
+// that only describe the package: the nuspec, [Content_Types].xml, _rels and the package core properties.
+func extractedFileName(name string) (string, bool) {
+	if strings.HasSuffix(name, "/") {
+		return "", false
+	}
+	lower := strings.ToLower(name)
+	switch {
+	case lower == "[content_types].xml",
+		strings.HasPrefix(lower, "_rels/"),
+		strings.HasPrefix(lower, "package/services/metadata/"),
+		!strings.Contains(lower, "/") && strings.HasSuffix(lower, consts.NuspecExtension):
+		return "", false
+	}
+	if unescaped, err := url.PathUnescape(name); err == nil {
+		name = unescaped
+	}
+	return name, true
+}
+
+// safeExtractPath returns the path of the entry name under dir, an error for the
+// absolute names and the names escaping dir (zip slip).
+func safeExtractPath(dir, name string) (string, error) {
+	slashed := strings.ReplaceAll(name, `\`, "/")
+	if path.IsAbs(slashed) || filepath.IsAbs(name) || filepath.VolumeName(name) != "" ||
+		(len(slashed) > 1 && slashed[1] == ':') {
+		return "", fmt.Errorf("invalid file path %s: absolute path", name)
+	}
+	cleaned := path.Clean(slashed)
+	if cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", fmt.Errorf("invalid file path %s: outside of the extraction directory", name)
+	}
+	target := filepath.Join(dir, filepath.FromSlash(cleaned))
+	if !strings.HasPrefix(target, dir+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid file path %s: outside of the extraction directory", name)
+	}
+	return target, nil
+}
+
+// extractFile writes the entry to target and sets its modification time.
+func extractFile(file *zip.File, target string) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	src, err := file.Open()
+	if err != nil {
+		return err
+	}
+	err = writeFile(target, src)
+	_ = src.Close()
+	if err != nil {
+		return err
+	}
+	if modified := file.Modified; !modified.IsZero() {
+		return os.Chtimes(target, modified, modified)
+	}
+	return nil
+}
+
+func writeFile(name string, r io.Reader) error {
+	file, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(file, r); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
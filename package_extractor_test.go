@@ -0,0 +1,165 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package nuget
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// extractedFiles returns the files under dir, relative and slash separated.
+func extractedFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	var files []string
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		require.NoError(t, err)
+		if !info.IsDir() {
+			rel, err := filepath.Rel(dir, path)
+			require.NoError(t, err)
+			files = append(files, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	return files
+}
+
+func TestPackageArchiveReader_ExtractTo(t *testing.T) {
+	data := buildTestPackage(t, "Contoso.Lib", "1.0.0", nil,
+		"lib/net8.0/Contoso.Lib.dll",
+		"content/a%20b.txt",
+		"[Content_Types].xml",
+		"_rels/.rels",
+		"package/services/metadata/core-properties/abc.psmdcp",
+	)
+	tests := []struct {
+		name  string
+		mode  PackageSaveMode
+		files []string
+	}{
+		{
+			name: "nuspec",
+			mode: PackageSaveModeNuspec,
+			files: []string{
+				"contoso.lib.nuspec",
+			},
+		},
+		{
+			name: "nupkg",
+			mode: PackageSaveModeNupkg,
+			files: []string{
+				"contoso.lib.1.0.0.nupkg",
+			},
+		},
+		{
+			name: "files",
+			mode: PackageSaveModeFiles,
+			files: []string{
+				"content/a b.txt",
+				"lib/net8.0/Contoso.Lib.dll",
+			},
+		},
+		{
+			name: "default v2",
+			mode: PackageSaveModeDefaultV2,
+			files: []string{
+				"contoso.lib.1.0.0.nupkg",
+				"content/a b.txt",
+				"lib/net8.0/Contoso.Lib.dll",
+			},
+		},
+		{
+			name: "default v3",
+			mode: PackageSaveModeDefaultV3,
+			files: []string{
+				"contoso.lib.nuspec",
+				"contoso.lib.1.0.0.nupkg",
+				"content/a b.txt",
+				"lib/net8.0/Contoso.Lib.dll",
+			},
+		},
+		{
+			name: "none",
+			mode: PackageSaveModeNone,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader, err := NewPackageArchiveReader(bytes.NewReader(data))
+			require.NoError(t, err)
+			dir := filepath.Join(t.TempDir(), "out")
+
+			paths, err := reader.ExtractTo(dir, tt.mode)
+			require.NoError(t, err)
+			require.Len(t, paths, len(tt.files))
+			if len(tt.files) > 0 {
+				require.ElementsMatch(t, tt.files, extractedFiles(t, dir))
+			}
+		})
+	}
+
+	reader, err := NewPackageArchiveReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	dir := t.TempDir()
+	_, err = reader.ExtractTo(dir, PackageSaveModeNupkg|PackageSaveModeFiles)
+	require.NoError(t, err)
+	nupkg, err := os.ReadFile(filepath.Join(dir, "contoso.lib.1.0.0.nupkg"))
+	require.NoError(t, err)
+	require.Equal(t, data, nupkg)
+	content, err := os.ReadFile(filepath.Join(dir, "lib", "net8.0", "Contoso.Lib.dll"))
+	require.NoError(t, err)
+	require.Equal(t, "lib/net8.0/Contoso.Lib.dll", string(content))
+}
+
+func TestPackageArchiveReader_ExtractTo_Timestamps(t *testing.T) {
+	reader, err := OpenPackageArchiveReader("testdata/test.1.0.0.nupkg")
+	require.NoError(t, err)
+	defer reader.Close()
+
+	dir := t.TempDir()
+	_, err = reader.ExtractTo(dir, PackageSaveModeFiles)
+	require.NoError(t, err)
+	for _, file := range reader.GetFilesFromDir("testLibrary/lib") {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+		info, err := os.Stat(filepath.Join(dir, filepath.FromSlash(file.Name)))
+		require.NoError(t, err)
+		require.True(t, file.Modified.Equal(info.ModTime()), "%s modified %v, want %v", file.Name, info.ModTime(), file.Modified)
+	}
+}
+
+func TestPackageArchiveReader_ExtractTo_RejectsUnsafeEntries(t *testing.T) {
+	for _, name := range []string{
+		"../evil.txt",
+		"lib/../../evil.txt",
+		`..\evil.txt`,
+		"/etc/evil.txt",
+		`\evil.txt`,
+		`C:\evil.txt`,
+		"c:/evil.txt",
+		"lib/%2E%2E/%2E%2E/evil.txt",
+	} {
+		t.Run(name, func(t *testing.T) {
+			data := buildTestPackage(t, "Contoso.Lib", "1.0.0", nil, "lib/net8.0/Contoso.Lib.dll", name)
+			reader, err := NewPackageArchiveReader(bytes.NewReader(data))
+			require.NoError(t, err)
+
+			root := t.TempDir()
+			dir := filepath.Join(root, "a", "out")
+			_, err = reader.ExtractTo(dir, PackageSaveModeDefaultV3)
+			require.ErrorContains(t, err, "invalid file path")
+
+			// Nothing is written when an entry is rejected.
+			require.NoDirExists(t, dir)
+			require.NoFileExists(t, filepath.Join(root, "evil.txt"))
+			require.NoFileExists(t, filepath.Join(root, "a", "evil.txt"))
+		})
+	}
+}
@@ -9,46 +9,86 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"os"
 	"strings"
 	"sync"
 
 	"github.com/huhouhua/go-nuget/internal/consts"
-	"github.com/huhouhua/go-nuget/internal/meta"
+	"github.com/huhouhua/go-nuget/meta"
 )
 
+// PackageArchiveReader reads the nuspec and the files of a nupkg. Readers opened over a file or an
+// io.ReaderAt read the archive lazily, only the entries accessed are read. Close releases the file.
 type PackageArchiveReader struct {
 	nuspec     *meta.Nuspec
-	buf        *bytes.Buffer
+	nuspecErr  error
 	archive    *zip.Reader
-	nuspecFile io.ReadCloser
+	nuspecFile *zip.File
+	source     io.ReaderAt
+	size       int64
+	closer     io.Closer
 	once       sync.Once
 }
 
+// NewPackageArchiveReader reads the whole package stream into memory, prefer OpenPackageArchiveReader
+// or NewPackageArchiveReaderAt for large packages.
 func NewPackageArchiveReader(reader io.Reader) (*PackageArchiveReader, error) {
-	p := &PackageArchiveReader{
-		buf: &bytes.Buffer{},
+	buf := &bytes.Buffer{}
+	if _, err := buf.ReadFrom(reader); err != nil {
+		return nil, err
+	}
+	return NewPackageArchiveReaderAt(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+}
+
+// NewPackageArchiveReaderAt returns a reader over the package of the given size, the archive is read lazily.
+func NewPackageArchiveReaderAt(reader io.ReaderAt, size int64) (*PackageArchiveReader, error) {
+	p := &PackageArchiveReader{}
+	if err := p.parse(reader, size); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// NewPackageArchiveReaderFromFile returns a reader over the package file, the archive is read lazily.
+// The file is closed by Close.
+func NewPackageArchiveReaderFromFile(file *os.File) (*PackageArchiveReader, error) {
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
 	}
-	if _, err := p.buf.ReadFrom(reader); err != nil {
+	p, err := NewPackageArchiveReaderAt(file, info.Size())
+	if err != nil {
 		return nil, err
 	}
-	if err := p.parse(); err != nil {
+	p.closer = file
+	return p, nil
+}
+
+// OpenPackageArchiveReader opens the package at path, the archive is read lazily.
+// The caller must Close the reader.
+func OpenPackageArchiveReader(path string) (*PackageArchiveReader, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	p, err := NewPackageArchiveReaderFromFile(file)
+	if err != nil {
+		_ = file.Close()
 		return nil, err
 	}
 	return p, nil
 }
 
-func (p *PackageArchiveReader) parse() error {
-	if p.buf == nil || p.buf.Len() == 0 {
+func (p *PackageArchiveReader) parse(reader io.ReaderAt, size int64) error {
+	if size == 0 {
 		return fmt.Errorf("package is empty")
 	}
-	// Create a zip reader from the buffer
-	r := p.buf.Bytes()
-	archive := bytes.NewReader(r)
 	var err error
-	if p.archive, err = zip.NewReader(archive, int64(len(r))); err != nil {
+	if p.archive, err = zip.NewReader(reader, size); err != nil {
 		return err
 	}
-	// Extract the nuspec file
+	p.source, p.size = reader, size
+	// Locate the nuspec file, it is read on first access
 	if p.nuspecFile, err = p.findNuspecFile(); err != nil {
 		return err
 	}
@@ -56,19 +96,26 @@ func (p *PackageArchiveReader) parse() error {
 }
 
 func (p *PackageArchiveReader) Nuspec() (*meta.Nuspec, error) {
-	if p.nuspec != nil {
-		return p.nuspec, nil
-	}
-	var err error
 	p.once.Do(func() {
+		var file io.ReadCloser
+		if file, p.nuspecErr = p.nuspecFile.Open(); p.nuspecErr != nil {
+			return
+		}
 		defer func() {
-			_ = p.nuspecFile.Close()
+			_ = file.Close()
 		}()
 		// Reader the XML content into the Nuspec struct
-		p.nuspec, err = meta.FromReader(p.nuspecFile)
+		p.nuspec, p.nuspecErr = meta.FromReader(file)
 	})
+	return p.nuspec, p.nuspecErr
+}
 
-	return p.nuspec, err
+// Close releases the package file, a no-op for readers over memory or an io.ReaderAt.
+func (p *PackageArchiveReader) Close() error {
+	if p.closer == nil {
+		return nil
+	}
+	return p.closer.Close()
 }
 
 func (p *PackageArchiveReader) GetFiles() []*zip.File {
@@ -86,14 +133,10 @@ func (p *PackageArchiveReader) GetFilesFromDir(folder string) []*zip.File {
 	return files
 }
 
-func (p *PackageArchiveReader) findNuspecFile() (io.ReadCloser, error) {
+func (p *PackageArchiveReader) findNuspecFile() (*zip.File, error) {
 	for _, file := range p.archive.File {
 		if strings.HasSuffix(file.Name, consts.NuspecExtension) {
-			if nuspecFile, err := file.Open(); err != nil {
-				return nil, err
-			} else {
-				return nuspecFile, nil
-			}
+			return file, nil
 		}
 	}
 	return nil, fmt.Errorf("no .nuspec file found in the .nupkg archive")
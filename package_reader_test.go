@@ -14,7 +14,7 @@ import (
 	"syscall"
 	"testing"
 
-	"github.com/huhouhua/go-nuget/internal/meta"
+	"github.com/huhouhua/go-nuget/meta"
 
 	"github.com/stretchr/testify/require"
 )
@@ -348,3 +348,59 @@ func TestReaderNupkg_ErrorScenarios(t *testing.T) {
 		})
 	}
 }
+
+// countingReaderAt counts the bytes read through it.
+type countingReaderAt struct {
+	io.ReaderAt
+	read int64
+}
+
+func (c *countingReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	n, err := c.ReaderAt.ReadAt(p, off)
+	c.read += int64(n)
+	return n, err
+}
+
+func TestNewPackageArchiveReaderAt(t *testing.T) {
+	data, err := os.ReadFile("testdata/test.1.0.0.nupkg")
+	require.NoError(t, err)
+
+	counter := &countingReaderAt{ReaderAt: bytes.NewReader(data)}
+	reader, err := NewPackageArchiveReaderAt(counter, int64(len(data)))
+	require.NoError(t, err)
+	nuspec, err := reader.Nuspec()
+	require.NoError(t, err)
+	require.Equal(t, "MyTestLibrary", nuspec.GetId())
+	require.NoError(t, reader.Close())
+
+	// Only the central directory and the nuspec are read, not the assemblies.
+	require.Less(t, counter.read, int64(len(data))/2)
+
+	_, err = NewPackageArchiveReaderAt(bytes.NewReader(nil), 0)
+	require.Equal(t, errors.New("package is empty"), err)
+}
+
+func TestOpenPackageArchiveReader(t *testing.T) {
+	reader, err := OpenPackageArchiveReader("testdata/test.1.0.0.nupkg")
+	require.NoError(t, err)
+	nuspec, err := reader.Nuspec()
+	require.NoError(t, err)
+	require.Equal(t, "MyTestLibrary", nuspec.GetId())
+	require.NotEmpty(t, reader.GetFilesFromDir("testLibrary/lib"))
+	require.NoError(t, reader.Close())
+	require.ErrorIs(t, reader.Close(), os.ErrClosed)
+
+	_, err = OpenPackageArchiveReader("testdata/missing.nupkg")
+	require.ErrorIs(t, err, fs.ErrNotExist)
+
+	_, err = OpenPackageArchiveReader("testdata/empty.nuspec.test.nupkg")
+	require.Equal(t, errors.New("no .nuspec file found in the .nupkg archive"), err)
+
+	file, err := os.Open("testdata/test.1.0.0.nupkg")
+	require.NoError(t, err)
+	reader, err = NewPackageArchiveReaderFromFile(file)
+	require.NoError(t, err)
+	require.NoError(t, reader.Close())
+	_, err = file.Stat()
+	require.ErrorIs(t, err, os.ErrClosed)
+}
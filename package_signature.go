@@ -0,0 +1,29 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package nuget
+
+import (
+	"github.com/huhouhua/go-nuget/signing"
+)
+
+// IsSigned True when the package holds a .signature.p7s.
+func (p *PackageArchiveReader) IsSigned() bool {
+	for _, file := range p.archive.File {
+		if file.Name == signing.SignatureFileName {
+			return true
+		}
+	}
+	return false
+}
+
+// Signature Returns the parsed signature of the package, signing.ErrPackageNotSigned when unsigned.
+func (p *PackageArchiveReader) Signature() (*signing.PrimarySignature, error) {
+	return signing.ReadSignature(p.source, p.size)
+}
+
+// VerifySignature Verifies the signature of the package, see signing.Verify.
+func (p *PackageArchiveReader) VerifySignature(opts *signing.VerifyOptions) (*signing.VerificationResult, error) {
+	return signing.Verify(p.source, p.size, opts)
+}
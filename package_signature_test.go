@@ -0,0 +1,49 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package nuget
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/huhouhua/go-nuget/signing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageArchiveReader_VerifySignature_Unsigned(t *testing.T) {
+	data := buildTestPackage(t, "Contoso.Lib", "1.0.0", nil, "lib/net8.0/Contoso.Lib.dll")
+	reader, err := NewPackageArchiveReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	require.False(t, reader.IsSigned())
+
+	_, err = reader.Signature()
+	require.ErrorIs(t, err, signing.ErrPackageNotSigned)
+
+	result, err := reader.VerifySignature(nil)
+	require.NoError(t, err)
+	require.False(t, result.Signed)
+	require.False(t, result.Valid())
+	require.ErrorIs(t, result.Err(), signing.ErrPackageNotSigned)
+
+	result, err = reader.VerifySignature(&signing.VerifyOptions{AllowUnsigned: true})
+	require.NoError(t, err)
+	require.True(t, result.Valid())
+}
+
+func TestPackageArchiveReader_IsSigned(t *testing.T) {
+	data := buildTestPackage(t, "Contoso.Lib", "1.0.0", nil, "lib/net8.0/Contoso.Lib.dll", signing.SignatureFileName)
+	reader, err := NewPackageArchiveReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	require.True(t, reader.IsSigned())
+
+	_, err = reader.Signature()
+	require.ErrorContains(t, err, "invalid signature")
+
+	result, err := reader.VerifySignature(nil)
+	require.NoError(t, err)
+	require.True(t, result.Signed)
+	require.ErrorIs(t, result.Err(), signing.ErrInvalidSignature)
+}
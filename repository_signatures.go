@@ -0,0 +1,205 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package nuget
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/huhouhua/go-nuget/signing"
+)
+
+var (
+	// ErrRepositorySignatureMissing The source signs all its packages but the package holds no repository signature.
+	ErrRepositorySignatureMissing = errors.New("package has no repository signature")
+
+	// ErrUnknownRepositoryCertificate The repository signature certificate is not one the source publishes.
+	ErrUnknownRepositoryCertificate = errors.New("repository signature certificate is not published by the source")
+)
+
+// fingerprintAlgorithms The hash algorithms of the certificate fingerprints, by object identifier.
+var fingerprintAlgorithms = map[string]crypto.Hash{
+	"2.16.840.1.101.3.4.2.1": crypto.SHA256,
+	"2.16.840.1.101.3.4.2.2": crypto.SHA384,
+	"2.16.840.1.101.3.4.2.3": crypto.SHA512,
+}
+
+type RepositorySignaturesResource struct {
+	client *Client
+}
+
+// RepositorySignatureInfo The repository signing policy of a source.
+// Source: https://learn.microsoft.com/en-us/nuget/api/repository-signatures-resource
+type RepositorySignatureInfo struct {
+	// AllRepositorySigned True when every package of the source has a repository signature.
+	AllRepositorySigned bool `json:"allRepositorySigned"`
+
+	// SigningCertificates The certificates the source signs packages with, past and present.
+	SigningCertificates []*RepositorySigningCertificate `json:"signingCertificates"`
+}
+
+type RepositorySigningCertificate struct {
+	// Fingerprints The hex encoded hashes of the DER certificate, keyed by the object identifier of the hash algorithm.
+	Fingerprints map[string]string `json:"fingerprints"`
+	Subject      string            `json:"subject"`
+	Issuer       string            `json:"issuer"`
+	NotBefore    time.Time         `json:"notBefore"`
+	NotAfter     time.Time         `json:"notAfter"`
+
+	// ContentURL The location of the DER encoded certificate.
+	ContentURL string `json:"contentUrl"`
+}
+
+// Matches True when a fingerprint of the published certificate is the one of cert.
+// Fingerprints of an unknown hash algorithm are ignored.
+func (r *RepositorySigningCertificate) Matches(cert *x509.Certificate) bool {
+	for oid, fingerprint := range r.Fingerprints {
+		h, ok := fingerprintAlgorithms[oid]
+		if !ok {
+			continue
+		}
+		expected, err := hex.DecodeString(fingerprint)
+		if err != nil {
+			continue
+		}
+		hasher := h.New()
+		hasher.Write(cert.Raw)
+		if bytes.Equal(hasher.Sum(nil), expected) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidAt True when t is within the validity period of the certificate.
+func (r *RepositorySigningCertificate) IsValidAt(t time.Time) bool {
+	return !t.Before(r.NotBefore) && !t.After(r.NotAfter)
+}
+
+// FindCertificate Returns the published certificate matching cert, nil when the source does not publish it.
+func (s *RepositorySignatureInfo) FindCertificate(cert *x509.Certificate) *RepositorySigningCertificate {
+	for _, published := range s.SigningCertificates {
+		if published.Matches(cert) {
+			return published
+		}
+	}
+	return nil
+}
+
+// Check Checks the repository signature of the package signature against the published certificates:
+// a repository primary signature or repository countersignature must be signed with one of them,
+// and must be present when the source signs all its packages. signature may be nil for unsigned packages.
+func (s *RepositorySignatureInfo) Check(signature *signing.PrimarySignature) error {
+	var repository *signing.Signature
+	if signature != nil {
+		if signature.Type == signing.SignatureTypeRepository {
+			repository = &signature.Signature
+		} else {
+			repository = signature.Countersignature
+		}
+	}
+	if repository == nil {
+		if s.AllRepositorySigned {
+			return ErrRepositorySignatureMissing
+		}
+		return nil
+	}
+	if s.FindCertificate(repository.Certificate) == nil {
+		return fmt.Errorf("%w: %s", ErrUnknownRepositoryCertificate, repository.Certificate.Subject)
+	}
+	return nil
+}
+
+// GetRepositorySignatures retrieves the repository signing policy and certificates of the source.
+func (r *RepositorySignaturesResource) GetRepositorySignatures(
+	options ...RequestOptionFunc,
+) (*RepositorySignatureInfo, *http.Response, error) {
+	baseURL := r.client.getResourceURL(RepositorySignatures)
+	if baseURL == nil {
+		return nil, nil, fmt.Errorf("the source does not support repository signatures")
+	}
+	req, err := r.client.NewRequest(http.MethodGet, baseURL.Path, baseURL, nil, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	var signatures RepositorySignatureInfo
+	resp, err := r.client.Do(req, &signatures, DecoderTypeJSON)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &signatures, resp, nil
+}
+
+// DownloadCertificate downloads the published certificate and checks it matches its fingerprints.
+func (r *RepositorySignaturesResource) DownloadCertificate(
+	certificate *RepositorySigningCertificate,
+	options ...RequestOptionFunc,
+) (*x509.Certificate, *http.Response, error) {
+	if certificate == nil || certificate.ContentURL == "" {
+		return nil, nil, fmt.Errorf("certificate content url is empty")
+	}
+	u, err := url.Parse(certificate.ContentURL)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := r.client.NewRequest(http.MethodGet, u.Path, u, nil, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Accept", "application/pkix-cert")
+	buf := &bytes.Buffer{}
+	resp, err := r.client.Do(req, buf, DecoderEmpty)
+	if err != nil {
+		return nil, resp, err
+	}
+	der := buf.Bytes()
+	if block, _ := pem.Decode(der); block != nil && strings.Contains(block.Type, "CERTIFICATE") {
+		der = block.Bytes
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, resp, err
+	}
+	if !certificate.Matches(cert) {
+		return nil, resp, fmt.Errorf("certificate %s does not match its fingerprints", certificate.ContentURL)
+	}
+	return cert, resp, nil
+}
+
+// VerifyPackage verifies the package signature, see signing.Verify, then checks its repository signature
+// against the certificates the source publishes. The failed check is reported as an issue of the result.
+func (r *RepositorySignaturesResource) VerifyPackage(
+	reader *PackageArchiveReader,
+	opts *signing.VerifyOptions,
+	options ...RequestOptionFunc,
+) (*signing.VerificationResult, *http.Response, error) {
+	result, err := reader.VerifySignature(opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	signatures, resp, err := r.GetRepositorySignatures(options...)
+	if err != nil {
+		return nil, resp, err
+	}
+	if err = signatures.Check(result.Signature); err != nil {
+		countersignature := result.Signature != nil && result.Signature.Type == signing.SignatureTypeAuthor &&
+			!errors.Is(err, ErrRepositorySignatureMissing)
+		result.Issues = append(result.Issues, &signing.VerificationIssue{
+			Type:             signing.SignatureTypeRepository,
+			Countersignature: countersignature,
+			Err:              err,
+		})
+	}
+	return result, resp, nil
+}
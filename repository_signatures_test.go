@@ -0,0 +1,151 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package nuget
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/huhouhua/go-nuget/signing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func readTestCertificate(t *testing.T, path string) *x509.Certificate {
+	t.Helper()
+	der, err := os.ReadFile(path)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert
+}
+
+// setupRepositorySignatures serves a repository signatures resource publishing the certificate at certPath.
+func setupRepositorySignatures(t *testing.T, allRepositorySigned bool, certPath string) *Client {
+	mux, client := setup(t, index_V3)
+	der, err := os.ReadFile(certPath)
+	require.NoError(t, err)
+	fingerprint := sha256.Sum256(der)
+
+	baseURL := client.getResourceURL(RepositorySignatures)
+	mux.HandleFunc(baseURL.Path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprintf(w, `{
+			"allRepositorySigned": %t,
+			"signingCertificates": [{
+				"fingerprints": {"2.16.840.1.101.3.4.2.1": %q},
+				"subject": "CN=go-nuget Test Repository",
+				"issuer": "CN=go-nuget Test Root",
+				"notBefore": "2025-01-01T00:00:00.0000000Z",
+				"notAfter": "2125-01-01T00:00:00.0000000Z",
+				"contentUrl": "%s://%s/v3-index/repository-signatures/certificates/repository.crt"
+			}]
+		}`, allRepositorySigned, hex.EncodeToString(fingerprint[:]), client.baseURL.Scheme, client.baseURL.Host)
+	})
+	mux.HandleFunc("/v3-index/repository-signatures/certificates/repository.crt", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		_, _ = w.Write(der)
+	})
+	return client
+}
+
+func TestRepositorySignaturesResource_GetRepositorySignatures(t *testing.T) {
+	client := setupRepositorySignatures(t, true, "testdata/repository_signing.crt")
+	cert := readTestCertificate(t, "testdata/repository_signing.crt")
+
+	signatures, resp, err := client.RepositorySignaturesResource.GetRepositorySignatures()
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.True(t, signatures.AllRepositorySigned)
+	require.Len(t, signatures.SigningCertificates, 1)
+	published := signatures.SigningCertificates[0]
+	require.Equal(t, "CN=go-nuget Test Repository", published.Subject)
+	require.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), published.NotBefore)
+	require.True(t, published.IsValidAt(time.Now()))
+	require.False(t, published.IsValidAt(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)))
+	require.True(t, published.Matches(cert))
+	require.Equal(t, published, signatures.FindCertificate(cert))
+	require.Nil(t, signatures.FindCertificate(readTestCertificate(t, "testdata/author_signing.crt")))
+
+	downloaded, _, err := client.RepositorySignaturesResource.DownloadCertificate(published)
+	require.NoError(t, err)
+	require.Equal(t, cert, downloaded)
+
+	published.Fingerprints["2.16.840.1.101.3.4.2.1"] = hex.EncodeToString(make([]byte, 32))
+	_, _, err = client.RepositorySignaturesResource.DownloadCertificate(published)
+	require.ErrorContains(t, err, "does not match its fingerprints")
+	_, _, err = client.RepositorySignaturesResource.DownloadCertificate(&RepositorySigningCertificate{})
+	require.EqualError(t, err, "certificate content url is empty")
+}
+
+func TestRepositorySignaturesResource_VerifyPackage(t *testing.T) {
+	roots := x509.NewCertPool()
+	roots.AddCert(readTestCertificate(t, "testdata/signing_root.crt"))
+	opts := &signing.VerifyOptions{Roots: roots, TimestampRoots: roots, RequireTimestamp: true}
+
+	signed, err := OpenPackageArchiveReader("testdata/test.1.0.0.signed.nupkg")
+	require.NoError(t, err)
+	defer signed.Close()
+	unsigned, err := OpenPackageArchiveReader("testdata/test.1.0.0.nupkg")
+	require.NoError(t, err)
+	defer unsigned.Close()
+
+	tests := []struct {
+		name                string
+		reader              *PackageArchiveReader
+		allRepositorySigned bool
+		certPath            string
+		errs                []error
+	}{
+		{
+			name:     "published repository countersignature",
+			reader:   signed,
+			certPath: "testdata/repository_signing.crt",
+		},
+		{
+			name:     "unknown repository certificate",
+			reader:   signed,
+			certPath: "testdata/author_signing.crt",
+			errs:     []error{ErrUnknownRepositoryCertificate},
+		},
+		{
+			name:                "unsigned on a source signing all its packages",
+			reader:              unsigned,
+			allRepositorySigned: true,
+			certPath:            "testdata/repository_signing.crt",
+			errs:                []error{signing.ErrPackageNotSigned, ErrRepositorySignatureMissing},
+		},
+		{
+			name:     "unsigned",
+			reader:   unsigned,
+			certPath: "testdata/repository_signing.crt",
+			errs:     []error{signing.ErrPackageNotSigned},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := setupRepositorySignatures(t, tt.allRepositorySigned, tt.certPath)
+			result, _, err := client.RepositorySignaturesResource.VerifyPackage(tt.reader, opts)
+			require.NoError(t, err)
+			require.Len(t, result.Issues, len(tt.errs), "issues: %v", result.Err())
+			for i, want := range tt.errs {
+				require.ErrorIs(t, result.Issues[i], want)
+			}
+		})
+	}
+
+	result, err := signed.VerifySignature(opts)
+	require.NoError(t, err)
+	require.True(t, result.Valid(), "issues: %v", result.Err())
+	require.Equal(t, signing.SignatureTypeAuthor, result.Signature.Type)
+	require.NotNil(t, result.Signature.Countersignature)
+	require.Equal(t, "https://api.nuget.org/v3/index.json", result.Signature.Countersignature.V3ServiceIndexURL)
+}
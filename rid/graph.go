@@ -0,0 +1,185 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package rid
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Any The runtime identifier every runtime falls back to
+const Any = "any"
+
+// importKey Key of the inherited runtimes in a runtime.json runtime description
+const importKey = "#import"
+
+//go:embed runtime.json
+var defaultGraphData []byte
+
+var (
+	defaultGraph *Graph
+	once         sync.Once
+)
+
+// DefaultGraph Returns the portable runtime graph shipped with the .NET SDK.
+func DefaultGraph() *Graph {
+	once.Do(func() {
+		graph, err := Parse(bytes.NewReader(defaultGraphData))
+		if err != nil {
+			panic(fmt.Sprintf("invalid embedded runtime graph: %v", err))
+		}
+		defaultGraph = graph
+	})
+	return defaultGraph
+}
+
+// Description A runtime identifier and the runtimes it inherits from, in priority order.
+// Ex: linux-x64 imports linux and unix-x64
+type Description struct {
+	RuntimeIdentifier string
+	InheritedRuntimes []string
+}
+
+func NewDescription(runtimeIdentifier string, inheritedRuntimes ...string) *Description {
+	return &Description{
+		RuntimeIdentifier: runtimeIdentifier,
+		InheritedRuntimes: inheritedRuntimes,
+	}
+}
+
+// Graph Runtime fallback graph read from runtime.json files
+type Graph struct {
+	runtimes map[string]*Description
+
+	lock   sync.RWMutex
+	expand map[string][]string
+}
+
+func NewGraph(descriptions ...*Description) *Graph {
+	g := &Graph{
+		runtimes: make(map[string]*Description),
+		expand:   make(map[string][]string),
+	}
+	for _, description := range descriptions {
+		if description != nil {
+			g.runtimes[description.RuntimeIdentifier] = description
+		}
+	}
+	return g
+}
+
+// runtimeJSON runtime.json document, only the runtimes section is read.
+type runtimeJSON struct {
+	Runtimes map[string]map[string]json.RawMessage `json:"runtimes"`
+}
+
+// Parse reads a runtime.json document.
+func Parse(r io.Reader) (*Graph, error) {
+	var doc runtimeJSON
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, err
+	}
+	descriptions := make([]*Description, 0, len(doc.Runtimes))
+	for runtimeIdentifier, properties := range doc.Runtimes {
+		description := NewDescription(runtimeIdentifier)
+		if raw, ok := properties[importKey]; ok {
+			if err := json.Unmarshal(raw, &description.InheritedRuntimes); err != nil {
+				return nil, fmt.Errorf("invalid %s of runtime %s: %w", importKey, runtimeIdentifier, err)
+			}
+		}
+		descriptions = append(descriptions, description)
+	}
+	return NewGraph(descriptions...), nil
+}
+
+// ParseFile reads a runtime.json file from the file system.
+func ParseFile(name string) (*Graph, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return Parse(file)
+}
+
+// Merge Returns a graph with the runtimes of both graphs, descriptions of other win over the ones of g.
+func (g *Graph) Merge(other *Graph) *Graph {
+	descriptions := make([]*Description, 0, len(g.runtimes))
+	for _, description := range g.runtimes {
+		descriptions = append(descriptions, description)
+	}
+	if other != nil {
+		for _, description := range other.runtimes {
+			descriptions = append(descriptions, description)
+		}
+	}
+	return NewGraph(descriptions...)
+}
+
+// Runtimes Returns the runtime identifiers of the graph, sorted by name.
+func (g *Graph) Runtimes() []string {
+	runtimes := make([]string, 0, len(g.runtimes))
+	for runtimeIdentifier := range g.runtimes {
+		runtimes = append(runtimes, runtimeIdentifier)
+	}
+	sort.Strings(runtimes)
+	return runtimes
+}
+
+// Find Returns the description of a runtime identifier.
+func (g *Graph) Find(runtimeIdentifier string) (*Description, bool) {
+	description, ok := g.runtimes[runtimeIdentifier]
+	return description, ok
+}
+
+// ExpandRuntime Returns the runtime followed by its fallback chain, nearest first.
+// Ex: linux-musl-x64 -> linux-musl-x64, linux-musl, linux-x64, linux, unix-x64, unix, any, base
+func (g *Graph) ExpandRuntime(runtimeIdentifier string) []string {
+	g.lock.RLock()
+	expanded, ok := g.expand[runtimeIdentifier]
+	g.lock.RUnlock()
+	if ok {
+		return append([]string(nil), expanded...)
+	}
+
+	// breadth first, so closer runtimes come before the ones they import
+	expanded = []string{runtimeIdentifier}
+	seen := map[string]bool{runtimeIdentifier: true}
+	for i := 0; i < len(expanded); i++ {
+		description, ok := g.runtimes[expanded[i]]
+		if !ok {
+			continue
+		}
+		for _, inherited := range description.InheritedRuntimes {
+			if !seen[inherited] {
+				seen[inherited] = true
+				expanded = append(expanded, inherited)
+			}
+		}
+	}
+
+	g.lock.Lock()
+	g.expand[runtimeIdentifier] = expanded
+	g.lock.Unlock()
+	return append([]string(nil), expanded...)
+}
+
+// AreCompatible True if assets of the provided runtime can be used on the criteria runtime.
+// Ex: linux-x64 can use assets of unix and linux, but not of linux-musl-x64
+func (g *Graph) AreCompatible(criteria, provided string) bool {
+	for _, runtimeIdentifier := range g.ExpandRuntime(criteria) {
+		if strings.EqualFold(runtimeIdentifier, provided) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package rid
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultGraph_ExpandRuntime(t *testing.T) {
+	tests := []struct {
+		runtimeIdentifier string
+		want              []string
+	}{
+		{
+			runtimeIdentifier: "linux-musl-x64",
+			want:              []string{"linux-musl-x64", "linux-musl", "linux-x64", "linux", "unix-x64", "unix", "any", "base"},
+		},
+		{
+			runtimeIdentifier: "win-x64",
+			want:              []string{"win-x64", "win", "any", "base"},
+		},
+		{
+			runtimeIdentifier: "osx-arm64",
+			want:              []string{"osx-arm64", "osx", "unix-arm64", "unix", "any", "base"},
+		},
+		{
+			runtimeIdentifier: "unknown-rid",
+			want:              []string{"unknown-rid"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.runtimeIdentifier, func(t *testing.T) {
+			require.Equal(t, tt.want, DefaultGraph().ExpandRuntime(tt.runtimeIdentifier))
+		})
+	}
+	require.Same(t, DefaultGraph(), DefaultGraph())
+}
+
+func TestGraph_AreCompatible(t *testing.T) {
+	graph := DefaultGraph()
+	require.True(t, graph.AreCompatible("linux-x64", "linux-x64"))
+	require.True(t, graph.AreCompatible("linux-x64", "unix"))
+	require.True(t, graph.AreCompatible("linux-musl-arm64", "linux-arm64"))
+	require.False(t, graph.AreCompatible("linux-x64", "linux-musl-x64"))
+	require.False(t, graph.AreCompatible("win-x64", "unix"))
+}
+
+func TestParseFile(t *testing.T) {
+	graph, err := ParseFile("testdata/runtime.json")
+	require.NoError(t, err)
+	require.Equal(t, []string{"any", "centos-x64", "linux", "linux-x64", "unix"}, graph.Runtimes())
+	require.Equal(t, []string{"centos-x64", "linux-x64", "linux", "unix", "any"}, graph.ExpandRuntime("centos-x64"))
+
+	description, ok := graph.Find("linux-x64")
+	require.True(t, ok)
+	require.Equal(t, NewDescription("linux-x64", "linux"), description)
+
+	_, err = ParseFile("testdata/missing.json")
+	require.Error(t, err)
+}
+
+func TestParse_ErrorScenarios(t *testing.T) {
+	_, err := Parse(strings.NewReader("{"))
+	require.Error(t, err)
+
+	_, err = Parse(strings.NewReader(`{"runtimes":{"linux":{"#import":"unix"}}}`))
+	require.ErrorContains(t, err, "invalid #import of runtime linux")
+}
+
+func TestGraph_Merge(t *testing.T) {
+	left := NewGraph(NewDescription("linux", "unix"), NewDescription("unix"))
+	right := NewGraph(NewDescription("linux", "any"), NewDescription("alpine-x64", "linux"))
+
+	merged := left.Merge(right)
+	require.Equal(t, []string{"alpine-x64", "linux", "unix"}, merged.Runtimes())
+	require.Equal(t, []string{"alpine-x64", "linux", "any"}, merged.ExpandRuntime("alpine-x64"))
+	require.Equal(t, []string{"linux", "unix"}, left.ExpandRuntime("linux"))
+	require.Equal(t, left.Runtimes(), left.Merge(nil).Runtimes())
+}
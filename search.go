@@ -70,18 +70,29 @@ func (p *PackageSearchResource) Search(
 	opt *SearchOptions,
 	options ...RequestOptionFunc,
 ) ([]*PackageSearchMetadata, *http.Response, error) {
+	result, resp, err := p.search(opt, options)
+	if err != nil {
+		return nil, resp, err
+	}
+	return result.Data, resp, nil
+}
+
+func (p *PackageSearchResource) search(
+	opt *SearchOptions,
+	options []RequestOptionFunc,
+) (*V3SearchResult, *http.Response, error) {
 	baseURL := p.client.getResourceURL(SearchQueryService)
 	req, err := p.client.NewRequest(http.MethodGet, baseURL.Path, baseURL, opt, options)
 	if err != nil {
 		return nil, nil, err
 	}
 	addSemVer(req.URL)
-	result := V3SearchResult{}
-	resp, err := p.client.Do(req, &result, DecoderTypeJSON)
+	result := &V3SearchResult{}
+	resp, err := p.client.Do(req, result, DecoderTypeJSON)
 	if err != nil {
 		return nil, resp, err
 	}
-	return result.Data, resp, nil
+	return result, resp, nil
 }
 
 func addSemVer(u *url.URL) {
@@ -0,0 +1,168 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package signing
+
+import (
+	"crypto"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// SignatureFileName The zip entry holding the signature of a signed package
+const SignatureFileName = ".signature.p7s"
+
+const (
+	centralDirectorySignature = 0x02014b50
+	endOfCentralDirSignature  = 0x06054b50
+	centralDirectoryHeaderLen = 46
+	endOfCentralDirLen        = 22
+	maxCommentLen             = 0xffff
+)
+
+// ErrZip64NotSupported Signing and verification are limited to packages without zip64 records.
+var ErrZip64NotSupported = errors.New("zip64 packages are not supported")
+
+// centralRecord A central directory file header of the archive
+type centralRecord struct {
+	// offset of the record in the archive and its length
+	offset, length int64
+	localOffset    int64
+	name           string
+}
+
+// archiveLayout The central directory of a zip, with the signature file record when signed.
+type archiveLayout struct {
+	size             int64
+	eocdOffset       int64
+	eocd             []byte
+	centralDirOffset int64
+	centralDirSize   int64
+	records          []*centralRecord
+	signature        *centralRecord
+}
+
+// readArchiveLayout reads the end of central directory and the central directory of a zip.
+func readArchiveLayout(r io.ReaderAt, size int64) (*archiveLayout, error) {
+	searchLen := int64(endOfCentralDirLen + maxCommentLen)
+	if searchLen > size {
+		searchLen = size
+	}
+	tail := make([]byte, searchLen)
+	if _, err := r.ReadAt(tail, size-searchLen); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	i := len(tail) - endOfCentralDirLen
+	for ; i >= 0; i-- {
+		if binary.LittleEndian.Uint32(tail[i:]) == endOfCentralDirSignature &&
+			i+endOfCentralDirLen+int(binary.LittleEndian.Uint16(tail[i+20:])) == len(tail) {
+			break
+		}
+	}
+	if i < 0 {
+		return nil, fmt.Errorf("zip: end of central directory not found")
+	}
+	l := &archiveLayout{size: size, eocdOffset: size - searchLen + int64(i), eocd: tail[i:]}
+	entries := binary.LittleEndian.Uint16(l.eocd[10:])
+	cdSize := binary.LittleEndian.Uint32(l.eocd[12:])
+	cdOffset := binary.LittleEndian.Uint32(l.eocd[16:])
+	if entries == 0xffff || cdSize == 0xffffffff || cdOffset == 0xffffffff {
+		return nil, ErrZip64NotSupported
+	}
+	l.centralDirOffset, l.centralDirSize = int64(cdOffset), int64(cdSize)
+	if l.centralDirOffset+l.centralDirSize != l.eocdOffset {
+		return nil, fmt.Errorf("zip: central directory does not end at the end of central directory record")
+	}
+
+	cd := make([]byte, cdSize)
+	if _, err := r.ReadAt(cd, l.centralDirOffset); err != nil {
+		return nil, err
+	}
+	for offset := 0; offset < len(cd); {
+		if len(cd)-offset < centralDirectoryHeaderLen ||
+			binary.LittleEndian.Uint32(cd[offset:]) != centralDirectorySignature {
+			return nil, fmt.Errorf("zip: invalid central directory header")
+		}
+		nameLen := int(binary.LittleEndian.Uint16(cd[offset+28:]))
+		extraLen := int(binary.LittleEndian.Uint16(cd[offset+30:]))
+		commentLen := int(binary.LittleEndian.Uint16(cd[offset+32:]))
+		length := centralDirectoryHeaderLen + nameLen + extraLen + commentLen
+		if offset+length > len(cd) {
+			return nil, fmt.Errorf("zip: invalid central directory header")
+		}
+		record := &centralRecord{
+			offset:      l.centralDirOffset + int64(offset),
+			length:      int64(length),
+			localOffset: int64(binary.LittleEndian.Uint32(cd[offset+42:])),
+			name:        string(cd[offset+centralDirectoryHeaderLen : offset+centralDirectoryHeaderLen+nameLen]),
+		}
+		if record.localOffset == 0xffffffff {
+			return nil, ErrZip64NotSupported
+		}
+		if record.name == SignatureFileName {
+			if l.signature != nil {
+				return nil, fmt.Errorf("zip: duplicate %s entry", SignatureFileName)
+			}
+			l.signature = record
+		}
+		l.records = append(l.records, record)
+		offset += length
+	}
+	if len(l.records) != int(entries) {
+		return nil, fmt.Errorf("zip: central directory holds %d entries, want %d", len(l.records), entries)
+	}
+	if l.signature != nil {
+		// The signature file is appended after every other entry, so that removing it restores the unsigned package.
+		for _, record := range l.records {
+			if record != l.signature && record.localOffset >= l.signature.localOffset {
+				return nil, fmt.Errorf("zip: %s is not the last entry of the package", SignatureFileName)
+			}
+		}
+	}
+	return l, nil
+}
+
+// writeUnsignedContent writes the archive with the signature file removed: the entries before the signature,
+// the central directory without its record and an end of central directory updated accordingly.
+// This is the content the package signature hashes, for an unsigned archive the whole archive.
+func (l *archiveLayout) writeUnsignedContent(w io.Writer, r io.ReaderAt) error {
+	if l.signature == nil {
+		_, err := io.Copy(w, io.NewSectionReader(r, 0, l.size))
+		return err
+	}
+	if _, err := io.Copy(w, io.NewSectionReader(r, 0, l.signature.localOffset)); err != nil {
+		return err
+	}
+	for _, record := range l.records {
+		if record == l.signature {
+			continue
+		}
+		if _, err := io.Copy(w, io.NewSectionReader(r, record.offset, record.length)); err != nil {
+			return err
+		}
+	}
+	eocd := append([]byte(nil), l.eocd...)
+	entries := uint16(len(l.records) - 1)
+	binary.LittleEndian.PutUint16(eocd[8:], entries)
+	binary.LittleEndian.PutUint16(eocd[10:], entries)
+	binary.LittleEndian.PutUint32(eocd[12:], uint32(l.centralDirSize-l.signature.length))
+	binary.LittleEndian.PutUint32(eocd[16:], uint32(l.signature.localOffset))
+	_, err := w.Write(eocd)
+	return err
+}
+
+// hashUnsignedContent returns the hash of the archive with the signature file removed.
+func hashUnsignedContent(r io.ReaderAt, size int64, h crypto.Hash) ([]byte, error) {
+	layout, err := readArchiveLayout(r, size)
+	if err != nil {
+		return nil, err
+	}
+	hasher := h.New()
+	if err = layout.writeUnsignedContent(hasher, r); err != nil {
+		return nil, err
+	}
+	return hasher.Sum(nil), nil
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package signing
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashUnsignedContent(t *testing.T) {
+	unsigned := writeTestArchive(t, nil, testPackageFiles...)
+	signed := writeTestArchive(t, []byte("signature"), testPackageFiles...)
+	want := sha256.Sum256(unsigned)
+
+	// the signed archive hashes as the archive without its signature
+	hash, err := hashUnsignedContent(bytes.NewReader(signed), int64(len(signed)), crypto.SHA256)
+	require.NoError(t, err)
+	require.Equal(t, want[:], hash)
+
+	hash, err = hashUnsignedContent(bytes.NewReader(unsigned), int64(len(unsigned)), crypto.SHA256)
+	require.NoError(t, err)
+	require.Equal(t, want[:], hash)
+
+	layout, err := readArchiveLayout(bytes.NewReader(signed), int64(len(signed)))
+	require.NoError(t, err)
+	require.Len(t, layout.records, 3)
+	require.NotNil(t, layout.signature)
+	require.Equal(t, SignatureFileName, layout.signature.name)
+}
+
+func TestReadArchiveLayout_Invalid(t *testing.T) {
+	// the signature must be the last entry
+	buf := &bytes.Buffer{}
+	w := zip.NewWriter(buf)
+	for _, name := range []string{SignatureFileName, "lib/a.dll"} {
+		f, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(name))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	_, err := readArchiveLayout(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.EqualError(t, err, "zip: .signature.p7s is not the last entry of the package")
+
+	data := []byte("not a zip")
+	_, err = readArchiveLayout(bytes.NewReader(data), int64(len(data)))
+	require.EqualError(t, err, "zip: end of central directory not found")
+
+	// a comment is kept in the unsigned content
+	buf.Reset()
+	w = zip.NewWriter(buf)
+	require.NoError(t, w.SetComment("comment"))
+	require.NoError(t, w.Close())
+	layout, err := readArchiveLayout(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	require.Empty(t, layout.records)
+	require.Equal(t, "comment", string(layout.eocd[endOfCentralDirLen:]))
+}
@@ -0,0 +1,433 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package signing
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	// register the hashes a signature may use
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+)
+
+// Object identifiers of the CMS structures and the NuGet signature attributes
+// Source: https://github.com/NuGet/Home/wiki/Package-Signatures-Technical-Details
+var (
+	oidData                    = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 1}
+	oidSignedData              = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 2}
+	oidTSTInfo                 = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 16, 1, 4}
+	oidAttributeContentType    = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 3}
+	oidAttributeMessageDigest  = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 4}
+	oidAttributeSigningTime    = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 5}
+	oidAttributeCounterSign    = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 6}
+	oidAttributeTimeStampToken = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 16, 2, 14}
+	oidAttributeCommitmentType = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 16, 2, 16}
+	oidAttributeSigningCertV2  = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 16, 2, 47}
+	oidProofOfOrigin           = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 16, 6, 1}
+	oidProofOfReceipt          = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 16, 6, 2}
+	oidNuGetV3ServiceIndexURL  = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 311, 84, 2, 1, 1, 1}
+	oidNuGetPackageOwners      = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 311, 84, 2, 1, 1, 2}
+
+	oidSHA256 = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 1}
+	oidSHA384 = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 2}
+	oidSHA512 = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 3}
+
+	oidRSAEncryption   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 1}
+	oidECDSAWithSHA256 = asn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 2}
+	oidECDSAWithSHA384 = asn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 3}
+	oidECDSAWithSHA512 = asn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 4}
+)
+
+// hashOIDs The hash algorithms allowed in package signatures
+var hashOIDs = map[crypto.Hash]asn1.ObjectIdentifier{
+	crypto.SHA256: oidSHA256,
+	crypto.SHA384: oidSHA384,
+	crypto.SHA512: oidSHA512,
+}
+
+// contentInfo Source: https://www.rfc-editor.org/rfc/rfc5652#section-3
+type contentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	Content     asn1.RawValue `asn1:"explicit,optional,tag:0"`
+}
+
+// signedData Source: https://www.rfc-editor.org/rfc/rfc5652#section-5.1
+type signedData struct {
+	Version          int
+	DigestAlgorithms []pkix.AlgorithmIdentifier `asn1:"set"`
+	EncapContentInfo encapsulatedContentInfo
+	Certificates     asn1.RawValue `asn1:"optional,tag:0"`
+	CRLs             asn1.RawValue `asn1:"optional,tag:1"`
+	SignerInfos      []signerInfo  `asn1:"set"`
+}
+
+type encapsulatedContentInfo struct {
+	EContentType asn1.ObjectIdentifier
+	EContent     []byte `asn1:"explicit,optional,tag:0"`
+}
+
+// signerInfo Source: https://www.rfc-editor.org/rfc/rfc5652#section-5.3
+type signerInfo struct {
+	Version            int
+	SID                asn1.RawValue
+	DigestAlgorithm    pkix.AlgorithmIdentifier
+	SignedAttributes   asn1.RawValue `asn1:"optional,tag:0"`
+	SignatureAlgorithm pkix.AlgorithmIdentifier
+	Signature          []byte
+	UnsignedAttributes asn1.RawValue `asn1:"optional,tag:1"`
+}
+
+type issuerAndSerialNumber struct {
+	Issuer       asn1.RawValue
+	SerialNumber *big.Int
+}
+
+type attribute struct {
+	Type   asn1.ObjectIdentifier
+	Values asn1.RawValue `asn1:"set"`
+}
+
+// essCertIDv2 Source: https://www.rfc-editor.org/rfc/rfc5035#section-4
+type essCertIDv2 struct {
+	HashAlgorithm pkix.AlgorithmIdentifier `asn1:"optional"`
+	CertHash      []byte
+	IssuerSerial  asn1.RawValue `asn1:"optional"`
+}
+
+type signingCertificateV2 struct {
+	Certs []essCertIDv2
+}
+
+type commitmentTypeIndication struct {
+	CommitmentTypeID asn1.ObjectIdentifier
+}
+
+// parseSignedData parses a DER ContentInfo holding a SignedData, with the certificates it carries.
+func parseSignedData(data []byte) (*signedData, []*x509.Certificate, error) {
+	var info contentInfo
+	if rest, err := asn1.Unmarshal(data, &info); err != nil {
+		return nil, nil, fmt.Errorf("invalid signature: %w", err)
+	} else if len(rest) > 0 {
+		return nil, nil, fmt.Errorf("invalid signature: trailing data")
+	}
+	if !info.ContentType.Equal(oidSignedData) {
+		return nil, nil, fmt.Errorf("invalid signature: content type %s is not signed data", info.ContentType)
+	}
+	var sd signedData
+	if _, err := asn1.Unmarshal(info.Content.Bytes, &sd); err != nil {
+		return nil, nil, fmt.Errorf("invalid signature: %w", err)
+	}
+	var certs []*x509.Certificate
+	if len(sd.Certificates.Bytes) > 0 {
+		var err error
+		if certs, err = x509.ParseCertificates(sd.Certificates.Bytes); err != nil {
+			return nil, nil, fmt.Errorf("invalid signature certificates: %w", err)
+		}
+	}
+	return &sd, certs, nil
+}
+
+// parseAttributes parses the signed or unsigned attributes of a signer, raw is the IMPLICIT [0] or [1] field.
+func parseAttributes(raw asn1.RawValue) ([]attribute, error) {
+	if len(raw.FullBytes) == 0 {
+		return nil, nil
+	}
+	var attributes []attribute
+	if _, err := asn1.UnmarshalWithParams(setOf(raw.FullBytes), &attributes, "set"); err != nil {
+		return nil, fmt.Errorf("invalid signature attributes: %w", err)
+	}
+	return attributes, nil
+}
+
+// setOf returns the implicitly tagged field retagged as a universal SET, the encoding signed attributes are hashed in.
+func setOf(implicit []byte) []byte {
+	set := append([]byte(nil), implicit...)
+	set[0] = 0x31
+	return set
+}
+
+// findAttribute returns the raw value of the first attribute of type oid.
+func findAttribute(attributes []attribute, oid asn1.ObjectIdentifier) ([]byte, bool) {
+	for _, attr := range attributes {
+		if attr.Type.Equal(oid) {
+			return attr.Values.Bytes, true
+		}
+	}
+	return nil, false
+}
+
+// findAttributes returns the raw values of all the attributes of type oid.
+func findAttributes(attributes []attribute, oid asn1.ObjectIdentifier) [][]byte {
+	var values [][]byte
+	for _, attr := range attributes {
+		if !attr.Type.Equal(oid) {
+			continue
+		}
+		rest := attr.Values.Bytes
+		for len(rest) > 0 {
+			var value asn1.RawValue
+			var err error
+			if rest, err = asn1.Unmarshal(rest, &value); err != nil {
+				break
+			}
+			values = append(values, value.FullBytes)
+		}
+	}
+	return values
+}
+
+// signerCertificate returns the certificate identified by the sid of the signer.
+func (s *signerInfo) signerCertificate(certs []*x509.Certificate) (*x509.Certificate, error) {
+	if s.SID.Class == asn1.ClassContextSpecific && s.SID.Tag == 0 {
+		for _, cert := range certs {
+			if bytes.Equal(cert.SubjectKeyId, s.SID.Bytes) {
+				return cert, nil
+			}
+		}
+		return nil, fmt.Errorf("signer certificate not found")
+	}
+	var ias issuerAndSerialNumber
+	if _, err := asn1.Unmarshal(s.SID.FullBytes, &ias); err != nil {
+		return nil, fmt.Errorf("invalid signer identifier: %w", err)
+	}
+	for _, cert := range certs {
+		if cert.SerialNumber.Cmp(ias.SerialNumber) == 0 && bytes.Equal(cert.RawIssuer, ias.Issuer.FullBytes) {
+			return cert, nil
+		}
+	}
+	return nil, fmt.Errorf("signer certificate not found")
+}
+
+// hashAlgorithm returns the digest algorithm of the signer, an error for the algorithms NuGet does not allow.
+func (s *signerInfo) hashAlgorithm() (crypto.Hash, error) {
+	return hashFromOID(s.DigestAlgorithm.Algorithm)
+}
+
+func hashFromOID(oid asn1.ObjectIdentifier) (crypto.Hash, error) {
+	for h, hashOID := range hashOIDs {
+		if hashOID.Equal(oid) {
+			return h, nil
+		}
+	}
+	return 0, fmt.Errorf("unsupported hash algorithm %s", oid)
+}
+
+// verify checks the signer signed content with cert: the messageDigest attribute must be the hash of content
+// and the signature must be valid over the signed attributes. Returns the signed attributes.
+func (s *signerInfo) verify(content []byte, cert *x509.Certificate) ([]attribute, error) {
+	h, err := s.hashAlgorithm()
+	if err != nil {
+		return nil, err
+	}
+	attributes, err := parseAttributes(s.SignedAttributes)
+	if err != nil {
+		return nil, err
+	}
+	if len(attributes) == 0 {
+		return nil, fmt.Errorf("signed attributes are missing")
+	}
+	raw, ok := findAttribute(attributes, oidAttributeMessageDigest)
+	if !ok {
+		return nil, fmt.Errorf("message digest attribute is missing")
+	}
+	var digest []byte
+	if _, err = asn1.Unmarshal(raw, &digest); err != nil {
+		return nil, fmt.Errorf("invalid message digest attribute: %w", err)
+	}
+	hasher := h.New()
+	hasher.Write(content)
+	if !bytes.Equal(hasher.Sum(nil), digest) {
+		return nil, fmt.Errorf("message digest does not match the signed content")
+	}
+	if err = verifySignature(cert.PublicKey, h, setOf(s.SignedAttributes.FullBytes), s.Signature); err != nil {
+		return nil, err
+	}
+	return attributes, nil
+}
+
+// verifySignature verifies an RSA PKCS#1 v1.5 or ECDSA signature of data.
+func verifySignature(publicKey crypto.PublicKey, h crypto.Hash, data, signature []byte) error {
+	hasher := h.New()
+	hasher.Write(data)
+	digest := hasher.Sum(nil)
+	switch pub := publicKey.(type) {
+	case *rsa.PublicKey:
+		if err := rsa.VerifyPKCS1v15(pub, h, digest, signature); err != nil {
+			return fmt.Errorf("invalid signature: %w", err)
+		}
+		return nil
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(pub, digest, signature) {
+			return errors.New("invalid signature: ecdsa verification failure")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported public key type %T", publicKey)
+	}
+}
+
+// signingTime returns the signing-time attribute, zero when absent.
+func signingTime(attributes []attribute) time.Time {
+	raw, ok := findAttribute(attributes, oidAttributeSigningTime)
+	if !ok {
+		return time.Time{}
+	}
+	var t time.Time
+	if _, err := asn1.Unmarshal(raw, &t); err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// verifySigningCertificate checks the signing-certificate-v2 attribute identifies cert.
+func verifySigningCertificate(attributes []attribute, cert *x509.Certificate) error {
+	raw, ok := findAttribute(attributes, oidAttributeSigningCertV2)
+	if !ok {
+		return fmt.Errorf("signing certificate v2 attribute is missing")
+	}
+	var value signingCertificateV2
+	if _, err := asn1.Unmarshal(raw, &value); err != nil {
+		return fmt.Errorf("invalid signing certificate v2 attribute: %w", err)
+	}
+	if len(value.Certs) == 0 {
+		return fmt.Errorf("signing certificate v2 attribute is empty")
+	}
+	id := value.Certs[0]
+	h := crypto.SHA256
+	if len(id.HashAlgorithm.Algorithm) > 0 {
+		var err error
+		if h, err = hashFromOID(id.HashAlgorithm.Algorithm); err != nil {
+			return err
+		}
+	}
+	hasher := h.New()
+	hasher.Write(cert.Raw)
+	if !bytes.Equal(hasher.Sum(nil), id.CertHash) {
+		return fmt.Errorf("signing certificate v2 attribute does not match the signer certificate")
+	}
+	return nil
+}
+
+// newAttribute returns the attribute of type oid holding values, asn1.RawValue values are encoded as is.
+func newAttribute(oid asn1.ObjectIdentifier, values ...any) (attribute, error) {
+	var encoded []byte
+	for _, value := range values {
+		der, err := asn1.Marshal(value)
+		if err != nil {
+			return attribute{}, err
+		}
+		encoded = append(encoded, der...)
+	}
+	return attribute{
+		Type:   oid,
+		Values: asn1.RawValue{Class: asn1.ClassUniversal, Tag: asn1.TagSet, IsCompound: true, Bytes: encoded},
+	}, nil
+}
+
+// marshalAttributes returns the attributes encoded as the IMPLICIT [tag] SET of a signer.
+func marshalAttributes(tag byte, attributes []attribute) (asn1.RawValue, error) {
+	der, err := asn1.MarshalWithParams(attributes, "set")
+	if err != nil {
+		return asn1.RawValue{}, err
+	}
+	der[0] = 0xa0 | tag
+	return asn1.RawValue{FullBytes: der}, nil
+}
+
+// newSignerInfo signs content with signer, the messageDigest attribute is appended to attributes.
+func newSignerInfo(cert *x509.Certificate, signer crypto.Signer, h crypto.Hash, content []byte,
+	attributes []attribute) (*signerInfo, error) {
+	digestOID, ok := hashOIDs[h]
+	if !ok {
+		return nil, fmt.Errorf("unsupported hash algorithm %s", h)
+	}
+	algorithm, err := signatureAlgorithm(signer.Public(), h)
+	if err != nil {
+		return nil, err
+	}
+	hasher := h.New()
+	hasher.Write(content)
+	messageDigest, err := newAttribute(oidAttributeMessageDigest, hasher.Sum(nil))
+	if err != nil {
+		return nil, err
+	}
+	signed, err := marshalAttributes(0, append(attributes, messageDigest))
+	if err != nil {
+		return nil, err
+	}
+	hasher = h.New()
+	hasher.Write(setOf(signed.FullBytes))
+	signature, err := signer.Sign(rand.Reader, hasher.Sum(nil), h)
+	if err != nil {
+		return nil, err
+	}
+	sid, err := asn1.Marshal(issuerAndSerialNumber{
+		Issuer:       asn1.RawValue{FullBytes: cert.RawIssuer},
+		SerialNumber: cert.SerialNumber,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &signerInfo{
+		Version:            1,
+		SID:                asn1.RawValue{FullBytes: sid},
+		DigestAlgorithm:    pkix.AlgorithmIdentifier{Algorithm: digestOID},
+		SignedAttributes:   signed,
+		SignatureAlgorithm: algorithm,
+		Signature:          signature,
+	}, nil
+}
+
+// signatureAlgorithm returns the signature algorithm of the key with the hash h.
+func signatureAlgorithm(publicKey crypto.PublicKey, h crypto.Hash) (pkix.AlgorithmIdentifier, error) {
+	switch publicKey.(type) {
+	case *rsa.PublicKey:
+		return pkix.AlgorithmIdentifier{Algorithm: oidRSAEncryption, Parameters: asn1.NullRawValue}, nil
+	case *ecdsa.PublicKey:
+		switch h {
+		case crypto.SHA256:
+			return pkix.AlgorithmIdentifier{Algorithm: oidECDSAWithSHA256}, nil
+		case crypto.SHA384:
+			return pkix.AlgorithmIdentifier{Algorithm: oidECDSAWithSHA384}, nil
+		case crypto.SHA512:
+			return pkix.AlgorithmIdentifier{Algorithm: oidECDSAWithSHA512}, nil
+		}
+	}
+	return pkix.AlgorithmIdentifier{}, fmt.Errorf("unsupported public key type %T", publicKey)
+}
+
+// marshalSignedData returns the DER ContentInfo of a SignedData of content signed by si, carrying certs.
+func marshalSignedData(contentType asn1.ObjectIdentifier, content []byte, si *signerInfo,
+	certs []*x509.Certificate) ([]byte, error) {
+	var raw []byte
+	for _, cert := range certs {
+		raw = append(raw, cert.Raw...)
+	}
+	sd, err := asn1.Marshal(signedData{
+		Version:          1,
+		DigestAlgorithms: []pkix.AlgorithmIdentifier{si.DigestAlgorithm},
+		EncapContentInfo: encapsulatedContentInfo{EContentType: contentType, EContent: content},
+		Certificates:     asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: raw},
+		SignerInfos:      []signerInfo{*si},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(contentInfo{
+		ContentType: oidSignedData,
+		Content:     asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: sd},
+	})
+}
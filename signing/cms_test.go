@@ -0,0 +1,269 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package signing
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var oidTestPolicy = asn1.ObjectIdentifier{1, 2, 3, 4, 1}
+
+type testIdentity struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+// testPKI A root issuing the author, repository and timestamp authority certificates of the tests.
+type testPKI struct {
+	root       *testIdentity
+	author     *testIdentity
+	repository *testIdentity
+	tsa        *testIdentity
+	roots      *x509.CertPool
+}
+
+var testSerial int64
+
+// newTestIdentity returns a certificate issued by parent from template, self-signed when parent is nil.
+func newTestIdentity(t *testing.T, parent *testIdentity, template *x509.Certificate) *testIdentity {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	testSerial++
+	template.SerialNumber = big.NewInt(testSerial)
+	template.SubjectKeyId = []byte{byte(testSerial)}
+	issuer, issuerKey := template, key
+	if parent != nil {
+		issuer, issuerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, issuer, &key.PublicKey, issuerKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testIdentity{cert: cert, key: key}
+}
+
+// newTestLeaf returns a certificate issued by parent for usage, valid from notBefore to notAfter.
+func newTestLeaf(t *testing.T, parent *testIdentity, name string, usage x509.ExtKeyUsage,
+	notBefore, notAfter time.Time) *testIdentity {
+	t.Helper()
+	return newTestIdentity(t, parent, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: name},
+		NotBefore:   notBefore,
+		NotAfter:    notAfter,
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{usage},
+	})
+}
+
+func newTestPKI(t *testing.T) *testPKI {
+	t.Helper()
+	now := time.Now()
+	root := newTestIdentity(t, nil, &x509.Certificate{
+		Subject:               pkix.Name{CommonName: "Test Root"},
+		NotBefore:             now.AddDate(-10, 0, 0),
+		NotAfter:              now.AddDate(10, 0, 0),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	})
+	pki := &testPKI{
+		root:       root,
+		author:     newTestLeaf(t, root, "Test Author", x509.ExtKeyUsageCodeSigning, now.Add(-time.Hour), now.AddDate(1, 0, 0)),
+		repository: newTestLeaf(t, root, "Test Repository", x509.ExtKeyUsageCodeSigning, now.Add(-time.Hour), now.AddDate(1, 0, 0)),
+		tsa:        newTestLeaf(t, root, "Test TSA", x509.ExtKeyUsageTimeStamping, now.AddDate(-5, 0, 0), now.AddDate(5, 0, 0)),
+		roots:      x509.NewCertPool(),
+	}
+	pki.roots.AddCert(root.cert)
+	return pki
+}
+
+// marshalAttribute returns the attribute of type oid holding values, asn1.RawValue values are used as is.
+func marshalAttribute(t *testing.T, oid asn1.ObjectIdentifier, values ...any) attribute {
+	t.Helper()
+	attr, err := newAttribute(oid, values...)
+	require.NoError(t, err)
+	return attr
+}
+
+// testAttributes returns the attributes as the IMPLICIT tagged SET of a signer.
+func testAttributes(t *testing.T, tag byte, attributes []attribute) asn1.RawValue {
+	t.Helper()
+	raw, err := marshalAttributes(tag, attributes)
+	require.NoError(t, err)
+	return raw
+}
+
+// newTestSignerInfo signs content with id, the messageDigest attribute is added to attributes.
+func newTestSignerInfo(t *testing.T, id *testIdentity, content []byte, attributes ...attribute) *signerInfo {
+	t.Helper()
+	si, err := newSignerInfo(id.cert, id.key, crypto.SHA256, content, attributes)
+	require.NoError(t, err)
+	return si
+}
+
+// testSignedData returns the ContentInfo of a SignedData of content signed by si, carrying certs.
+func testSignedData(t *testing.T, contentType asn1.ObjectIdentifier, content []byte, si *signerInfo,
+	certs ...*x509.Certificate) []byte {
+	t.Helper()
+	der, err := marshalSignedData(contentType, content, si, certs)
+	require.NoError(t, err)
+	return der
+}
+
+// timestampToken returns a timestamp token of the tsa over the SHA-256 digest at the time at.
+func (pki *testPKI) timestampToken(t *testing.T, digest []byte, nonce *big.Int, at time.Time) []byte {
+	t.Helper()
+	info, err := asn1.Marshal(tstInfo{
+		Version: 1,
+		Policy:  oidTestPolicy,
+		MessageImprint: messageImprint{
+			HashAlgorithm: pkix.AlgorithmIdentifier{Algorithm: oidSHA256},
+			HashedMessage: digest,
+		},
+		SerialNumber: big.NewInt(42),
+		GenTime:      at.UTC(),
+		Accuracy:     accuracy{Seconds: 1},
+		Nonce:        nonce,
+	})
+	require.NoError(t, err)
+	si := newTestSignerInfo(t, pki.tsa, info, marshalAttribute(t, oidAttributeContentType, oidTSTInfo))
+	return testSignedData(t, oidTSTInfo, info, si, pki.tsa.cert)
+}
+
+// testSigner How a test signature is produced.
+type testSigner struct {
+	id *testIdentity
+	// commitment is the commitment type of the signature, the attribute is omitted when nil
+	commitment asn1.ObjectIdentifier
+	// timestamp is the time of the timestamp over the signature, none when zero
+	timestamp time.Time
+	extra     []attribute
+}
+
+func (pki *testPKI) signerInfo(t *testing.T, signer *testSigner, content []byte) *signerInfo {
+	t.Helper()
+	certHash := sha256.Sum256(signer.id.cert.Raw)
+	attributes := append([]attribute{
+		marshalAttribute(t, oidAttributeContentType, oidData),
+		marshalAttribute(t, oidAttributeSigningTime, time.Now().UTC()),
+		marshalAttribute(t, oidAttributeSigningCertV2, signingCertificateV2{Certs: []essCertIDv2{{CertHash: certHash[:]}}}),
+	}, signer.extra...)
+	if signer.commitment != nil {
+		attributes = append(attributes,
+			marshalAttribute(t, oidAttributeCommitmentType, commitmentTypeIndication{CommitmentTypeID: signer.commitment}))
+	}
+	si := newTestSignerInfo(t, signer.id, content, attributes...)
+	if !signer.timestamp.IsZero() {
+		digest := sha256.Sum256(si.Signature)
+		token := pki.timestampToken(t, digest[:], nil, signer.timestamp)
+		si.UnsignedAttributes = testAttributes(t, 1, []attribute{
+			marshalAttribute(t, oidAttributeTimeStampToken, asn1.RawValue{FullBytes: token}),
+		})
+	}
+	return si
+}
+
+// signature returns the .signature.p7s of the primary signer over content, countersigned when counter is set.
+func (pki *testPKI) signature(t *testing.T, content []byte, primary, counter *testSigner) []byte {
+	t.Helper()
+	si := pki.signerInfo(t, primary, content)
+	if counter != nil {
+		counterInfo := pki.signerInfo(t, counter, si.Signature)
+		counterDER, err := asn1.Marshal(*counterInfo)
+		require.NoError(t, err)
+		unsigned, err := parseAttributes(si.UnsignedAttributes)
+		require.NoError(t, err)
+		unsigned = append(unsigned, marshalAttribute(t, oidAttributeCounterSign, asn1.RawValue{FullBytes: counterDER}))
+		si.UnsignedAttributes = testAttributes(t, 1, unsigned)
+	}
+	certs := []*x509.Certificate{primary.id.cert, pki.tsa.cert}
+	if counter != nil {
+		certs = append(certs, counter.id.cert)
+	}
+	return testSignedData(t, oidData, content, si, certs...)
+}
+
+// writeTestArchive returns a zip of the files and their content, followed by the signature when not nil.
+func writeTestArchive(t *testing.T, signature []byte, files ...string) []byte {
+	t.Helper()
+	buf := &bytes.Buffer{}
+	w := zip.NewWriter(buf)
+	for _, name := range files {
+		f, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(name))
+		require.NoError(t, err)
+	}
+	if signature != nil {
+		f, err := w.CreateHeader(&zip.FileHeader{Name: SignatureFileName, Method: zip.Store})
+		require.NoError(t, err)
+		_, err = f.Write(signature)
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+var testPackageFiles = []string{"Contoso.Lib.nuspec", "lib/net8.0/Contoso.Lib.dll"}
+
+// signTestPackage returns a package signed by primary, countersigned when counter is set.
+func (pki *testPKI) signTestPackage(t *testing.T, primary, counter *testSigner) []byte {
+	t.Helper()
+	hash := sha256.Sum256(writeTestArchive(t, nil, testPackageFiles...))
+	content := (&SignatureContent{HashAlgorithm: crypto.SHA256, HashValue: hash[:]}).Bytes()
+	return writeTestArchive(t, pki.signature(t, content, primary, counter), testPackageFiles...)
+}
+
+func TestSignerInfo_Verify(t *testing.T) {
+	pki := newTestPKI(t)
+	content := []byte("content")
+	si := newTestSignerInfo(t, pki.author, content)
+
+	cert, err := si.signerCertificate([]*x509.Certificate{pki.repository.cert, pki.author.cert})
+	require.NoError(t, err)
+	require.Equal(t, pki.author.cert, cert)
+	_, err = si.signerCertificate([]*x509.Certificate{pki.repository.cert})
+	require.EqualError(t, err, "signer certificate not found")
+
+	attributes, err := si.verify(content, pki.author.cert)
+	require.NoError(t, err)
+	require.Len(t, attributes, 1)
+
+	_, err = si.verify([]byte("other"), pki.author.cert)
+	require.EqualError(t, err, "message digest does not match the signed content")
+	_, err = si.verify(content, pki.repository.cert)
+	require.EqualError(t, err, "invalid signature: ecdsa verification failure")
+
+	si.DigestAlgorithm.Algorithm = asn1.ObjectIdentifier{1, 3, 14, 3, 2, 26}
+	_, err = si.verify(content, pki.author.cert)
+	require.EqualError(t, err, "unsupported hash algorithm 1.3.14.3.2.26")
+}
+
+func TestVerifySigningCertificate(t *testing.T) {
+	pki := newTestPKI(t)
+	certHash := sha256.Sum256(pki.author.cert.Raw)
+	attributes := []attribute{
+		marshalAttribute(t, oidAttributeSigningCertV2, signingCertificateV2{Certs: []essCertIDv2{{CertHash: certHash[:]}}}),
+	}
+	require.NoError(t, verifySigningCertificate(attributes, pki.author.cert))
+	require.EqualError(t, verifySigningCertificate(attributes, pki.repository.cert),
+		"signing certificate v2 attribute does not match the signer certificate")
+	require.EqualError(t, verifySigningCertificate(nil, pki.author.cert), "signing certificate v2 attribute is missing")
+}
@@ -0,0 +1,269 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package signing
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"slices"
+	"time"
+)
+
+const (
+	localFileHeaderSignature = 0x04034b50
+	localFileHeaderLen       = 30
+	zipVersion20             = 20
+	// minRSAKeySize The smallest RSA key NuGet accepts for signing.
+	minRSAKeySize = 2048
+)
+
+// ErrPackageAlreadySigned The package to sign already holds a .signature.p7s.
+var ErrPackageAlreadySigned = errors.New("package is already signed")
+
+// SignOptions How a package is signed.
+type SignOptions struct {
+	// Certificate The code signing certificate of the author.
+	Certificate *x509.Certificate
+
+	// Signer The private key of Certificate.
+	Signer crypto.Signer
+
+	// Intermediates The chain of Certificate up to its root excluded, embedded so that verifiers can build the chain.
+	Intermediates []*x509.Certificate
+
+	// HashAlgorithm The hash of the package and of the signature, SHA-256 when zero.
+	HashAlgorithm crypto.Hash
+
+	// Timestamper Timestamps the signature, so that it outlives the certificate. No timestamp when nil.
+	Timestamper Timestamper
+
+	// SigningTime The signing-time attribute and modification time of the signature file, time.Now when zero.
+	SigningTime time.Time
+}
+
+// withDefaults returns a copy of the options with the defaults of the unset fields.
+func (o *SignOptions) withDefaults() *SignOptions {
+	if o == nil {
+		return nil
+	}
+	opts := *o
+	if opts.HashAlgorithm == 0 {
+		opts.HashAlgorithm = crypto.SHA256
+	}
+	if opts.SigningTime.IsZero() {
+		opts.SigningTime = time.Now()
+	}
+	return &opts
+}
+
+func (o *SignOptions) validate() error {
+	if o == nil || o.Certificate == nil || o.Signer == nil {
+		return fmt.Errorf("certificate and signer are required")
+	}
+	if _, ok := hashOIDs[o.HashAlgorithm]; !ok {
+		return fmt.Errorf("unsupported hash algorithm %s", o.HashAlgorithm)
+	}
+	publicKey, ok := o.Signer.Public().(interface{ Equal(crypto.PublicKey) bool })
+	if !ok || !publicKey.Equal(o.Certificate.PublicKey) {
+		return fmt.Errorf("signer does not match the certificate public key")
+	}
+	if key, ok := o.Certificate.PublicKey.(*rsa.PublicKey); ok && key.N.BitLen() < minRSAKeySize {
+		return fmt.Errorf("rsa key must be at least %d bits", minRSAKeySize)
+	}
+	if len(o.Certificate.ExtKeyUsage) > 0 && !slices.Contains(o.Certificate.ExtKeyUsage, x509.ExtKeyUsageCodeSigning) {
+		return fmt.Errorf("certificate is not valid for code signing")
+	}
+	if o.SigningTime.Before(o.Certificate.NotBefore) || o.SigningTime.After(o.Certificate.NotAfter) {
+		return fmt.Errorf("certificate is not valid at %s", o.SigningTime.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// Sign writes to w the package read from r with an author signature appended.
+// The entries of the package are copied as is: the signature file is written after them,
+// followed by the central directory it is added to.
+func Sign(r io.ReaderAt, size int64, w io.Writer, opts *SignOptions) error {
+	opts = opts.withDefaults()
+	layout, signature, err := prepareSignature(r, size, opts)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(w, io.NewSectionReader(r, 0, layout.centralDirOffset)); err != nil {
+		return err
+	}
+	return layout.writeSignature(w, r, signature, opts.SigningTime)
+}
+
+// SignFile adds an author signature to the package at path in place,
+// only its central directory is rewritten after the appended signature file.
+func SignFile(path string, opts *SignOptions) error {
+	opts = opts.withDefaults()
+	file, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+	layout, signature, err := prepareSignature(file, info.Size(), opts)
+	if err == nil {
+		err = layout.writeSignature(io.NewOffsetWriter(file, layout.centralDirOffset), file, signature, opts.SigningTime)
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// prepareSignature reads the layout of the unsigned package and creates its author signature.
+func prepareSignature(r io.ReaderAt, size int64, opts *SignOptions) (*archiveLayout, []byte, error) {
+	if err := opts.validate(); err != nil {
+		return nil, nil, err
+	}
+	layout, err := readArchiveLayout(r, size)
+	if err != nil {
+		return nil, nil, err
+	}
+	if layout.signature != nil {
+		return nil, nil, ErrPackageAlreadySigned
+	}
+	hash, err := hashUnsignedContent(r, size, opts.HashAlgorithm)
+	if err != nil {
+		return nil, nil, err
+	}
+	signature, err := createAuthorSignature(&SignatureContent{HashAlgorithm: opts.HashAlgorithm, HashValue: hash}, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return layout, signature, nil
+}
+
+// createAuthorSignature returns the DER encoded .signature.p7s of an author signature over content.
+func createAuthorSignature(content *SignatureContent, opts *SignOptions) ([]byte, error) {
+	h := opts.HashAlgorithm
+	certHash := crypto.SHA256.New()
+	certHash.Write(opts.Certificate.Raw)
+	attributes := make([]attribute, 0, 4)
+	for _, attr := range []struct {
+		oid   asn1.ObjectIdentifier
+		value any
+	}{
+		{oidAttributeContentType, oidData},
+		{oidAttributeSigningTime, opts.SigningTime.UTC()},
+		{oidAttributeCommitmentType, commitmentTypeIndication{CommitmentTypeID: oidProofOfOrigin}},
+		{oidAttributeSigningCertV2, signingCertificateV2{Certs: []essCertIDv2{{CertHash: certHash.Sum(nil)}}}},
+	} {
+		a, err := newAttribute(attr.oid, attr.value)
+		if err != nil {
+			return nil, err
+		}
+		attributes = append(attributes, a)
+	}
+	si, err := newSignerInfo(opts.Certificate, opts.Signer, h, content.Bytes(), attributes)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Timestamper != nil {
+		hasher := h.New()
+		hasher.Write(si.Signature)
+		token, err := opts.Timestamper.Timestamp(h, hasher.Sum(nil))
+		if err != nil {
+			return nil, fmt.Errorf("timestamp signature: %w", err)
+		}
+		timestamp, err := ParseTimestamp(token)
+		if err != nil {
+			return nil, err
+		}
+		if err = timestamp.verifyToken(si.Signature); err != nil {
+			return nil, fmt.Errorf("timestamp signature: %w", err)
+		}
+		tokenAttribute, err := newAttribute(oidAttributeTimeStampToken, asn1.RawValue{FullBytes: token})
+		if err != nil {
+			return nil, err
+		}
+		if si.UnsignedAttributes, err = marshalAttributes(1, []attribute{tokenAttribute}); err != nil {
+			return nil, err
+		}
+	}
+	certs := append([]*x509.Certificate{opts.Certificate}, opts.Intermediates...)
+	return marshalSignedData(oidData, content.Bytes(), si, certs)
+}
+
+// writeSignature writes what follows the entries of the unsigned archive once signed: the signature file,
+// the central directory with its record appended and the end of central directory.
+func (l *archiveLayout) writeSignature(w io.Writer, r io.ReaderAt, signature []byte, modified time.Time) error {
+	// The central directory is read first, SignFile overwrites it.
+	centralDir := make([]byte, l.centralDirSize)
+	if _, err := r.ReadAt(centralDir, l.centralDirOffset); err != nil {
+		return err
+	}
+	localOffset := l.centralDirOffset
+	centralDirOffset := localOffset + localFileHeaderLen + int64(len(SignatureFileName)) + int64(len(signature))
+	centralDirSize := l.centralDirSize + centralDirectoryHeaderLen + int64(len(SignatureFileName))
+	if centralDirOffset+centralDirSize > 0xffffffff || len(l.records)+1 >= 0xffff {
+		return ErrZip64NotSupported
+	}
+	modTime, modDate := msDosTime(modified)
+	crc := crc32.ChecksumIEEE(signature)
+
+	local := make([]byte, localFileHeaderLen, localFileHeaderLen+len(SignatureFileName))
+	binary.LittleEndian.PutUint32(local[0:], localFileHeaderSignature)
+	binary.LittleEndian.PutUint16(local[4:], zipVersion20)
+	binary.LittleEndian.PutUint16(local[10:], modTime)
+	binary.LittleEndian.PutUint16(local[12:], modDate)
+	binary.LittleEndian.PutUint32(local[14:], crc)
+	binary.LittleEndian.PutUint32(local[18:], uint32(len(signature)))
+	binary.LittleEndian.PutUint32(local[22:], uint32(len(signature)))
+	binary.LittleEndian.PutUint16(local[26:], uint16(len(SignatureFileName)))
+	local = append(local, SignatureFileName...)
+
+	record := make([]byte, centralDirectoryHeaderLen, centralDirectoryHeaderLen+len(SignatureFileName))
+	binary.LittleEndian.PutUint32(record[0:], centralDirectorySignature)
+	binary.LittleEndian.PutUint16(record[4:], zipVersion20)
+	binary.LittleEndian.PutUint16(record[6:], zipVersion20)
+	binary.LittleEndian.PutUint16(record[12:], modTime)
+	binary.LittleEndian.PutUint16(record[14:], modDate)
+	binary.LittleEndian.PutUint32(record[16:], crc)
+	binary.LittleEndian.PutUint32(record[20:], uint32(len(signature)))
+	binary.LittleEndian.PutUint32(record[24:], uint32(len(signature)))
+	binary.LittleEndian.PutUint16(record[28:], uint16(len(SignatureFileName)))
+	binary.LittleEndian.PutUint32(record[42:], uint32(localOffset))
+	record = append(record, SignatureFileName...)
+
+	eocd := append([]byte(nil), l.eocd...)
+	entries := uint16(len(l.records) + 1)
+	binary.LittleEndian.PutUint16(eocd[8:], entries)
+	binary.LittleEndian.PutUint16(eocd[10:], entries)
+	binary.LittleEndian.PutUint32(eocd[12:], uint32(centralDirSize))
+	binary.LittleEndian.PutUint32(eocd[16:], uint32(centralDirOffset))
+
+	for _, part := range [][]byte{local, signature, centralDir, record, eocd} {
+		if _, err := w.Write(part); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// msDosTime returns the MS-DOS time and date of t, the timestamps of zip entries.
+func msDosTime(t time.Time) (uint16, uint16) {
+	if t.Year() < 1980 {
+		t = time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+	modTime := uint16(t.Hour()<<11 | t.Minute()<<5 | t.Second()>>1)
+	modDate := uint16((t.Year()-1980)<<9 | int(t.Month())<<5 | t.Day())
+	return modTime, modDate
+}
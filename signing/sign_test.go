@@ -0,0 +1,143 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package signing
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSign(t *testing.T) {
+	pki := newTestPKI(t)
+	unsigned := writeTestArchive(t, nil, testPackageFiles...)
+	timestamper := newTestTimestampServer(t, pki, nil)
+
+	buf := &bytes.Buffer{}
+	err := Sign(bytes.NewReader(unsigned), int64(len(unsigned)), buf, &SignOptions{
+		Certificate: pki.author.cert,
+		Signer:      pki.author.key,
+		Timestamper: timestamper,
+	})
+	require.NoError(t, err)
+	signed := buf.Bytes()
+
+	// the entries are kept as is, only the central directory moves after the signature
+	layout, err := readArchiveLayout(bytes.NewReader(unsigned), int64(len(unsigned)))
+	require.NoError(t, err)
+	require.Equal(t, unsigned[:layout.centralDirOffset], signed[:layout.centralDirOffset])
+
+	archive, err := zip.NewReader(bytes.NewReader(signed), int64(len(signed)))
+	require.NoError(t, err)
+	require.Len(t, archive.File, len(testPackageFiles)+1)
+	for _, file := range archive.File {
+		src, err := file.Open()
+		require.NoError(t, err)
+		_, err = io.Copy(io.Discard, src)
+		require.NoError(t, err, file.Name)
+		require.NoError(t, src.Close())
+	}
+	require.Equal(t, SignatureFileName, archive.File[len(archive.File)-1].Name)
+	require.Equal(t, zip.Store, archive.File[len(archive.File)-1].Method)
+
+	result, err := Verify(bytes.NewReader(signed), int64(len(signed)), &VerifyOptions{Roots: pki.roots, RequireTimestamp: true})
+	require.NoError(t, err)
+	require.True(t, result.Valid(), "issues: %v", result.Err())
+	require.Equal(t, SignatureTypeAuthor, result.Signature.Type)
+	require.Len(t, result.Signature.Timestamps, 1)
+
+	err = Sign(bytes.NewReader(signed), int64(len(signed)), io.Discard, &SignOptions{
+		Certificate: pki.author.cert,
+		Signer:      pki.author.key,
+	})
+	require.ErrorIs(t, err, ErrPackageAlreadySigned)
+}
+
+func TestSignFile(t *testing.T) {
+	pki := newTestPKI(t)
+
+	// an RSA author, signing with SHA-512
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	der, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(1000),
+		Subject:      pkix.Name{CommonName: "RSA Author"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	}, pki.root.cert, &key.PublicKey, pki.root.key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "contoso.lib.1.0.0.nupkg")
+	require.NoError(t, os.WriteFile(path, writeTestArchive(t, nil, testPackageFiles...), 0644))
+	opts := &SignOptions{Certificate: cert, Signer: key, HashAlgorithm: crypto.SHA512}
+	require.NoError(t, SignFile(path, opts))
+	require.True(t, opts.SigningTime.IsZero(), "options are not modified")
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	result, err := Verify(bytes.NewReader(data), int64(len(data)), &VerifyOptions{Roots: pki.roots})
+	require.NoError(t, err)
+	require.True(t, result.Valid(), "issues: %v", result.Err())
+	require.Equal(t, crypto.SHA512, result.Signature.HashAlgorithm)
+	require.Equal(t, crypto.SHA512, result.Signature.Content.HashAlgorithm)
+
+	require.ErrorIs(t, SignFile(path, opts), ErrPackageAlreadySigned)
+}
+
+func TestSign_InvalidOptions(t *testing.T) {
+	pki := newTestPKI(t)
+	unsigned := writeTestArchive(t, nil, testPackageFiles...)
+	tests := []struct {
+		name string
+		opts *SignOptions
+		err  string
+	}{
+		{
+			name: "nil",
+			err:  "certificate and signer are required",
+		},
+		{
+			name: "signer mismatch",
+			opts: &SignOptions{Certificate: pki.author.cert, Signer: pki.repository.key},
+			err:  "signer does not match the certificate public key",
+		},
+		{
+			name: "not code signing",
+			opts: &SignOptions{Certificate: pki.tsa.cert, Signer: pki.tsa.key},
+			err:  "certificate is not valid for code signing",
+		},
+		{
+			name: "expired",
+			opts: &SignOptions{Certificate: pki.author.cert, Signer: pki.author.key, SigningTime: time.Now().AddDate(2, 0, 0)},
+			err:  "certificate is not valid at",
+		},
+		{
+			name: "sha1",
+			opts: &SignOptions{Certificate: pki.author.cert, Signer: pki.author.key, HashAlgorithm: crypto.SHA1},
+			err:  "unsupported hash algorithm SHA-1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Sign(bytes.NewReader(unsigned), int64(len(unsigned)), io.Discard, tt.opts)
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+}
@@ -0,0 +1,302 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package signing
+
+import (
+	"archive/zip"
+	"crypto"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrPackageNotSigned The package holds no .signature.p7s.
+var ErrPackageNotSigned = errors.New("package is not signed")
+
+// maxSignatureSize The largest signature file read, NuGet rejects signatures above 1 MiB.
+const maxSignatureSize = 1024 * 1024
+
+// SignatureType Who produced a signature, from its commitment-type-indication attribute.
+type SignatureType int
+
+const (
+	SignatureTypeUnknown SignatureType = iota
+	SignatureTypeAuthor
+	SignatureTypeRepository
+)
+
+func (t SignatureType) String() string {
+	switch t {
+	case SignatureTypeAuthor:
+		return "Author"
+	case SignatureTypeRepository:
+		return "Repository"
+	default:
+		return "Unknown"
+	}
+}
+
+// Signature An author or repository signature, or the repository countersignature of an author signature.
+type Signature struct {
+	Type SignatureType
+
+	// Certificate The signer certificate.
+	Certificate *x509.Certificate
+
+	// Certificates All the certificates of the signature, used as intermediates when building the chain.
+	Certificates []*x509.Certificate
+
+	// SigningTime The signing-time attribute, only informational as the signer controls it.
+	SigningTime time.Time
+
+	HashAlgorithm crypto.Hash
+
+	// Timestamps The RFC 3161 timestamps over the signature value.
+	Timestamps []*Timestamp
+
+	// V3ServiceIndexURL The service index of the repository, repository signatures only.
+	V3ServiceIndexURL string
+
+	// PackageOwners The owners of the package on the repository, repository signatures only.
+	PackageOwners []string
+
+	signer *signerInfo
+	// signed is the content the signer signed, the signature content for primary signatures
+	// and the primary signature value for countersignatures.
+	signed []byte
+}
+
+// Value The signature value, the content its timestamps are over.
+func (s *Signature) Value() []byte {
+	return s.signer.Signature
+}
+
+// PrimarySignature The signature of a package read from its .signature.p7s.
+type PrimarySignature struct {
+	Signature
+
+	// Content The hash of the package the signature covers.
+	Content *SignatureContent
+
+	// Countersignature The repository countersignature of an author signature, nil when absent.
+	Countersignature *Signature
+
+	raw []byte
+}
+
+// Raw The DER encoded .signature.p7s.
+func (s *PrimarySignature) Raw() []byte {
+	return s.raw
+}
+
+// SignatureContent The content a primary signature signs: the hash of the package with the signature file removed.
+// Source: https://github.com/NuGet/Home/wiki/Package-Signatures-Technical-Details#signature-content
+type SignatureContent struct {
+	HashAlgorithm crypto.Hash
+	HashValue     []byte
+}
+
+// Bytes Returns the encoded signature content.
+func (c *SignatureContent) Bytes() []byte {
+	return []byte(fmt.Sprintf("Version:1\r\n\r\n%s-Hash:%s\r\n\r\n",
+		hashOIDs[c.HashAlgorithm], base64.StdEncoding.EncodeToString(c.HashValue)))
+}
+
+// ParseSignatureContent parses the encoded signature content.
+func ParseSignatureContent(data []byte) (*SignatureContent, error) {
+	sections := strings.Split(string(data), "\r\n\r\n")
+	if len(sections) < 2 || sections[0] != "Version:1" {
+		return nil, fmt.Errorf("invalid signature content: unsupported version")
+	}
+	content := &SignatureContent{}
+	for _, line := range strings.Split(sections[1], "\r\n") {
+		name, value, ok := strings.Cut(line, ":")
+		if !ok || !strings.HasSuffix(name, "-Hash") {
+			continue
+		}
+		oid, err := parseOID(strings.TrimSuffix(name, "-Hash"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid signature content: %w", err)
+		}
+		h, err := hashFromOID(oid)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signature content: %w", err)
+		}
+		hash, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signature content: %w", err)
+		}
+		content.HashAlgorithm, content.HashValue = h, hash
+		return content, nil
+	}
+	return nil, fmt.Errorf("invalid signature content: package hash is missing")
+}
+
+func parseOID(s string) (asn1.ObjectIdentifier, error) {
+	var oid asn1.ObjectIdentifier
+	for _, part := range strings.Split(s, ".") {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 || strconv.Itoa(n) != part {
+			return nil, fmt.Errorf("invalid object identifier %q", s)
+		}
+		oid = append(oid, n)
+	}
+	if len(oid) < 2 {
+		return nil, fmt.Errorf("invalid object identifier %q", s)
+	}
+	return oid, nil
+}
+
+// ReadSignature reads and parses the .signature.p7s of the package, ErrPackageNotSigned when it has none.
+// Parsing does not verify the signature, see Verify.
+func ReadSignature(r io.ReaderAt, size int64) (*PrimarySignature, error) {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range archive.File {
+		if file.Name != SignatureFileName {
+			continue
+		}
+		if file.UncompressedSize64 > maxSignatureSize {
+			return nil, fmt.Errorf("invalid signature: %s exceeds %d bytes", SignatureFileName, maxSignatureSize)
+		}
+		src, err := file.Open()
+		if err != nil {
+			return nil, err
+		}
+		data, err := io.ReadAll(io.LimitReader(src, maxSignatureSize+1))
+		_ = src.Close()
+		if err != nil {
+			return nil, err
+		}
+		return ParsePrimarySignature(data)
+	}
+	return nil, ErrPackageNotSigned
+}
+
+// ParsePrimarySignature parses a DER encoded .signature.p7s.
+func ParsePrimarySignature(data []byte) (*PrimarySignature, error) {
+	sd, certs, err := parseSignedData(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(sd.SignerInfos) != 1 {
+		return nil, fmt.Errorf("invalid signature: %d signers, want 1", len(sd.SignerInfos))
+	}
+	if !sd.EncapContentInfo.EContentType.Equal(oidData) {
+		return nil, fmt.Errorf("invalid signature: content type %s is not data", sd.EncapContentInfo.EContentType)
+	}
+	content, err := ParseSignatureContent(sd.EncapContentInfo.EContent)
+	if err != nil {
+		return nil, err
+	}
+	primary := &PrimarySignature{Content: content, raw: data}
+	if err = primary.parse(&sd.SignerInfos[0], certs, sd.EncapContentInfo.EContent); err != nil {
+		return nil, err
+	}
+	if primary.Type == SignatureTypeUnknown {
+		return nil, fmt.Errorf("invalid signature: commitment type indication is missing")
+	}
+
+	unsigned, err := parseAttributes(sd.SignerInfos[0].UnsignedAttributes)
+	if err != nil {
+		return nil, err
+	}
+	for _, raw := range findAttributes(unsigned, oidAttributeCounterSign) {
+		var si signerInfo
+		if _, err = asn1.Unmarshal(raw, &si); err != nil {
+			return nil, fmt.Errorf("invalid countersignature: %w", err)
+		}
+		counter := &Signature{}
+		if err = counter.parse(&si, certs, primary.Value()); err != nil {
+			return nil, fmt.Errorf("invalid countersignature: %w", err)
+		}
+		if counter.Type != SignatureTypeRepository {
+			continue
+		}
+		if primary.Type != SignatureTypeAuthor {
+			return nil, fmt.Errorf("invalid signature: a repository signature cannot be countersigned")
+		}
+		if primary.Countersignature != nil {
+			return nil, fmt.Errorf("invalid signature: multiple repository countersignatures")
+		}
+		primary.Countersignature = counter
+	}
+	return primary, nil
+}
+
+// parse reads the signer, its signed attributes and its timestamps, signed is the content the signer signed.
+func (s *Signature) parse(si *signerInfo, certs []*x509.Certificate, signed []byte) error {
+	var err error
+	s.signer, s.signed, s.Certificates = si, signed, certs
+	if s.HashAlgorithm, err = si.hashAlgorithm(); err != nil {
+		return err
+	}
+	if s.Certificate, err = si.signerCertificate(certs); err != nil {
+		return err
+	}
+	attributes, err := parseAttributes(si.SignedAttributes)
+	if err != nil {
+		return err
+	}
+	s.SigningTime = signingTime(attributes)
+
+	if raw, ok := findAttribute(attributes, oidAttributeCommitmentType); ok {
+		var indication commitmentTypeIndication
+		if _, err = asn1.Unmarshal(raw, &indication); err != nil {
+			return fmt.Errorf("invalid commitment type indication: %w", err)
+		}
+		switch {
+		case indication.CommitmentTypeID.Equal(oidProofOfOrigin):
+			s.Type = SignatureTypeAuthor
+		case indication.CommitmentTypeID.Equal(oidProofOfReceipt):
+			s.Type = SignatureTypeRepository
+		}
+	}
+	if raw, ok := findAttribute(attributes, oidNuGetV3ServiceIndexURL); ok {
+		if _, err = asn1.Unmarshal(raw, &s.V3ServiceIndexURL); err != nil {
+			return fmt.Errorf("invalid v3 service index url: %w", err)
+		}
+	}
+	if raw, ok := findAttribute(attributes, oidNuGetPackageOwners); ok {
+		if _, err = asn1.Unmarshal(raw, &s.PackageOwners); err != nil {
+			return fmt.Errorf("invalid package owners: %w", err)
+		}
+	}
+
+	unsigned, err := parseAttributes(si.UnsignedAttributes)
+	if err != nil {
+		return err
+	}
+	for _, raw := range findAttributes(unsigned, oidAttributeTimeStampToken) {
+		timestamp, err := ParseTimestamp(raw)
+		if err != nil {
+			return err
+		}
+		s.Timestamps = append(s.Timestamps, timestamp)
+	}
+	return nil
+}
+
+// IsSigned True when the package holds a .signature.p7s.
+func IsSigned(r io.ReaderAt, size int64) (bool, error) {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return false, err
+	}
+	for _, file := range archive.File {
+		if file.Name == SignatureFileName {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package signing
+
+import (
+	"bytes"
+	"crypto"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSignatureContent(t *testing.T) {
+	content := &SignatureContent{HashAlgorithm: crypto.SHA256, HashValue: []byte{1, 2, 3}}
+	require.Equal(t, "Version:1\r\n\r\n2.16.840.1.101.3.4.2.1-Hash:AQID\r\n\r\n", string(content.Bytes()))
+
+	parsed, err := ParseSignatureContent(content.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, content, parsed)
+
+	tests := []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{
+			name:    "version",
+			content: "Version:2\r\n\r\n2.16.840.1.101.3.4.2.1-Hash:AQID\r\n\r\n",
+			err:     "invalid signature content: unsupported version",
+		},
+		{
+			name:    "missing hash",
+			content: "Version:1\r\n\r\n",
+			err:     "invalid signature content: package hash is missing",
+		},
+		{
+			name:    "sha1",
+			content: "Version:1\r\n\r\n1.3.14.3.2.26-Hash:AQID\r\n\r\n",
+			err:     "invalid signature content: unsupported hash algorithm 1.3.14.3.2.26",
+		},
+		{
+			name:    "oid",
+			content: "Version:1\r\n\r\nsha256-Hash:AQID\r\n\r\n",
+			err:     `invalid signature content: invalid object identifier "sha256"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseSignatureContent([]byte(tt.content))
+			require.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func TestReadSignature(t *testing.T) {
+	pki := newTestPKI(t)
+	at := time.Now().Add(-time.Minute).Truncate(time.Second)
+	data := pki.signTestPackage(t,
+		&testSigner{id: pki.author, commitment: oidProofOfOrigin, timestamp: at},
+		&testSigner{id: pki.repository, commitment: oidProofOfReceipt, timestamp: at, extra: repositoryAttributes(t)},
+	)
+
+	signed, err := IsSigned(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	require.True(t, signed)
+
+	signature, err := ReadSignature(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	require.Equal(t, SignatureTypeAuthor, signature.Type)
+	require.Equal(t, "Author", signature.Type.String())
+	require.Equal(t, pki.author.cert, signature.Certificate)
+	require.Equal(t, crypto.SHA256, signature.HashAlgorithm)
+	require.Equal(t, crypto.SHA256, signature.Content.HashAlgorithm)
+	require.False(t, signature.SigningTime.IsZero())
+	require.NotEmpty(t, signature.Raw())
+	require.Len(t, signature.Timestamps, 1)
+	require.True(t, at.Equal(signature.Timestamps[0].GeneralizedTime))
+	require.Equal(t, time.Second, signature.Timestamps[0].Accuracy)
+	require.Equal(t, pki.tsa.cert, signature.Timestamps[0].Certificate)
+	require.Equal(t, oidTestPolicy, signature.Timestamps[0].Policy)
+
+	counter := signature.Countersignature
+	require.NotNil(t, counter)
+	require.Equal(t, SignatureTypeRepository, counter.Type)
+	require.Equal(t, pki.repository.cert, counter.Certificate)
+	require.Equal(t, "https://api.nuget.org/v3/index.json", counter.V3ServiceIndexURL)
+	require.Equal(t, []string{"kevin", "contoso"}, counter.PackageOwners)
+	require.Len(t, counter.Timestamps, 1)
+
+	unsigned := writeTestArchive(t, nil, testPackageFiles...)
+	signed, err = IsSigned(bytes.NewReader(unsigned), int64(len(unsigned)))
+	require.NoError(t, err)
+	require.False(t, signed)
+	_, err = ReadSignature(bytes.NewReader(unsigned), int64(len(unsigned)))
+	require.ErrorIs(t, err, ErrPackageNotSigned)
+
+	invalid := writeTestArchive(t, []byte("invalid"), testPackageFiles...)
+	_, err = ReadSignature(bytes.NewReader(invalid), int64(len(invalid)))
+	require.ErrorContains(t, err, "invalid signature")
+}
+
+func TestParsePrimarySignature_Repository(t *testing.T) {
+	pki := newTestPKI(t)
+	content := (&SignatureContent{HashAlgorithm: crypto.SHA256, HashValue: make([]byte, 32)}).Bytes()
+	signature, err := ParsePrimarySignature(pki.signature(t, content,
+		&testSigner{id: pki.repository, commitment: oidProofOfReceipt, extra: repositoryAttributes(t)}, nil))
+	require.NoError(t, err)
+	require.Equal(t, SignatureTypeRepository, signature.Type)
+	require.Nil(t, signature.Countersignature)
+	require.Empty(t, signature.Timestamps)
+	require.Equal(t, []string{"kevin", "contoso"}, signature.PackageOwners)
+
+	// a repository signature cannot be countersigned by a repository
+	_, err = ParsePrimarySignature(pki.signature(t, content,
+		&testSigner{id: pki.repository, commitment: oidProofOfReceipt, extra: repositoryAttributes(t)},
+		&testSigner{id: pki.repository, commitment: oidProofOfReceipt, extra: repositoryAttributes(t)}))
+	require.EqualError(t, err, "invalid signature: a repository signature cannot be countersigned")
+
+	_, err = ParsePrimarySignature(pki.signature(t, content, &testSigner{id: pki.author}, nil))
+	require.EqualError(t, err, "invalid signature: commitment type indication is missing")
+}
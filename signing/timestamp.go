@@ -0,0 +1,139 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package signing
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// Timestamp An RFC 3161 timestamp token over a signature value.
+type Timestamp struct {
+	// GeneralizedTime The time the timestamp authority vouches the signature existed at.
+	GeneralizedTime time.Time
+
+	// Accuracy The accuracy of GeneralizedTime, zero when unspecified.
+	Accuracy time.Duration
+
+	SerialNumber *big.Int
+	Policy       asn1.ObjectIdentifier
+
+	// Nonce The nonce of the timestamp request, nil when the request had none.
+	Nonce *big.Int
+
+	// HashAlgorithm and HashedMessage The message imprint, the hash of the timestamped signature value.
+	HashAlgorithm crypto.Hash
+	HashedMessage []byte
+
+	// Certificate The timestamp authority certificate.
+	Certificate *x509.Certificate
+
+	// Certificates All the certificates of the timestamp token.
+	Certificates []*x509.Certificate
+
+	signer  *signerInfo
+	content []byte
+	raw     []byte
+}
+
+// Raw The DER encoded timestamp token.
+func (t *Timestamp) Raw() []byte {
+	return t.raw
+}
+
+// tstInfo Source: https://www.rfc-editor.org/rfc/rfc3161#section-2.4.2
+type tstInfo struct {
+	Version        int
+	Policy         asn1.ObjectIdentifier
+	MessageImprint messageImprint
+	SerialNumber   *big.Int
+	GenTime        time.Time     `asn1:"generalized"`
+	Accuracy       accuracy      `asn1:"optional"`
+	Ordering       bool          `asn1:"optional,default:false"`
+	Nonce          *big.Int      `asn1:"optional"`
+	TSA            asn1.RawValue `asn1:"explicit,optional,tag:0"`
+	Extensions     asn1.RawValue `asn1:"optional,tag:1"`
+}
+
+type messageImprint struct {
+	HashAlgorithm pkix.AlgorithmIdentifier
+	HashedMessage []byte
+}
+
+type accuracy struct {
+	Seconds int `asn1:"optional"`
+	Millis  int `asn1:"optional,tag:0"`
+	Micros  int `asn1:"optional,tag:1"`
+}
+
+func (a accuracy) duration() time.Duration {
+	return time.Duration(a.Seconds)*time.Second + time.Duration(a.Millis)*time.Millisecond +
+		time.Duration(a.Micros)*time.Microsecond
+}
+
+// ParseTimestamp parses a DER encoded RFC 3161 timestamp token.
+func ParseTimestamp(data []byte) (*Timestamp, error) {
+	sd, certs, err := parseSignedData(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timestamp: %w", err)
+	}
+	if len(sd.SignerInfos) != 1 {
+		return nil, fmt.Errorf("invalid timestamp: %d signers, want 1", len(sd.SignerInfos))
+	}
+	if !sd.EncapContentInfo.EContentType.Equal(oidTSTInfo) {
+		return nil, fmt.Errorf("invalid timestamp: content type %s is not TSTInfo", sd.EncapContentInfo.EContentType)
+	}
+	var info tstInfo
+	if _, err = asn1.Unmarshal(sd.EncapContentInfo.EContent, &info); err != nil {
+		return nil, fmt.Errorf("invalid timestamp: %w", err)
+	}
+	h, err := hashFromOID(info.MessageImprint.HashAlgorithm.Algorithm)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timestamp: %w", err)
+	}
+	timestamp := &Timestamp{
+		GeneralizedTime: info.GenTime,
+		Accuracy:        info.Accuracy.duration(),
+		SerialNumber:    info.SerialNumber,
+		Policy:          info.Policy,
+		Nonce:           info.Nonce,
+		HashAlgorithm:   h,
+		HashedMessage:   info.MessageImprint.HashedMessage,
+		Certificates:    certs,
+		signer:          &sd.SignerInfos[0],
+		content:         sd.EncapContentInfo.EContent,
+		raw:             data,
+	}
+	if timestamp.Certificate, err = timestamp.signer.signerCertificate(certs); err != nil {
+		return nil, fmt.Errorf("invalid timestamp: %w", err)
+	}
+	return timestamp, nil
+}
+
+// verify checks the timestamp is over value, is signed by its timestamp authority and
+// that the authority chains to roots at the time of the timestamp.
+func (t *Timestamp) verify(value []byte, roots *x509.CertPool) error {
+	if err := t.verifyToken(value); err != nil {
+		return err
+	}
+	return verifyChain(t.Certificate, t.Certificates, roots, t.GeneralizedTime, x509.ExtKeyUsageTimeStamping)
+}
+
+// verifyToken checks the timestamp is over value and is signed by its timestamp authority.
+func (t *Timestamp) verifyToken(value []byte) error {
+	hasher := t.HashAlgorithm.New()
+	hasher.Write(value)
+	if !bytes.Equal(hasher.Sum(nil), t.HashedMessage) {
+		return fmt.Errorf("message imprint does not match the signature")
+	}
+	_, err := t.signer.verify(t.content, t.Certificate)
+	return err
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package signing
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+)
+
+// Timestamper Requests RFC 3161 timestamp tokens, digest is the hash of the timestamped signature value.
+// Returns the DER encoded timestamp token.
+type Timestamper interface {
+	Timestamp(h crypto.Hash, digest []byte) ([]byte, error)
+}
+
+// TimestampClient A timestamp authority reached over HTTP.
+// Source: https://www.rfc-editor.org/rfc/rfc3161#section-3.4
+type TimestampClient struct {
+	// URL The timestamp authority endpoint.
+	URL string
+
+	// HTTPClient The client requests are sent with, http.DefaultClient when nil.
+	HTTPClient *http.Client
+}
+
+// NewTimestampClient returns a client of the timestamp authority at url.
+func NewTimestampClient(url string) *TimestampClient {
+	return &TimestampClient{URL: url}
+}
+
+type timeStampReq struct {
+	Version        int
+	MessageImprint messageImprint
+	ReqPolicy      asn1.ObjectIdentifier `asn1:"optional"`
+	Nonce          *big.Int              `asn1:"optional"`
+	CertReq        bool                  `asn1:"optional"`
+}
+
+type timeStampResp struct {
+	Status         pkiStatusInfo
+	TimeStampToken asn1.RawValue `asn1:"optional"`
+}
+
+type pkiStatusInfo struct {
+	Status       int
+	StatusString []string       `asn1:"optional"`
+	FailInfo     asn1.BitString `asn1:"optional"`
+}
+
+// Timestamp requests a timestamp token over digest and checks the token answers the request.
+func (c *TimestampClient) Timestamp(h crypto.Hash, digest []byte) ([]byte, error) {
+	hashOID, ok := hashOIDs[h]
+	if !ok {
+		return nil, fmt.Errorf("unsupported hash algorithm %s", h)
+	}
+	nonce, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 64))
+	if err != nil {
+		return nil, err
+	}
+	body, err := asn1.Marshal(timeStampReq{
+		Version: 1,
+		MessageImprint: messageImprint{
+			HashAlgorithm: pkix.AlgorithmIdentifier{Algorithm: hashOID, Parameters: asn1.NullRawValue},
+			HashedMessage: digest,
+		},
+		Nonce:   nonce,
+		CertReq: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Post(c.URL, "application/timestamp-query", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("timestamp authority %s: %s", c.URL, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxSignatureSize))
+	if err != nil {
+		return nil, err
+	}
+
+	var response timeStampResp
+	if _, err = asn1.Unmarshal(data, &response); err != nil {
+		return nil, fmt.Errorf("invalid timestamp response: %w", err)
+	}
+	// granted or grantedWithMods
+	if response.Status.Status > 1 {
+		return nil, fmt.Errorf("timestamp request rejected with status %d: %s",
+			response.Status.Status, strings.Join(response.Status.StatusString, ", "))
+	}
+	token := response.TimeStampToken.FullBytes
+	timestamp, err := ParseTimestamp(token)
+	if err != nil {
+		return nil, err
+	}
+	if timestamp.HashAlgorithm != h || !bytes.Equal(timestamp.HashedMessage, digest) {
+		return nil, fmt.Errorf("timestamp message imprint does not match the request")
+	}
+	if timestamp.Nonce == nil || timestamp.Nonce.Cmp(nonce) != 0 {
+		return nil, fmt.Errorf("timestamp nonce does not match the request")
+	}
+	return token, nil
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package signing
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"encoding/asn1"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newTestTimestampServer serves the timestamp authority of the pki, respond may alter the token of the response.
+func newTestTimestampServer(t *testing.T, pki *testPKI, respond func(resp *timeStampResp)) *TimestampClient {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "application/timestamp-query", r.Header.Get("Content-Type"))
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		var req timeStampReq
+		_, err = asn1.Unmarshal(body, &req)
+		require.NoError(t, err)
+		require.True(t, req.CertReq)
+
+		token := pki.timestampToken(t, req.MessageImprint.HashedMessage, req.Nonce, time.Now())
+		resp := &timeStampResp{TimeStampToken: asn1.RawValue{FullBytes: token}}
+		if respond != nil {
+			respond(resp)
+		}
+		der, err := asn1.Marshal(*resp)
+		require.NoError(t, err)
+		w.Header().Set("Content-Type", "application/timestamp-reply")
+		_, _ = w.Write(der)
+	}))
+	t.Cleanup(server.Close)
+	return NewTimestampClient(server.URL)
+}
+
+func TestTimestampClient_Timestamp(t *testing.T) {
+	pki := newTestPKI(t)
+	digest := sha256.Sum256([]byte("signature"))
+
+	token, err := newTestTimestampServer(t, pki, nil).Timestamp(crypto.SHA256, digest[:])
+	require.NoError(t, err)
+	timestamp, err := ParseTimestamp(token)
+	require.NoError(t, err)
+	require.NoError(t, timestamp.verify([]byte("signature"), pki.roots))
+	require.NotNil(t, timestamp.Nonce)
+
+	tests := []struct {
+		name    string
+		respond func(resp *timeStampResp)
+		err     string
+	}{
+		{
+			name: "rejected",
+			respond: func(resp *timeStampResp) {
+				resp.Status = pkiStatusInfo{Status: 2, StatusString: []string{"bad request"}}
+				resp.TimeStampToken = asn1.RawValue{}
+			},
+			err: "timestamp request rejected with status 2: bad request",
+		},
+		{
+			name: "nonce",
+			respond: func(resp *timeStampResp) {
+				resp.TimeStampToken.FullBytes = pki.timestampToken(t, digest[:], big.NewInt(1), time.Now())
+			},
+			err: "timestamp nonce does not match the request",
+		},
+		{
+			name: "message imprint",
+			respond: func(resp *timeStampResp) {
+				resp.TimeStampToken.FullBytes = pki.timestampToken(t, make([]byte, 32), nil, time.Now())
+			},
+			err: "timestamp message imprint does not match the request",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newTestTimestampServer(t, pki, tt.respond).Timestamp(crypto.SHA256, digest[:])
+			require.EqualError(t, err, tt.err)
+		})
+	}
+
+	_, err = NewTimestampClient("http://127.0.0.1:0").Timestamp(crypto.SHA1, digest[:])
+	require.EqualError(t, err, "unsupported hash algorithm SHA-1")
+}
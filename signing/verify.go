@@ -0,0 +1,220 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package signing
+
+import (
+	"bytes"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+var (
+	// ErrPackageTampered The package content does not match the hash its signature covers.
+	ErrPackageTampered = errors.New("package content does not match its signature")
+
+	// ErrInvalidSignature The signature is malformed or its value does not verify.
+	ErrInvalidSignature = errors.New("invalid package signature")
+
+	// ErrUntrustedCertificate The signer certificate does not chain to the trusted roots.
+	ErrUntrustedCertificate = errors.New("signing certificate is not trusted")
+
+	// ErrInvalidTimestamp A timestamp of the signature does not verify.
+	ErrInvalidTimestamp = errors.New("invalid signature timestamp")
+
+	// ErrTimestampRequired The signature holds no valid timestamp while VerifyOptions.RequireTimestamp is set.
+	ErrTimestampRequired = errors.New("signature timestamp is required")
+)
+
+// VerifyOptions How Verify validates a package signature.
+type VerifyOptions struct {
+	// Roots The roots the signer certificates must chain to, the system roots when nil.
+	Roots *x509.CertPool
+
+	// TimestampRoots The roots the timestamp authorities must chain to, Roots when nil.
+	TimestampRoots *x509.CertPool
+
+	// AllowUnsigned Unsigned packages verify without issue.
+	AllowUnsigned bool
+
+	// RequireTimestamp Report signatures without a valid timestamp.
+	RequireTimestamp bool
+
+	// CurrentTime The time certificates are validated at when a signature has no valid timestamp, time.Now when zero.
+	// A valid timestamp validates the signer certificate at the timestamp time instead,
+	// so that signatures outlive the expiration of their certificate.
+	CurrentTime time.Time
+}
+
+func (o *VerifyOptions) now() time.Time {
+	if o.CurrentTime.IsZero() {
+		return time.Now()
+	}
+	return o.CurrentTime
+}
+
+func (o *VerifyOptions) timestampRoots() *x509.CertPool {
+	if o.TimestampRoots != nil {
+		return o.TimestampRoots
+	}
+	return o.Roots
+}
+
+// VerificationIssue A reason the package signature is not valid.
+type VerificationIssue struct {
+	// Type The signature the issue is about, SignatureTypeUnknown for the package itself.
+	Type SignatureType
+
+	// Countersignature True when the issue is about the repository countersignature.
+	Countersignature bool
+
+	Err error
+}
+
+func (i *VerificationIssue) Error() string {
+	switch {
+	case i.Countersignature:
+		return fmt.Sprintf("repository countersignature: %v", i.Err)
+	case i.Type != SignatureTypeUnknown:
+		return fmt.Sprintf("%s signature: %v", i.Type, i.Err)
+	default:
+		return i.Err.Error()
+	}
+}
+
+func (i *VerificationIssue) Unwrap() error {
+	return i.Err
+}
+
+// VerificationResult The outcome of Verify.
+type VerificationResult struct {
+	// Signed True when the package holds a .signature.p7s.
+	Signed bool
+
+	// Signature The parsed signature, nil when unsigned or unreadable.
+	Signature *PrimarySignature
+
+	Issues []*VerificationIssue
+}
+
+// Valid True when the verification found no issue.
+func (r *VerificationResult) Valid() bool {
+	return len(r.Issues) == 0
+}
+
+// Err Returns the issues joined, nil when valid.
+func (r *VerificationResult) Err() error {
+	errs := make([]error, 0, len(r.Issues))
+	for _, issue := range r.Issues {
+		errs = append(errs, issue)
+	}
+	return errors.Join(errs...)
+}
+
+func (r *VerificationResult) addIssue(t SignatureType, countersignature bool, err error) {
+	r.Issues = append(r.Issues, &VerificationIssue{Type: t, Countersignature: countersignature, Err: err})
+}
+
+// Verify reads the signature of the package and validates it: the package content must match the signed hash,
+// the primary signature and its repository countersignature must verify, their timestamps must be over
+// their signature value and all the certificates must chain to the trusted roots.
+// The problems found are reported as issues of the result, the error is for unreadable packages.
+func Verify(r io.ReaderAt, size int64, opts *VerifyOptions) (*VerificationResult, error) {
+	if opts == nil {
+		opts = &VerifyOptions{}
+	}
+	layout, err := readArchiveLayout(r, size)
+	if err != nil {
+		return nil, err
+	}
+	result := &VerificationResult{}
+	if layout.signature == nil {
+		if !opts.AllowUnsigned {
+			result.addIssue(SignatureTypeUnknown, false, ErrPackageNotSigned)
+		}
+		return result, nil
+	}
+	result.Signed = true
+
+	signature, err := ReadSignature(r, size)
+	if err != nil {
+		result.addIssue(SignatureTypeUnknown, false, fmt.Errorf("%w: %v", ErrInvalidSignature, err))
+		return result, nil
+	}
+	result.Signature = signature
+
+	hasher := signature.Content.HashAlgorithm.New()
+	if err = layout.writeUnsignedContent(hasher, r); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(hasher.Sum(nil), signature.Content.HashValue) {
+		result.addIssue(SignatureTypeUnknown, false, ErrPackageTampered)
+	}
+
+	for _, err = range verifySigner(&signature.Signature, opts) {
+		result.addIssue(signature.Type, false, err)
+	}
+	if signature.Countersignature != nil {
+		for _, err = range verifySigner(signature.Countersignature, opts) {
+			result.addIssue(SignatureTypeRepository, true, err)
+		}
+	}
+	return result, nil
+}
+
+// verifySigner validates the signer of s, its timestamps and its certificate chain.
+func verifySigner(s *Signature, opts *VerifyOptions) []error {
+	var errs []error
+	attributes, err := s.signer.verify(s.signed, s.Certificate)
+	if err == nil {
+		err = verifySigningCertificate(attributes, s.Certificate)
+	}
+	if err != nil {
+		errs = append(errs, fmt.Errorf("%w: %v", ErrInvalidSignature, err))
+	}
+	if s.Type == SignatureTypeRepository && s.V3ServiceIndexURL == "" {
+		errs = append(errs, fmt.Errorf("%w: v3 service index url is missing", ErrInvalidSignature))
+	}
+
+	at := opts.now()
+	var timestamped bool
+	for _, timestamp := range s.Timestamps {
+		if err = timestamp.verify(s.Value(), opts.timestampRoots()); err != nil {
+			errs = append(errs, fmt.Errorf("%w: %v", ErrInvalidTimestamp, err))
+			continue
+		}
+		if !timestamped {
+			at, timestamped = timestamp.GeneralizedTime, true
+		}
+	}
+	if !timestamped && opts.RequireTimestamp {
+		errs = append(errs, ErrTimestampRequired)
+	}
+
+	if err = verifyChain(s.Certificate, s.Certificates, opts.Roots, at, x509.ExtKeyUsageCodeSigning); err != nil {
+		errs = append(errs, fmt.Errorf("%w: %v", ErrUntrustedCertificate, err))
+	}
+	return errs
+}
+
+// verifyChain checks leaf chains to roots at the time at, through the certificates of the signature.
+func verifyChain(leaf *x509.Certificate, certs []*x509.Certificate, roots *x509.CertPool, at time.Time,
+	usage x509.ExtKeyUsage) error {
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs {
+		if cert != leaf {
+			intermediates.AddCert(cert)
+		}
+	}
+	_, err := leaf.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   at,
+		KeyUsages:     []x509.ExtKeyUsage{usage},
+	})
+	return err
+}
@@ -0,0 +1,157 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package signing
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// repositoryAttributes returns the v3 service index url and package owners attributes of a repository signature.
+func repositoryAttributes(t *testing.T) []attribute {
+	return []attribute{
+		marshalAttribute(t, oidNuGetV3ServiceIndexURL, "https://api.nuget.org/v3/index.json"),
+		marshalAttribute(t, oidNuGetPackageOwners, []string{"kevin", "contoso"}),
+	}
+}
+
+func TestVerify(t *testing.T) {
+	pki := newTestPKI(t)
+	now := time.Now()
+	expired := newTestLeaf(t, pki.root, "Expired Author", x509.ExtKeyUsageCodeSigning,
+		now.AddDate(-2, 0, 0), now.AddDate(-1, 0, 0))
+	other := newTestPKI(t)
+
+	author := &testSigner{id: pki.author, commitment: oidProofOfOrigin}
+	repository := &testSigner{id: pki.repository, commitment: oidProofOfReceipt, extra: repositoryAttributes(t)}
+
+	tests := []struct {
+		name string
+		data []byte
+		opts *VerifyOptions
+		// errs are the errors of the issues, valid when empty
+		errs []error
+	}{
+		{
+			name: "author",
+			data: pki.signTestPackage(t, author, nil),
+			opts: &VerifyOptions{Roots: pki.roots},
+		},
+		{
+			name: "author countersigned with timestamps",
+			data: pki.signTestPackage(t,
+				&testSigner{id: pki.author, commitment: oidProofOfOrigin, timestamp: now},
+				&testSigner{id: pki.repository, commitment: oidProofOfReceipt, timestamp: now, extra: repositoryAttributes(t)}),
+			opts: &VerifyOptions{Roots: pki.roots, RequireTimestamp: true},
+		},
+		{
+			name: "repository",
+			data: pki.signTestPackage(t, repository, nil),
+			opts: &VerifyOptions{Roots: pki.roots},
+		},
+		{
+			name: "untrusted root",
+			data: pki.signTestPackage(t, author, repository),
+			opts: &VerifyOptions{Roots: other.roots},
+			errs: []error{ErrUntrustedCertificate, ErrUntrustedCertificate},
+		},
+		{
+			name: "expired timestamped at the validity of the certificate",
+			data: pki.signTestPackage(t, &testSigner{id: expired, commitment: oidProofOfOrigin, timestamp: now.AddDate(-1, -6, 0)}, nil),
+			opts: &VerifyOptions{Roots: pki.roots},
+		},
+		{
+			name: "expired without timestamp",
+			data: pki.signTestPackage(t, &testSigner{id: expired, commitment: oidProofOfOrigin}, nil),
+			opts: &VerifyOptions{Roots: pki.roots},
+			errs: []error{ErrUntrustedCertificate},
+		},
+		{
+			name: "expired timestamped after the validity of the certificate",
+			data: pki.signTestPackage(t, &testSigner{id: expired, commitment: oidProofOfOrigin, timestamp: now}, nil),
+			opts: &VerifyOptions{Roots: pki.roots},
+			errs: []error{ErrUntrustedCertificate},
+		},
+		{
+			name: "timestamp required",
+			data: pki.signTestPackage(t, author, nil),
+			opts: &VerifyOptions{Roots: pki.roots, RequireTimestamp: true},
+			errs: []error{ErrTimestampRequired},
+		},
+		{
+			name: "untrusted timestamp authority",
+			data: pki.signTestPackage(t, &testSigner{id: pki.author, commitment: oidProofOfOrigin, timestamp: now}, nil),
+			opts: &VerifyOptions{Roots: pki.roots, TimestampRoots: other.roots},
+			errs: []error{ErrInvalidTimestamp},
+		},
+		{
+			name: "repository without service index",
+			data: pki.signTestPackage(t, &testSigner{id: pki.repository, commitment: oidProofOfReceipt}, nil),
+			opts: &VerifyOptions{Roots: pki.roots},
+			errs: []error{ErrInvalidSignature},
+		},
+		{
+			name: "unsigned",
+			data: writeTestArchive(t, nil, testPackageFiles...),
+			errs: []error{ErrPackageNotSigned},
+		},
+		{
+			name: "unsigned allowed",
+			data: writeTestArchive(t, nil, testPackageFiles...),
+			opts: &VerifyOptions{AllowUnsigned: true},
+		},
+		{
+			name: "invalid signature file",
+			data: writeTestArchive(t, []byte("invalid"), testPackageFiles...),
+			errs: []error{ErrInvalidSignature},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Verify(bytes.NewReader(tt.data), int64(len(tt.data)), tt.opts)
+			require.NoError(t, err)
+			require.Len(t, result.Issues, len(tt.errs), "issues: %v", result.Err())
+			for i, want := range tt.errs {
+				require.ErrorIs(t, result.Issues[i], want)
+			}
+			require.Equal(t, len(tt.errs) == 0, result.Valid())
+		})
+	}
+}
+
+func TestVerify_Tampered(t *testing.T) {
+	pki := newTestPKI(t)
+	data := pki.signTestPackage(t, &testSigner{id: pki.author, commitment: oidProofOfOrigin}, nil)
+
+	// the modification time of the first entry
+	data[10] ^= 0xff
+	result, err := Verify(bytes.NewReader(data), int64(len(data)), &VerifyOptions{Roots: pki.roots})
+	require.NoError(t, err)
+	require.True(t, result.Signed)
+	require.NotNil(t, result.Signature)
+	require.Len(t, result.Issues, 1)
+	require.ErrorIs(t, result.Err(), ErrPackageTampered)
+	require.Equal(t, "package content does not match its signature", result.Issues[0].Error())
+}
+
+func TestVerify_InvalidSignatureValue(t *testing.T) {
+	pki := newTestPKI(t)
+	content := (&SignatureContent{HashAlgorithm: crypto.SHA256, HashValue: make([]byte, 32)}).Bytes()
+	signature := pki.signature(t, content, &testSigner{id: pki.author, commitment: oidProofOfOrigin}, nil)
+	parsed, err := ParsePrimarySignature(signature)
+	require.NoError(t, err)
+	parsed.signer.Signature[len(parsed.signer.Signature)-1] ^= 0xff
+
+	errs := verifySigner(&parsed.Signature, &VerifyOptions{Roots: pki.roots})
+	require.Len(t, errs, 1)
+	require.ErrorIs(t, errs[0], ErrInvalidSignature)
+	issue := &VerificationIssue{Type: parsed.Type, Err: errs[0]}
+	require.Contains(t, issue.Error(), "Author signature: invalid package signature")
+}
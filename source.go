@@ -0,0 +1,64 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package nuget
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/huhouhua/go-nuget/config"
+)
+
+// NewClientFromPackageSource returns a new NuGet API client for a package source of a NuGet.config,
+// authenticated with the credentials of the source. Options are applied after the source ones.
+// A plain http source is only allowed when its allowInsecureConnections is set.
+func NewClientFromPackageSource(source *config.PackageSource, options ...ClientOptionFunc) (*Client, error) {
+	if source == nil {
+		return nil, fmt.Errorf("package source is required")
+	}
+	if !source.IsHTTP() {
+		return nil, fmt.Errorf("package source %s is not an http source: %s", source.Name, source.Source)
+	}
+	if !source.IsHTTPS() && !source.AllowInsecureConnections {
+		return nil, fmt.Errorf(
+			"package source %s uses http, set allowInsecureConnections to allow it: %s", source.Name, source.Source)
+	}
+	opts := []ClientOptionFunc{WithSourceURL(source.Source)}
+	if source.Credentials != nil {
+		password, err := source.Credentials.GetPassword()
+		if err != nil {
+			return nil, fmt.Errorf("package source %s: %w", source.Name, err)
+		}
+		opts = append(opts, WithBasicAuth(source.Credentials.GetUsername(), password))
+	}
+	client, err := newClient(append(opts, options...)...)
+	if err != nil {
+		return nil, fmt.Errorf("package source %s: %w", source.Name, err)
+	}
+	return client, nil
+}
+
+// NewClientsFromSettings returns a NuGet API client for every enabled http source of the settings,
+// keyed by source name. Local folder sources and sources of protocol version 2 are skipped. The clients of the
+// other sources are returned together with the joined errors of the sources that failed.
+func NewClientsFromSettings(settings *config.Settings, options ...ClientOptionFunc) (map[string]*Client, error) {
+	if settings == nil {
+		return nil, fmt.Errorf("settings are required")
+	}
+	clients := make(map[string]*Client)
+	var errs []error
+	for _, source := range settings.EnabledPackageSources() {
+		if !source.IsHTTP() || source.ProtocolVersion == 2 {
+			continue
+		}
+		client, err := NewClientFromPackageSource(source, options...)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		clients[source.Name] = client
+	}
+	return clients, errors.Join(errs...)
+}
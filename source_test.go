@@ -0,0 +1,92 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package nuget
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/huhouhua/go-nuget/config"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewClientFromPackageSource(t *testing.T) {
+	mux, server := createHttpServer(t, index_V3)
+	mux.HandleFunc("/private/v3/index.json", func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		if !ok || username != "kevin" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		mustWriteHTTPResponse(t, w, index_V3)
+	})
+	t.Setenv("GO_NUGET_TEST_PASSWORD", "secret")
+
+	source := config.NewPackageSource("private", fmt.Sprintf("%s/private/v3/index.json", server.URL))
+	source.Credentials = config.NewPackageSourceCredential("kevin", "%GO_NUGET_TEST_PASSWORD%")
+	_, err := NewClientFromPackageSource(source)
+	require.Equal(t, fmt.Errorf(
+		"package source private uses http, set allowInsecureConnections to allow it: %s", source.Source), err)
+
+	source.AllowInsecureConnections = true
+	client, err := NewClientFromPackageSource(source)
+	require.NoError(t, err)
+	require.Equal(t, source.Source, client.SourceURL().String())
+	require.Equal(t, "kevin", client.username)
+	require.Equal(t, "secret", client.password)
+
+	source.Credentials = nil
+	_, err = NewClientFromPackageSource(source, WithoutRetries())
+	require.ErrorContains(t, err, "package source private: ")
+
+	_, err = NewClientFromPackageSource(config.NewPackageSource("local", "./packages"))
+	require.Equal(t, errors.New("package source local is not an http source: ./packages"), err)
+
+	_, err = NewClientFromPackageSource(nil)
+	require.Equal(t, errors.New("package source is required"), err)
+}
+
+func TestNewClientsFromSettings(t *testing.T) {
+	_, server := createHttpServer(t, index_V3)
+
+	settings := config.NewSettings()
+	sourceURL := fmt.Sprintf("%s/v3/index.json", server.URL)
+	require.NoError(t, settings.SetPackageSource(
+		&config.PackageSource{Name: "first", Source: sourceURL, AllowInsecureConnections: true, IsEnabled: true}))
+	require.NoError(t, settings.SetPackageSource(config.NewPackageSource("local", "/var/packages")))
+	require.NoError(t, settings.SetPackageSource(&config.PackageSource{Name: "disabled", Source: sourceURL}))
+	require.NoError(t, settings.SetPackageSource(&config.PackageSource{
+		Name:                     "v2",
+		Source:                   fmt.Sprintf("%s/api/v2", server.URL),
+		ProtocolVersion:          2,
+		AllowInsecureConnections: true,
+		IsEnabled:                true,
+	}))
+
+	clients, err := NewClientsFromSettings(settings)
+	require.NoError(t, err)
+	require.Len(t, clients, 1)
+	require.Equal(t, sourceURL, clients["first"].SourceURL().String())
+
+	// the failure of a source is returned with the clients of the other sources.
+	require.NoError(t, settings.SetPackageSource(config.NewPackageSource("insecure", sourceURL)))
+	require.NoError(t, settings.SetPackageSource(&config.PackageSource{
+		Name:                     "missing",
+		Source:                   fmt.Sprintf("%s/missing/index.json", server.URL),
+		AllowInsecureConnections: true,
+		IsEnabled:                true,
+	}))
+	clients, err = NewClientsFromSettings(settings, WithoutRetries())
+	require.Len(t, clients, 1)
+	require.NotNil(t, clients["first"])
+	require.ErrorContains(t, err, "package source insecure uses http")
+	require.ErrorContains(t, err, "package source missing: ")
+
+	_, err = NewClientsFromSettings(nil)
+	require.Equal(t, errors.New("settings are required"), err)
+}
@@ -40,6 +40,7 @@ const (
 	SymbolPackagePublish      ServiceType = "SymbolPackagePublish"
 	VulnerabilityInfo         ServiceType = "VulnerabilityInfo"
 	OwnerDetailsURLTemplate   ServiceType = "OwnerDetailsUriTemplate"
+	Catalog                   ServiceType = "Catalog"
 )
 
 var (
@@ -109,6 +110,10 @@ var (
 	OwnerDetailsURLTemplateTypes = ServiceTypes{
 		string(OwnerDetailsURLTemplate + Version6110),
 	}
+
+	CatalogTypes = ServiceTypes{
+		string(Catalog + Version300),
+	}
 	typesMaps map[ServiceType]*ServiceTypeOptions
 )
 
@@ -135,6 +140,7 @@ func init() {
 		SymbolPackagePublish:      newTypeOptions(SymbolPackagePublishTypes, ""),
 		VulnerabilityInfo:         newTypeOptions(VulnerabilityInfoTypes, ""),
 		OwnerDetailsURLTemplate:   newTypeOptions(OwnerDetailsURLTemplateTypes, ""),
+		Catalog:                   newTypeOptions(CatalogTypes, ""),
 	}
 }
 
@@ -6,6 +6,7 @@ package nuget
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -17,8 +18,12 @@ import (
 	"strings"
 	"time"
 
+	retryablehttp "github.com/hashicorp/go-retryablehttp"
+
 	"github.com/huhouhua/go-nuget/internal/consts"
 	"github.com/huhouhua/go-nuget/internal/util"
+
+	nugetVersion "github.com/huhouhua/go-nuget/version"
 )
 
 const schemeFile = "file"
@@ -33,9 +38,109 @@ type resultContext struct {
 	Error error
 }
 
+// DeleteMode How DeleteWithMode removes a package.
+type DeleteMode int
+
+const (
+	// DeleteModeUnlist Lets the server decide, nuget.org and most servers only unlist the package.
+	DeleteModeUnlist DeleteMode = iota
+
+	// DeleteModeHardDelete The package must be removed from the server, Ex: BaGet with
+	// PackageDeletionBehavior set to HardDelete. Fails with ErrPackageNotDeleted when the server still
+	// serves the package after the delete.
+	DeleteModeHardDelete
+)
+
+var (
+	// ErrPackageNotFound The server has no such package version, it also matches ErrNotFound.
+	ErrPackageNotFound = fmt.Errorf("package %w", ErrNotFound)
+
+	// ErrPackageForbidden The api key is not allowed to modify the package.
+	ErrPackageForbidden = errors.New("403 Forbidden")
+
+	// ErrPackageConflict The server refused the change in the current state of the package.
+	ErrPackageConflict = errors.New("409 Conflict")
+
+	// ErrPackageExists The server already has the pushed package version, see PushPackageOptions.SkipDuplicate.
+	ErrPackageExists = errors.New("package already exists")
+
+	// ErrPackageNotDeleted The server only unlisted a package DeleteModeHardDelete requested to delete.
+	ErrPackageNotDeleted = errors.New("package was unlisted instead of deleted")
+)
+
+// PackageOperationError An unlist, relist or delete of a package version the server refused.
+type PackageOperationError struct {
+	Op       string
+	Id       string
+	Version  string
+	Response *http.Response
+
+	// Err ErrPackageNotFound, ErrPackageForbidden or ErrPackageConflict wrapping the *ErrorResponse,
+	// or the error of the response for the other statuses.
+	Err error
+}
+
+func (e *PackageOperationError) Error() string {
+	return fmt.Sprintf("%s %s %s: %v", e.Op, e.Id, e.Version, e.Err)
+}
+
+func (e *PackageOperationError) Unwrap() error {
+	return e.Err
+}
+
 // Delete deletes a package from the server.
-// please note that this package can only be soft deleted
+// please note that this package can only be soft deleted, see DeleteWithMode for servers that hard delete.
 func (p *PackageUpdateResource) Delete(id, version string, options ...RequestOptionFunc) (*http.Response, error) {
+	return p.modify("delete", http.MethodDelete, id, version, options)
+}
+
+// Unlist hides a package version from search and version resolution, it can still be restored by exact version.
+func (p *PackageUpdateResource) Unlist(id, version string, options ...RequestOptionFunc) (*http.Response, error) {
+	return p.modify("unlist", http.MethodDelete, id, version, options)
+}
+
+// Relist lists again an unlisted package version.
+func (p *PackageUpdateResource) Relist(id, version string, options ...RequestOptionFunc) (*http.Response, error) {
+	return p.modify("relist", http.MethodPost, id, version, options)
+}
+
+// DeleteWithMode deletes a package from the server, see DeleteMode.
+func (p *PackageUpdateResource) DeleteWithMode(
+	id, version string,
+	mode DeleteMode,
+	options ...RequestOptionFunc,
+) (*http.Response, error) {
+	resp, err := p.modify("delete", http.MethodDelete, id, version, options)
+	if err != nil || mode != DeleteModeHardDelete {
+		return resp, err
+	}
+	// a cached version list would still hold the deleted version.
+	check := append([]RequestOptionFunc{WithNoCache()}, options...)
+	versions, _, err := p.client.FindPackageResource.ListAllVersions(id, check...)
+	if errors.Is(err, ErrNotFound) {
+		return resp, nil
+	}
+	if err != nil {
+		return resp, err
+	}
+	deleted, err := nugetVersion.Parse(version)
+	if err != nil {
+		return resp, err
+	}
+	for _, v := range versions {
+		if strings.EqualFold(v.ToNormalizedString(), deleted.ToNormalizedString()) {
+			return resp, &PackageOperationError{Op: "delete", Id: id, Version: version, Response: resp,
+				Err: ErrPackageNotDeleted}
+		}
+	}
+	return resp, nil
+}
+
+// modify sends a request changing the state of a package version, method is DELETE to unlist and POST to relist.
+func (p *PackageUpdateResource) modify(
+	op, method, id, version string,
+	options []RequestOptionFunc,
+) (*http.Response, error) {
 	baseURL, err := p.getResourceURL(PackagePublish)
 	if err != nil {
 		return nil, err
@@ -45,14 +150,26 @@ func (p *PackageUpdateResource) Delete(id, version string, options ...RequestOpt
 		return nil, err
 	}
 	if sourceURL.Scheme == schemeFile {
-		return nil, fmt.Errorf("no support file system delete")
+		return nil, fmt.Errorf("no support file system %s", op)
 	}
 	u := fmt.Sprintf("%s/%s/%s", baseURL.Path, PathEscape(id), PathEscape(version))
-	req, err := p.client.NewRequest(http.MethodDelete, u, baseURL, nil, options)
+	req, err := p.client.NewRequest(method, u, baseURL, nil, options)
 	if err != nil {
 		return nil, err
 	}
-	return p.client.Do(req, nil, DecoderEmpty)
+	resp, err := p.client.Do(req, nil, DecoderEmpty)
+	if err != nil && resp != nil {
+		switch resp.StatusCode {
+		case http.StatusNotFound:
+			err = ErrPackageNotFound
+		case http.StatusForbidden:
+			err = fmt.Errorf("%w: %w", ErrPackageForbidden, err)
+		case http.StatusConflict:
+			err = fmt.Errorf("%w: %w", ErrPackageConflict, err)
+		}
+		err = &PackageOperationError{Op: op, Id: id, Version: version, Response: resp, Err: err}
+	}
+	return resp, err
 }
 
 type PushPackageOptions struct {
@@ -61,13 +178,58 @@ type PushPackageOptions struct {
 	TimeoutInDuration time.Duration `json:"TimeoutInDuration"`
 
 	IsSnupkg bool `json:"isSnupkg"`
+
+	// SkipDuplicate Treats a package the server already has as pushed instead of failing with ErrPackageExists,
+	// like dotnet nuget push --skip-duplicate.
+	SkipDuplicate bool `json:"skipDuplicate"`
+
+	// Progress Reports the upload of each pushed package, see UploadProgressFunc.
+	Progress UploadProgressFunc `json:"-"`
 }
 
-// PushWithStream pushes a package stream to the server.
+// PushWithStream pushes a package stream to the server, the stream is uploaded while it is read.
+// The request has a Content-Length when the size of the stream is known, see Client.StreamUploadRequest.
+// When IsSnupkg is set the stream is a symbol package, pushed to SymbolSource only.
 func (p *PackageUpdateResource) PushWithStream(
 	packageStream io.Reader,
 	opt *PushPackageOptions,
 	options ...RequestOptionFunc,
+) (*http.Response, error) {
+	sourceURL, err := p.getResourceURL(PackagePublish)
+	if err != nil {
+		return nil, err
+	}
+	if opt.IsSnupkg {
+		if strings.TrimSpace(opt.SymbolSource) == "" {
+			return nil, nil
+		}
+		if sourceURL, err = util.CreateSourceURL(opt.SymbolSource); err != nil {
+			return nil, err
+		}
+		if util.IsSourceNuGetSymbolServer(sourceURL) {
+			// the verification api key is created from the nuspec, which is read from a file.
+			return p.pushStaged(packageStream, opt, options)
+		}
+	} else if p.client.apiKey == "" && sourceURL.Scheme != schemeFile {
+		return nil, fmt.Errorf("api key is required")
+	}
+	if sourceURL.Scheme == schemeFile {
+		return nil, fmt.Errorf("no support file system push")
+	}
+	return withTimeout(opt.TimeoutInDuration, options, func(options []RequestOptionFunc) (*http.Response, error) {
+		req, err := p.newPushRequest(packageStream, sourceURL, opt, options)
+		if err != nil {
+			return nil, err
+		}
+		return p.send(req, sourceURL, opt)
+	})
+}
+
+// pushStaged copies the stream to a temp file and pushes the file.
+func (p *PackageUpdateResource) pushStaged(
+	packageStream io.Reader,
+	opt *PushPackageOptions,
+	options []RequestOptionFunc,
 ) (*http.Response, error) {
 	tempDir := os.TempDir()
 	extension := consts.PackageExtension
@@ -103,8 +265,6 @@ func (p *PackageUpdateResource) Push(
 	opt *PushPackageOptions,
 	options ...RequestOptionFunc,
 ) (*http.Response, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), opt.TimeoutInDuration)
-	defer cancel()
 	packageURL, err := p.getResourceURL(PackagePublish)
 	if err != nil {
 		return nil, err
@@ -115,33 +275,46 @@ func (p *PackageUpdateResource) Push(
 			return nil, err
 		}
 	}
-	resultChan := make(chan *resultContext)
-	go func() {
-		defer close(resultChan)
-		var resp *http.Response
-		var err error
+	return withTimeout(opt.TimeoutInDuration, options, func(options []RequestOptionFunc) (*http.Response, error) {
 		if !strings.HasSuffix(packagePath, consts.SnupkgExtension) {
-			resp, err = p.pushPackagePath(opt, packagePath, packageURL, symbolURL, options...)
-		} else if strings.TrimSpace(opt.SymbolSource) != "" {
+			return p.pushPackagePath(opt, packagePath, packageURL, symbolURL, options...)
+		}
+		if strings.TrimSpace(opt.SymbolSource) != "" {
 			// symbolSource is only set when:
 			// - The user specified it on the command line
 			// - The endpoint for main package supports pushing snupkgs
-			resp, err = p.pushWithSymbol(opt, packagePath, symbolURL, options...)
+			return p.pushWithSymbol(opt, packagePath, symbolURL, options...)
 		}
+		return nil, nil
+	})
+}
+
+// withTimeout runs push with a context timing out after timeout, the context also cancels the requests of push.
+func withTimeout(
+	timeout time.Duration,
+	options []RequestOptionFunc,
+	push func(options []RequestOptionFunc) (*http.Response, error),
+) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	options = append([]RequestOptionFunc{WithContext(ctx)}, options...)
+
+	resultChan := make(chan *resultContext, 1)
+	go func() {
+		defer close(resultChan)
+		resp, err := push(options)
 		resultChan <- &resultContext{
 			Resp:  resp,
 			Error: err,
 		}
 	}()
 
-	for {
-		select {
-		// context deadline exceeded
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		case result := <-resultChan:
-			return result.Resp, result.Error
-		}
+	select {
+	// context deadline exceeded
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case result := <-resultChan:
+		return result.Resp, result.Error
 	}
 }
 
@@ -174,7 +347,7 @@ func (p *PackageUpdateResource) pushPackagePath(
 		return nil, fmt.Errorf("api key is required")
 	}
 	for _, nupkgToPush := range paths {
-		if resp, err := p.pushPackageCore(nupkgToPush, sourceURL, options...); err != nil {
+		if resp, err := p.pushPackageCore(nupkgToPush, sourceURL, opt, options...); err != nil {
 			return resp, err
 		}
 		// If the package was pushed successfully, push the symbol package.
@@ -185,7 +358,7 @@ func (p *PackageUpdateResource) pushPackagePath(
 		if _, err = os.Stat(symbolPackagePath); os.IsNotExist(err) {
 			continue
 		}
-		if resp, err := p.pushPackageCore(symbolPackagePath, symbolURL, options...); err != nil {
+		if resp, err := p.pushPackageCore(symbolPackagePath, symbolURL, opt, options...); err != nil {
 			return resp, err
 		}
 	}
@@ -195,6 +368,7 @@ func (p *PackageUpdateResource) pushPackagePath(
 func (p *PackageUpdateResource) pushPackageCore(
 	packageToPush string,
 	sourceURL *url.URL,
+	opt *PushPackageOptions,
 	options ...RequestOptionFunc,
 ) (*http.Response, error) {
 	log.Printf("push package %s to %s", filepath.Base(packageToPush), sourceURL.String())
@@ -203,7 +377,7 @@ func (p *PackageUpdateResource) pushPackageCore(
 	if sourceURL.Scheme == schemeFile {
 		return nil, fmt.Errorf("no support file system push")
 	}
-	return p.push(packageToPush, sourceURL, options...)
+	return p.push(packageToPush, sourceURL, opt, options...)
 }
 
 // pushWithSymbol handle push to https://nuget.smbsrc.net/
@@ -229,7 +403,7 @@ func (p *PackageUpdateResource) pushWithSymbol(
 		log.Printf("warning symbol server not configured %s", filepath.Base(symbolPackagePath))
 	}
 	for _, packageToPush := range paths {
-		if resp, err := p.pushPackageCore(packageToPush, symbolURL, options...); err != nil {
+		if resp, err := p.pushPackageCore(packageToPush, symbolURL, opt, options...); err != nil {
 			return resp, err
 		}
 	}
@@ -241,17 +415,13 @@ func (p *PackageUpdateResource) createVerificationApiKey(
 	pathToPackage string,
 	options ...RequestOptionFunc,
 ) (string, error) {
-	packageFile, err := os.Open(pathToPackage)
+	reader, err := OpenPackageArchiveReader(pathToPackage)
 	if err != nil {
 		return "", err
 	}
 	defer func() {
-		_ = packageFile.Close()
+		_ = reader.Close()
 	}()
-	reader, err := NewPackageArchiveReader(packageFile)
-	if err != nil {
-		return "", err
-	}
 	nuspec, err := reader.Nuspec()
 	if err != nil {
 		return "", err
@@ -273,39 +443,70 @@ func (p *PackageUpdateResource) createVerificationApiKey(
 	return keyMap["Key"], nil
 }
 
-// push pushes a package to the server.
+// push pushes a package file to the server.
 func (p *PackageUpdateResource) push(
 	pathToPackage string,
 	sourceURL *url.URL,
+	opt *PushPackageOptions,
 	options ...RequestOptionFunc,
 ) (*http.Response, error) {
 	file, err := os.Open(pathToPackage)
 	if err != nil {
 		return nil, err
 	}
+	defer func() {
+		_ = file.Close()
+	}()
+	req, err := p.newPushRequest(file, sourceURL, opt, options)
+	if err != nil {
+		return nil, err
+	}
+	if util.IsSourceNuGetSymbolServer(sourceURL) {
+		if key, err := p.createVerificationApiKey(pathToPackage, options...); err != nil {
+			return nil, err
+		} else {
+			req.Header.Add("X-NuGet-ApiKey", key)
+		}
+	}
+	return p.send(req, sourceURL, opt)
+}
+
+// newPushRequest creates the request uploading a package to sourceURL.
+func (p *PackageUpdateResource) newPushRequest(
+	content io.Reader,
+	sourceURL *url.URL,
+	opt *PushPackageOptions,
+	options []RequestOptionFunc,
+) (*retryablehttp.Request, error) {
 	endpointURL, err := util.GetServiceEndpointUrl(sourceURL.String(), "", false)
 	if err != nil {
 		return nil, err
 	}
-	req, err := p.client.UploadRequest(
+	return p.client.StreamUploadRequest(
 		http.MethodPut,
 		endpointURL.Path,
 		endpointURL,
-		file,
+		content,
 		"package",
 		"package.nupkg",
-		nil,
+		opt.Progress,
 		options,
 	)
-	if err != nil {
-		return nil, err
+}
+
+// send sends a push request, a package the server already has fails with ErrPackageExists unless SkipDuplicate.
+func (p *PackageUpdateResource) send(
+	req *retryablehttp.Request,
+	sourceURL *url.URL,
+	opt *PushPackageOptions,
+) (*http.Response, error) {
+	resp, err := p.client.Do(req, nil, DecoderEmpty)
+	if err == nil || resp == nil || resp.StatusCode != http.StatusConflict {
+		return resp, err
 	}
-	if util.IsSourceNuGetSymbolServer(sourceURL) {
-		if key, err := p.createVerificationApiKey(pathToPackage, options...); err != nil {
-			return nil, err
-		} else {
-			req.Header.Add("X-NuGet-ApiKey", key)
-		}
+	if opt.SkipDuplicate {
+		log.Printf("package already exists at %s, skipping", sourceURL.String())
+		return resp, nil
 	}
-	return p.client.Do(req, nil, DecoderEmpty)
+	return resp, fmt.Errorf("%w: %w", ErrPackageExists, err)
 }
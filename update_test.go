@@ -29,6 +29,19 @@ import (
 
 const testHttpScheme = "http"
 
+// unreadablePackage a package stream of a known length failing to read.
+type unreadablePackage struct {
+	err error
+}
+
+func (p unreadablePackage) Read([]byte) (int, error) {
+	return 0, p.err
+}
+
+func (p unreadablePackage) Len() int {
+	return 1
+}
+
 func TestPackageUpdateResource_PushWithStream(t *testing.T) {
 	defaultTimeOut := time.Second * 10
 	mux, client := setup(t, index_V3)
@@ -68,14 +81,12 @@ func TestPackageUpdateResource_PushWithStream(t *testing.T) {
 		error      error
 	}{
 		{
-			name:       "push empty package return error",
-			opt:        &PushPackageOptions{},
-			packageBuf: os.Stdout,
-			error: &fs.PathError{
-				Op:   "read",
-				Path: "/dev/stdout",
-				Err:  syscall.Errno(9),
+			name: "push unreadable package return error",
+			opt: &PushPackageOptions{
+				TimeoutInDuration: defaultTimeOut,
 			},
+			packageBuf: unreadablePackage{err: syscall.EBADF},
+			error:      syscall.EBADF,
 		},
 		{
 			name: "push suffix .nupkg package return success",
@@ -96,11 +107,47 @@ func TestPackageUpdateResource_PushWithStream(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			_, err := client.UpdateResource.PushWithStream(tt.packageBuf, tt.opt)
-			require.Equal(t, tt.error, err, "PackageUpdateResource.PushWithStream returns an error")
+			if tt.error != nil {
+				require.ErrorIs(t, err, tt.error, "PackageUpdateResource.PushWithStream returns an error")
+			} else {
+				require.NoError(t, err, "PackageUpdateResource.PushWithStream returns an error")
+			}
 		})
 	}
 }
 
+func TestPackageUpdateResource_PushDuplicate(t *testing.T) {
+	mux, client := setup(t, index_V3)
+	nupkgPath := filepath.Join(t.TempDir(), "mynuget.nupkg")
+	createFile(t, nupkgPath, "TestPackageUpdateResource_PushDuplicate")
+	mux.HandleFunc(client.getResourceURL(PackagePublish).Path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPut)
+		_, err := io.Copy(io.Discard, r.Body)
+		require.NoError(t, err)
+		w.WriteHeader(http.StatusConflict)
+	})
+	opt := &PushPackageOptions{TimeoutInDuration: time.Second * 10}
+
+	var sent int64
+	opt.Progress = func(n, _ int64) { sent = n }
+	resp, err := client.UpdateResource.PushWithStream(strings.NewReader("nupkg"), opt)
+	require.ErrorIs(t, err, ErrPackageExists)
+	var errResp *ErrorResponse
+	require.ErrorAs(t, err, &errResp)
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+	require.Equal(t, int64(len("nupkg")), sent)
+
+	_, err = client.UpdateResource.Push(nupkgPath, opt)
+	require.ErrorIs(t, err, ErrPackageExists)
+
+	opt.SkipDuplicate = true
+	resp, err = client.UpdateResource.PushWithStream(strings.NewReader("nupkg"), opt)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+	_, err = client.UpdateResource.Push(nupkgPath, opt)
+	require.NoError(t, err)
+}
+
 func TestPackageUpdateResource_Delete(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -179,6 +226,126 @@ func TestPackageUpdateResource_Delete(t *testing.T) {
 	}
 }
 
+func TestPackageUpdateResource_UnlistRelist(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		unlist  bool
+		wantErr error
+	}{
+		{name: "unlist", status: http.StatusOK, unlist: true},
+		{name: "relist", status: http.StatusOK},
+		{name: "not found", status: http.StatusNotFound, wantErr: ErrPackageNotFound},
+		{name: "forbidden", status: http.StatusForbidden, unlist: true, wantErr: ErrPackageForbidden},
+		{name: "conflict", status: http.StatusConflict, wantErr: ErrPackageConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, client := setup(t, index_V3)
+			baseURL := client.getResourceURL(PackagePublish)
+			mux.HandleFunc(baseURL.Path+"/newtonsoft%2Ejson/13.0.1", func(w http.ResponseWriter, r *http.Request) {
+				if tt.unlist {
+					testMethod(t, r, http.MethodDelete)
+				} else {
+					testMethod(t, r, http.MethodPost)
+				}
+				require.Equal(t, client.apiKey, r.Header.Get("X-NuGet-ApiKey"))
+				w.WriteHeader(tt.status)
+			})
+
+			var err error
+			if tt.unlist {
+				_, err = client.UpdateResource.Unlist("newtonsoft.json", "13.0.1")
+			} else {
+				_, err = client.UpdateResource.Relist("newtonsoft.json", "13.0.1")
+			}
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tt.wantErr)
+			var opErr *PackageOperationError
+			require.ErrorAs(t, err, &opErr)
+			require.Equal(t, "newtonsoft.json", opErr.Id)
+			require.Equal(t, tt.status, opErr.Response.StatusCode)
+		})
+	}
+
+	_, client := setup(t, index_V3)
+	client.serviceURLs[PackagePublish] = createUrl(t, "file:///localhost:5000/api/v2/package")
+	_, err := client.UpdateResource.Relist("newtonsoft.json", "13.0.1")
+	require.EqualError(t, err, "no support file system relist")
+}
+
+func TestPackageUpdateResource_DeleteWithMode(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     DeleteMode
+		versions string
+		wantErr  error
+	}{
+		{name: "unlist keeps the package", mode: DeleteModeUnlist, versions: `["1.0.0", "1.0.1"]`},
+		{name: "hard delete removes the version", mode: DeleteModeHardDelete, versions: `["1.0.0"]`},
+		{name: "hard delete removes the package", mode: DeleteModeHardDelete},
+		{
+			name:     "hard delete only unlisted",
+			mode:     DeleteModeHardDelete,
+			versions: `["1.0.0", "1.0.1"]`,
+			wantErr:  ErrPackageNotDeleted,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, client := setup(t, index_V3)
+			baseURL := client.getResourceURL(PackagePublish)
+			mux.HandleFunc(baseURL.Path+"/test/1.0.1", func(w http.ResponseWriter, r *http.Request) {
+				testMethod(t, r, http.MethodDelete)
+			})
+			listed := false
+			flatURL := client.getResourceURL(PackageBaseAddress)
+			mux.HandleFunc(flatURL.Path+"/test/index.json", func(w http.ResponseWriter, r *http.Request) {
+				testMethod(t, r, http.MethodGet)
+				listed = true
+				if tt.versions == "" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				fmt.Fprintf(w, `{"versions": %s}`, tt.versions)
+			})
+
+			_, err := client.UpdateResource.DeleteWithMode("test", "1.0.1", tt.mode)
+			require.ErrorIs(t, err, tt.wantErr)
+			require.Equal(t, tt.mode == DeleteModeHardDelete, listed)
+		})
+	}
+}
+
+func TestPackageUpdateResource_DeleteWithMode_HTTPCache(t *testing.T) {
+	mux, client := setup(t, index_V3)
+	require.NoError(t, WithHTTPCache(NewMemoryHTTPCache())(client))
+	deleted := false
+	mux.HandleFunc(client.getResourceURL(PackagePublish).Path+"/test/1.0.1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodDelete)
+		deleted = true
+	})
+	mux.HandleFunc(client.getResourceURL(PackageBaseAddress).Path+"/test/index.json",
+		func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, http.MethodGet)
+			if deleted {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			fmt.Fprint(w, `{"versions": ["1.0.1"]}`)
+		})
+
+	// the version list cached before the delete must not be used to check it.
+	versions, _, err := client.FindPackageResource.ListAllVersions("test")
+	require.NoError(t, err)
+	require.Len(t, versions, 1)
+	_, err = client.UpdateResource.DeleteWithMode("test", "1.0.1", DeleteModeHardDelete)
+	require.NoError(t, err)
+}
+
 func TestPackageUpdateResource_Push(t *testing.T) {
 	tmpDir := t.TempDir()
 	emptyPath := filepath.Join(tmpDir, "empty.nupkg")
@@ -511,6 +678,7 @@ func TestPushPackage(t *testing.T) {
 			_, err = client.UpdateResource.push(
 				tt.packagePath,
 				packageUrl,
+				&PushPackageOptions{},
 				func(request *retryablehttp.Request) error {
 					request.URL.Scheme = testHttpScheme
 					request.URL.Host = client.baseURL.Host
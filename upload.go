@@ -0,0 +1,202 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package nuget
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+
+	retryablehttp "github.com/hashicorp/go-retryablehttp"
+
+	"github.com/huhouhua/go-nuget/internal/util"
+)
+
+// ErrUploadNotReplayable A retried upload request can not read its content again, the content is not an io.Seeker.
+var ErrUploadNotReplayable = errors.New("the upload content can not be read again to retry the request")
+
+// UploadProgressFunc Reports the bytes of the uploaded content sent so far, total is -1 when the size is unknown.
+// A retried request reports its progress again from zero.
+type UploadProgressFunc func(sent, total int64)
+
+// StreamUploadRequest creates an API request uploading content as a multipart file, content is read while the
+// request is sent instead of being buffered in memory. The request has a Content-Length when the size of content
+// is known, that is when it is an io.Seeker or has a Len() int method, otherwise the body is sent chunked.
+// Retrying the request reads content again from where it started, which needs an io.Seeker, a retry of content
+// which can not be read again fails with ErrUploadNotReplayable before it is sent.
+func (c *Client) StreamUploadRequest(
+	method, path string,
+	baseUrl *url.URL,
+	content io.Reader,
+	fileType, filename string,
+	progress UploadProgressFunc,
+	options []RequestOptionFunc,
+) (*retryablehttp.Request, error) {
+	u := *c.baseURL
+	if baseUrl != nil {
+		u = *baseUrl
+	}
+	unescaped, err := url.PathUnescape(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set the encoded path data
+	u.RawPath = c.baseURL.Path + path
+	u.Path = util.PathCombine(c.baseURL.Path, unescaped)
+
+	// The multipart headers and trailer surrounding the content.
+	frame := new(bytes.Buffer)
+	w := multipart.NewWriter(frame)
+	if _, err = w.CreateFormFile(fileType, filename); err != nil {
+		return nil, err
+	}
+	headerLen := frame.Len()
+	if err = w.Close(); err != nil {
+		return nil, err
+	}
+
+	stream, err := newUploadStream(content, progress)
+	if err != nil {
+		return nil, err
+	}
+	header, trailer := frame.Bytes()[:headerLen], frame.Bytes()[headerLen:]
+	body := retryablehttp.ReaderFunc(func() (io.Reader, error) {
+		if err := stream.rewind(); err != nil {
+			return nil, err
+		}
+		return &uploadBody{stream: stream, header: header, trailer: trailer}, nil
+	})
+	req, err := retryablehttp.NewRequest(method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	// NewRequest called body to measure it without reading content, the first attempt is still to come.
+	stream.attempts = 0
+
+	for _, fn := range append(c.defaultRequestOptions, options...) {
+		if fn == nil {
+			continue
+		}
+		if err := fn(req); err != nil {
+			return nil, err
+		}
+	}
+
+	// Set the request specific headers.
+	reqHeaders := make(http.Header)
+	reqHeaders.Set("Accept", "application/json")
+	reqHeaders.Set("Content-Type", w.FormDataContentType())
+	if c.UserAgent != "" {
+		reqHeaders.Set("User-Agent", c.UserAgent)
+	}
+	for k, v := range reqHeaders {
+		req.Header[k] = v
+	}
+
+	return req, nil
+}
+
+// uploadStream The content of an upload request, shared by the attempts of the request.
+type uploadStream struct {
+	content  io.Reader
+	progress UploadProgressFunc
+
+	// seeker rewinds content to start for a retry, nil when content can not be read again.
+	seeker io.Seeker
+	start  int64
+
+	// size The size of content, -1 when unknown.
+	size int64
+
+	// attempts The attempts of the request so far.
+	attempts int
+}
+
+func newUploadStream(content io.Reader, progress UploadProgressFunc) (*uploadStream, error) {
+	s := &uploadStream{content: content, progress: progress, size: -1}
+	if seeker, ok := content.(io.Seeker); ok {
+		if err := s.seek(seeker); err != nil {
+			return nil, err
+		}
+	}
+	if lr, ok := content.(interface{ Len() int }); ok && s.size < 0 {
+		s.size = int64(lr.Len())
+	}
+	return s, nil
+}
+
+// seek measures the size of content, content which can not seek, Ex: a pipe, is read only once.
+func (s *uploadStream) seek(seeker io.Seeker) error {
+	start, err := seeker.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil
+	}
+	end, err := seeker.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil
+	}
+	if _, err = seeker.Seek(start, io.SeekStart); err != nil {
+		return err
+	}
+	s.seeker, s.start, s.size = seeker, start, end-start
+	return nil
+}
+
+// rewind prepares content for an attempt of the request, an attempt after the first one reads content again
+// from start.
+func (s *uploadStream) rewind() error {
+	s.attempts++
+	if s.attempts == 1 {
+		return nil
+	}
+	if s.seeker == nil {
+		return ErrUploadNotReplayable
+	}
+	_, err := s.seeker.Seek(s.start, io.SeekStart)
+	return err
+}
+
+// uploadBody The multipart body of an attempt of the request.
+type uploadBody struct {
+	stream          *uploadStream
+	header, trailer []byte
+	reader          io.Reader
+	sent            int64
+}
+
+// Len Returns the size of the body, 0 when unknown for the request to be sent chunked.
+func (b *uploadBody) Len() int {
+	if b.stream.size < 0 {
+		return 0
+	}
+	return len(b.header) + int(b.stream.size) + len(b.trailer)
+}
+
+func (b *uploadBody) Read(p []byte) (int, error) {
+	if b.reader == nil {
+		b.reader = io.MultiReader(bytes.NewReader(b.header), readerFunc(b.readContent), bytes.NewReader(b.trailer))
+	}
+	return b.reader.Read(p)
+}
+
+func (b *uploadBody) readContent(p []byte) (int, error) {
+	n, err := b.stream.content.Read(p)
+	if n > 0 && b.stream.progress != nil {
+		b.sent += int64(n)
+		b.stream.progress(b.sent, b.stream.size)
+	}
+	return n, err
+}
+
+// readerFunc An io.Reader calling the function.
+type readerFunc func(p []byte) (int, error)
+
+func (f readerFunc) Read(p []byte) (int, error) {
+	return f(p)
+}
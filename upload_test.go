@@ -0,0 +1,96 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package nuget
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// uploadedFile a received upload request.
+type uploadedFile struct {
+	contentLength int64
+	content       string
+}
+
+// setupUpload serves the package publish endpoint, failing the first failures requests with a 500.
+func setupUpload(t *testing.T, failures int) (*Client, *[]uploadedFile) {
+	mux, client := setup(t, index_V3)
+	var uploads []uploadedFile
+	mux.HandleFunc(client.getResourceURL(PackagePublish).Path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPut)
+		file, header, err := r.FormFile("package")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		require.Equal(t, "package.nupkg", header.Filename)
+		content, err := io.ReadAll(file)
+		require.NoError(t, err)
+		uploads = append(uploads, uploadedFile{contentLength: r.ContentLength, content: string(content)})
+		if len(uploads) <= failures {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+	return client, &uploads
+}
+
+func uploadContent(t *testing.T, client *Client, content io.Reader, progress UploadProgressFunc) error {
+	u := client.getResourceURL(PackagePublish)
+	req, err := client.StreamUploadRequest(http.MethodPut, u.Path, u, content, "package", "package.nupkg", progress, nil)
+	require.NoError(t, err)
+	require.Contains(t, req.Header.Get("Content-Type"), "multipart/form-data;")
+	_, err = client.Do(req, nil, DecoderEmpty)
+	return err
+}
+
+func TestStreamUploadRequest(t *testing.T) {
+	client, uploads := setupUpload(t, 0)
+	content := strings.Repeat("nupkg", 10000)
+
+	var sent, total int64
+	err := uploadContent(t, client, strings.NewReader(content), func(s, n int64) {
+		sent, total = s, n
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(len(content)), sent)
+	require.Equal(t, int64(len(content)), total)
+
+	// the size of a reader which is neither an io.Seeker nor has a Len is unknown, the body is sent chunked.
+	err = uploadContent(t, client, io.LimitReader(strings.NewReader(content), 100), func(s, n int64) {
+		sent, total = s, n
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(100), sent)
+	require.Equal(t, int64(-1), total)
+
+	require.Len(t, *uploads, 2)
+	require.Greater(t, (*uploads)[0].contentLength, int64(len(content)))
+	require.Equal(t, content, (*uploads)[0].content)
+	require.Equal(t, int64(-1), (*uploads)[1].contentLength)
+	require.Equal(t, content[:100], (*uploads)[1].content)
+}
+
+func TestStreamUploadRequest_Retry(t *testing.T) {
+	client, uploads := setupUpload(t, 1)
+
+	// a retry reads the content again from where it started.
+	content := strings.NewReader("skipped nupkg")
+	_, err := content.Seek(int64(len("skipped ")), io.SeekStart)
+	require.NoError(t, err)
+	require.NoError(t, uploadContent(t, client, content, nil))
+	require.Len(t, *uploads, 2)
+	require.Equal(t, "nupkg", (*uploads)[0].content)
+	require.Equal(t, "nupkg", (*uploads)[1].content)
+
+	client, uploads = setupUpload(t, 1)
+	err = uploadContent(t, client, io.LimitReader(strings.NewReader("nupkg"), 5), nil)
+	require.ErrorIs(t, err, ErrUploadNotReplayable)
+	require.Len(t, *uploads, 1)
+}
@@ -194,13 +194,13 @@ func appendNormalized(builder *strings.Builder, version *Version) {
 }
 
 func appendVersion(builder *strings.Builder, version *Version) {
-	builder.WriteString(strconv.FormatInt(int64(version.Semver.Major()), 36))
+	builder.WriteString(strconv.FormatInt(int64(version.Semver.Major()), 10))
 	builder.WriteString(".")
-	builder.WriteString(strconv.FormatInt(int64(version.Semver.Minor()), 36))
+	builder.WriteString(strconv.FormatInt(int64(version.Semver.Minor()), 10))
 	builder.WriteString(".")
-	builder.WriteString(strconv.FormatInt(int64(version.Semver.Patch()), 36))
+	builder.WriteString(strconv.FormatInt(int64(version.Semver.Patch()), 10))
 	if version.IsLegacyVersion() {
 		builder.WriteString(".")
-		builder.WriteString(strconv.FormatInt(int64(version.Revision), 36))
+		builder.WriteString(strconv.FormatInt(int64(version.Revision), 10))
 	}
 }
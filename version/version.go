@@ -63,6 +63,14 @@ func (v *Version) IsSemVer2() bool {
 	return strings.TrimSpace(v.Semver.Prerelease()) != "" || strings.TrimSpace(v.Semver.Metadata()) != ""
 }
 
+// ToNormalizedString returns the version without leading zeros, revision 0 or metadata.
+// Ex: 1.0 -> 1.0.0, 1.01.0.0-Beta+sha -> 1.1.0-Beta
+func (v *Version) ToNormalizedString() string {
+	builder := &strings.Builder{}
+	appendNormalized(builder, v)
+	return builder.String()
+}
+
 func NewVersion(semver *semver.Version, revision int,
 	originalVersion string) *Version {
 	v := &Version{
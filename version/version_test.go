@@ -240,6 +240,27 @@ func TestIsRevision(t *testing.T) {
 	}
 }
 
+func TestToNormalizedString(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "1.0", expected: "1.0.0"},
+		{input: "1.0.0.0", expected: "1.0.0"},
+		{input: "2018.4.8.256", expected: "2018.4.8.256"},
+		{input: "01.010.0", expected: "1.10.0"},
+		{input: "1.0.0-Beta+Meta", expected: "1.0.0-Beta"},
+		{input: "36.35.12-rc.1", expected: "36.35.12-rc.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			for _, v := range parse(t, tt.input) {
+				require.Equal(t, tt.expected, v.ToNormalizedString())
+			}
+		})
+	}
+}
+
 func TestParseVersion(t *testing.T) {
 	tests := []struct {
 		major   uint64
@@ -0,0 +1,224 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package nuget
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/huhouhua/go-nuget/meta"
+
+	nugetVersion "github.com/huhouhua/go-nuget/version"
+)
+
+// VulnerabilitySeverity The severity of an advisory.
+type VulnerabilitySeverity int
+
+const (
+	VulnerabilitySeverityLow VulnerabilitySeverity = iota
+	VulnerabilitySeverityModerate
+	VulnerabilitySeverityHigh
+	VulnerabilitySeverityCritical
+)
+
+func (s VulnerabilitySeverity) String() string {
+	switch s {
+	case VulnerabilitySeverityLow:
+		return "Low"
+	case VulnerabilitySeverityModerate:
+		return "Moderate"
+	case VulnerabilitySeverityHigh:
+		return "High"
+	case VulnerabilitySeverityCritical:
+		return "Critical"
+	default:
+		return fmt.Sprintf("Unknown(%d)", int(s))
+	}
+}
+
+// VulnerabilityInfoResource Reads the vulnerability files of a source and audits packages against them.
+// Source: https://learn.microsoft.com/en-us/nuget/api/vulnerability-info
+type VulnerabilityInfoResource struct {
+	client *Client
+
+	mu sync.Mutex
+	// pages the vulnerability files already downloaded, by url
+	pages map[string]*vulnerabilityPageEntry
+}
+
+type vulnerabilityPageEntry struct {
+	updated time.Time
+	page    VulnerabilityPage
+}
+
+// VulnerabilityFile An entry of the vulnerability index.
+type VulnerabilityFile struct {
+	// Name The name of the file, "base" or "update".
+	Name string `json:"@name"`
+
+	// URL The location of the file.
+	URL string `json:"@id"`
+
+	// Updated When the file last changed, the file is downloaded again only when it changes.
+	Updated time.Time `json:"@updated"`
+
+	Comment string `json:"comment"`
+}
+
+// VulnerabilityAdvisory A known vulnerability of a range of versions of a package.
+type VulnerabilityAdvisory struct {
+	AdvisoryURL string                `json:"url"`
+	Severity    VulnerabilitySeverity `json:"severity"`
+
+	// Versions The affected version range.
+	Versions string `json:"versions"`
+
+	// VersionRange The parsed Versions.
+	VersionRange *nugetVersion.VersionRange `json:"-"`
+}
+
+// VulnerabilityPage The advisories of a vulnerability file, by lowercase package id.
+type VulnerabilityPage map[string][]*VulnerabilityAdvisory
+
+// PackageAuditResult The advisories affecting a package version.
+type PackageAuditResult struct {
+	Id         string
+	Version    *nugetVersion.Version
+	Advisories []*VulnerabilityAdvisory
+}
+
+// Vulnerable True when at least one advisory affects the package.
+func (r *PackageAuditResult) Vulnerable() bool {
+	return len(r.Advisories) > 0
+}
+
+// MaxSeverity Returns the highest severity of the advisories, -1 when not vulnerable.
+func (r *PackageAuditResult) MaxSeverity() VulnerabilitySeverity {
+	severity := VulnerabilitySeverity(-1)
+	for _, advisory := range r.Advisories {
+		severity = max(severity, advisory.Severity)
+	}
+	return severity
+}
+
+// GetIndex retrieves the vulnerability files of the source.
+func (v *VulnerabilityInfoResource) GetIndex(options ...RequestOptionFunc) ([]*VulnerabilityFile, *http.Response, error) {
+	baseURL := v.client.getResourceURL(VulnerabilityInfo)
+	if baseURL == nil {
+		return nil, nil, fmt.Errorf("the source does not support vulnerability info")
+	}
+	req, err := v.client.NewRequest(http.MethodGet, baseURL.Path, baseURL, nil, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	var files []*VulnerabilityFile
+	resp, err := v.client.Do(req, &files, DecoderTypeJSON)
+	if err != nil {
+		return nil, resp, err
+	}
+	return files, resp, nil
+}
+
+// GetPage retrieves the advisories of a vulnerability file. The file is downloaded once per @updated value,
+// the response is nil when the advisories are served from memory.
+func (v *VulnerabilityInfoResource) GetPage(
+	file *VulnerabilityFile,
+	options ...RequestOptionFunc,
+) (VulnerabilityPage, *http.Response, error) {
+	if file == nil || file.URL == "" {
+		return nil, nil, fmt.Errorf("vulnerability file url is empty")
+	}
+	v.mu.Lock()
+	entry, ok := v.pages[file.URL]
+	v.mu.Unlock()
+	if ok && entry.updated.Equal(file.Updated) {
+		return entry.page, nil, nil
+	}
+
+	u, err := url.Parse(file.URL)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := v.client.NewRequest(http.MethodGet, u.Path, u, nil, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	var page VulnerabilityPage
+	resp, err := v.client.Do(req, &page, DecoderTypeJSON)
+	if err != nil {
+		return nil, resp, err
+	}
+	normalized := make(VulnerabilityPage, len(page))
+	for id, advisories := range page {
+		for _, advisory := range advisories {
+			if advisory.VersionRange, err = nugetVersion.ParseRange(advisory.Versions); err != nil {
+				return nil, resp, fmt.Errorf("invalid version range %q of %s: %w", advisory.Versions, id, err)
+			}
+		}
+		id = strings.ToLower(id)
+		normalized[id] = append(normalized[id], advisories...)
+	}
+
+	v.mu.Lock()
+	if v.pages == nil {
+		v.pages = make(map[string]*vulnerabilityPageEntry)
+	}
+	v.pages[file.URL] = &vulnerabilityPageEntry{updated: file.Updated, page: normalized}
+	v.mu.Unlock()
+	return normalized, resp, nil
+}
+
+// GetVulnerabilities retrieves the advisories of all the vulnerability files of the source.
+func (v *VulnerabilityInfoResource) GetVulnerabilities(
+	options ...RequestOptionFunc,
+) ([]VulnerabilityPage, *http.Response, error) {
+	files, resp, err := v.GetIndex(options...)
+	if err != nil {
+		return nil, resp, err
+	}
+	pages := make([]VulnerabilityPage, 0, len(files))
+	for _, file := range files {
+		page, pageResp, err := v.GetPage(file, options...)
+		if err != nil {
+			return nil, pageResp, err
+		}
+		pages = append(pages, page)
+	}
+	return pages, resp, nil
+}
+
+// Audit returns the advisories affecting each of the packages, in the order of packages,
+// like dotnet list package --vulnerable.
+func (v *VulnerabilityInfoResource) Audit(
+	packages []*meta.PackageIdentity,
+	options ...RequestOptionFunc,
+) ([]*PackageAuditResult, *http.Response, error) {
+	pages, resp, err := v.GetVulnerabilities(options...)
+	if err != nil {
+		return nil, resp, err
+	}
+	results := make([]*PackageAuditResult, 0, len(packages))
+	for _, identity := range packages {
+		result := &PackageAuditResult{Id: identity.Id, Version: identity.Version}
+		if !identity.HasVersion() {
+			results = append(results, result)
+			continue
+		}
+		id := strings.ToLower(identity.Id)
+		for _, page := range pages {
+			for _, advisory := range page[id] {
+				if advisory.VersionRange.Satisfies(identity.Version) {
+					result.Advisories = append(result.Advisories, advisory)
+				}
+			}
+		}
+		results = append(results, result)
+	}
+	return results, resp, nil
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2025 Kevin Berger <huhouhuam@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package nuget
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/huhouhua/go-nuget/meta"
+
+	"github.com/stretchr/testify/require"
+
+	nugetVersion "github.com/huhouhua/go-nuget/version"
+)
+
+func TestVulnerabilityInfoResource_Audit(t *testing.T) {
+	mux, client := setup(t, index_V3)
+	index := "testdata/vulnerability_index.json"
+	baseRequests, updateRequests := 0, 0
+
+	baseURL := client.getResourceURL(VulnerabilityInfo)
+	mux.HandleFunc(baseURL.Path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		mustWriteHTTPResponse(t, w, index)
+	})
+	mux.HandleFunc("/v3/vulnerabilities/base.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		baseRequests++
+		mustWriteHTTPResponse(t, w, "testdata/vulnerability_base.json")
+	})
+	mux.HandleFunc("/v3/vulnerabilities/update.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		updateRequests++
+		mustWriteHTTPResponse(t, w, "testdata/vulnerability_update.json")
+	})
+
+	var packages []*meta.PackageIdentity
+	for _, p := range [][2]string{
+		{"Newtonsoft.Json", "12.0.1"},
+		{"Newtonsoft.Json", "13.0.1"},
+		{"System.Text.Encodings.Web", "4.5.0"},
+		{"Serilog", "3.1.1"},
+	} {
+		identity, err := meta.NewPackageIdentity(p[0], p[1])
+		require.NoError(t, err)
+		packages = append(packages, identity)
+	}
+	results, resp, err := client.VulnerabilityInfoResource.Audit(packages, withTestServer(client))
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.Len(t, results, len(packages))
+
+	require.Equal(t, "Newtonsoft.Json", results[0].Id)
+	require.True(t, results[0].Vulnerable())
+	require.Len(t, results[0].Advisories, 2)
+	require.Equal(t, "https://github.com/advisories/GHSA-5crp-9r3c-p9vr", results[0].Advisories[0].AdvisoryURL)
+	require.Equal(t, "https://github.com/advisories/GHSA-update", results[0].Advisories[1].AdvisoryURL)
+	require.Equal(t, VulnerabilitySeverityHigh, results[0].MaxSeverity())
+	require.Equal(t, "High", results[0].MaxSeverity().String())
+
+	require.False(t, results[1].Vulnerable())
+	require.Equal(t, VulnerabilitySeverity(-1), results[1].MaxSeverity())
+
+	require.Len(t, results[2].Advisories, 1)
+	require.Equal(t, VulnerabilitySeverityCritical, results[2].Advisories[0].Severity)
+	require.True(t, results[2].Advisories[0].VersionRange.Satisfies(nugetVersion.NewVersionFrom(4, 0, 0, "", "")))
+	require.False(t, results[3].Vulnerable())
+	require.Equal(t, 1, baseRequests)
+	require.Equal(t, 1, updateRequests)
+
+	// Unchanged files are served from memory, changed ones downloaded again.
+	_, _, err = client.VulnerabilityInfoResource.Audit(packages, withTestServer(client))
+	require.NoError(t, err)
+	require.Equal(t, 1, baseRequests)
+	require.Equal(t, 1, updateRequests)
+
+	index = "testdata/vulnerability_index_updated.json"
+	_, _, err = client.VulnerabilityInfoResource.Audit(packages, withTestServer(client))
+	require.NoError(t, err)
+	require.Equal(t, 1, baseRequests)
+	require.Equal(t, 2, updateRequests)
+}
+
+func TestVulnerabilityInfoResource_GetPage(t *testing.T) {
+	mux, client := setup(t, index_V3)
+	mux.HandleFunc("/v3/vulnerabilities/invalid.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		mustWriteHTTPResponse(t, w, "testdata/vulnerability_invalid.json")
+	})
+
+	_, _, err := client.VulnerabilityInfoResource.GetPage(&VulnerabilityFile{
+		URL: "http://localhost:5000/v3/vulnerabilities/invalid.json",
+	}, withTestServer(client))
+	require.ErrorContains(t, err, `invalid version range "[2.0.0" of test`)
+
+	_, _, err = client.VulnerabilityInfoResource.GetPage(&VulnerabilityFile{})
+	require.EqualError(t, err, "vulnerability file url is empty")
+}